@@ -51,6 +51,24 @@ func main() {
 		log.Info("WhatsApp connection status: %v", status.IsConnected)
 	}
 
+	// Jalankan pengingat bukti transaksi jika diaktifkan
+	if reminder := container.GetProofReminderService(); reminder != nil {
+		reminder.Start()
+		defer reminder.Stop()
+	}
+
+	// Siapkan spreadsheet baru setiap pergantian tahun jika partisi diaktifkan
+	if rollover := container.GetSpreadsheetRolloverService(); rollover != nil {
+		rollover.Start()
+		defer rollover.Stop()
+	}
+
+	// Catat cicilan yang jatuh tempo sebagai pengeluaran bulanan
+	if installments := container.GetInstallmentService(); installments != nil {
+		installments.Start()
+		defer installments.Stop()
+	}
+
 	// Setup signal handling for graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
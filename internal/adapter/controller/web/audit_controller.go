@@ -0,0 +1,185 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gwenziro/botopia/internal/domain/audit"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+// auditDefaultLimit adalah jumlah entri default yang ditampilkan di halaman audit
+const auditDefaultLimit = 200
+
+// AuditController adalah controller untuk melihat dan mengekspor audit log
+type AuditController struct {
+	auditService service.AuditService
+	log          *logger.Logger
+}
+
+// NewAuditController membuat instance controller baru
+func NewAuditController(auditService service.AuditService) *AuditController {
+	return &AuditController{
+		auditService: auditService,
+		log:          logger.New("AuditController", logger.INFO, true),
+	}
+}
+
+// HandleAuditPage menangani halaman audit log
+func (c *AuditController) HandleAuditPage(ctx *fiber.Ctx) error {
+	return ctx.Render("pages/audit", fiber.Map{
+		"Title": "Audit Log | Botopia",
+		"Page":  "audit",
+	}, "layouts/main")
+}
+
+// HandleGetAudit menangani API daftar entri audit beserta status keutuhan rantai
+func (c *AuditController) HandleGetAudit(ctx *fiber.Ctx) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	filter, err := parseAuditFilter(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	entries, err := c.auditService.List(timeoutCtx, filter)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal memuat audit log: " + err.Error(),
+		})
+	}
+
+	verify, err := c.auditService.Verify(timeoutCtx)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal memverifikasi audit log: " + err.Error(),
+		})
+	}
+
+	total := len(entries)
+	limit := ctx.QueryInt("limit", auditDefaultLimit)
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	return ctx.JSON(fiber.Map{
+		"entries": entries,
+		"total":   total,
+		"verify":  verify,
+	})
+}
+
+// HandleVerifyAudit menangani API verifikasi keutuhan audit log
+func (c *AuditController) HandleVerifyAudit(ctx *fiber.Ctx) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	verify, err := c.auditService.Verify(timeoutCtx)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal memverifikasi audit log: " + err.Error(),
+		})
+	}
+
+	return ctx.JSON(verify)
+}
+
+// HandleExportAudit menangani ekspor audit log dalam format CSV atau JSON Lines
+func (c *AuditController) HandleExportAudit(ctx *fiber.Ctx) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx.Context(), 30*time.Second)
+	defer cancel()
+
+	filter, err := parseAuditFilter(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	entries, err := c.auditService.List(timeoutCtx, filter)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal memuat audit log: " + err.Error(),
+		})
+	}
+
+	// Ekspor mengikuti urutan penulisan agar rantai hash mudah diperiksa ulang
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+
+	stamp := time.Now().Format("20060102-150405")
+	var buf bytes.Buffer
+
+	switch ctx.Query("format", "csv") {
+	case "jsonl":
+		encoder := json.NewEncoder(&buf)
+		for _, entry := range entries {
+			if err := encoder.Encode(entry); err != nil {
+				return ctx.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+			}
+		}
+		ctx.Set(fiber.HeaderContentType, "application/x-ndjson")
+		ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="audit-%s.jsonl"`, stamp))
+
+	case "csv":
+		writer := csv.NewWriter(&buf)
+		writer.Write([]string{"No", "Waktu", "Aksi", "Target", "Pelaku", "Chat", "Sebelum", "Sesudah", "Hash Sebelumnya", "Hash"})
+		for _, entry := range entries {
+			writer.Write([]string{
+				fmt.Sprintf("%d", entry.Sequence),
+				entry.Timestamp.Local().Format("2006-01-02 15:04:05"),
+				string(entry.Action),
+				entry.Target,
+				entry.Actor.String(),
+				entry.Actor.ChatID,
+				string(entry.Before),
+				string(entry.After),
+				entry.PrevHash,
+				entry.Hash,
+			})
+		}
+		writer.Flush()
+		ctx.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="audit-%s.csv"`, stamp))
+
+	default:
+		return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Format ekspor harus csv atau jsonl"})
+	}
+
+	return ctx.Send(buf.Bytes())
+}
+
+// parseAuditFilter membaca filter audit dari query string
+func parseAuditFilter(ctx *fiber.Ctx) (*audit.Filter, error) {
+	filter := &audit.Filter{
+		Action: audit.Action(ctx.Query("action")),
+		Target: ctx.Query("target"),
+		Actor:  ctx.Query("actor"),
+	}
+
+	if from := ctx.Query("from"); from != "" {
+		date, err := time.ParseInLocation("2006-01-02", from, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("tanggal awal tidak valid")
+		}
+		filter.From = date
+	}
+
+	if to := ctx.Query("to"); to != "" {
+		date, err := time.ParseInLocation("2006-01-02", to, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("tanggal akhir tidak valid")
+		}
+		// Sertakan seluruh hari terakhir
+		filter.To = date.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,231 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gwenziro/botopia/internal/domain/audit"
+	"github.com/gwenziro/botopia/internal/domain/dto"
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/infrastructure/config"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+// CategoryRuleController adalah controller untuk aturan kategorisasi otomatis
+type CategoryRuleController struct {
+	ruleService    service.CategoryRuleService
+	financeService service.FinanceService
+	config         *config.Config
+	log            *logger.Logger
+}
+
+// categoryRuleInput adalah payload aturan dari frontend
+type categoryRuleInput struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	Keywords      []string `json:"keywords"`
+	Type          string   `json:"type"`
+	Category      string   `json:"category"`
+	PaymentMethod string   `json:"paymentMethod"`
+	StorageMedia  string   `json:"storageMedia"`
+	Override      bool     `json:"override"`
+	Priority      int      `json:"priority"`
+	IsActive      bool     `json:"isActive"`
+}
+
+// toRule mengkonversi payload ke domain model
+func (i categoryRuleInput) toRule() *finance.CategoryRule {
+	return &finance.CategoryRule{
+		ID:            i.ID,
+		Name:          i.Name,
+		Keywords:      i.Keywords,
+		Type:          finance.RecordType(i.Type),
+		Category:      i.Category,
+		PaymentMethod: i.PaymentMethod,
+		StorageMedia:  i.StorageMedia,
+		Override:      i.Override,
+		Priority:      i.Priority,
+		IsActive:      i.IsActive,
+	}
+}
+
+// NewCategoryRuleController membuat instance controller baru
+func NewCategoryRuleController(
+	ruleService service.CategoryRuleService,
+	financeService service.FinanceService,
+	cfg *config.Config,
+) *CategoryRuleController {
+	return &CategoryRuleController{
+		ruleService:    ruleService,
+		financeService: financeService,
+		config:         cfg,
+		log:            logger.New("CategoryRuleController", logger.INFO, true),
+	}
+}
+
+// HandleRulesPage menangani halaman aturan kategori
+func (c *CategoryRuleController) HandleRulesPage(ctx *fiber.Ctx) error {
+	return ctx.Render("pages/category_rules", fiber.Map{
+		"Title": "Aturan Kategori | Botopia",
+		"Page":  "category-rules",
+	}, "layouts/main")
+}
+
+// HandleGetRules menangani API untuk mendapatkan daftar aturan beserta data master
+func (c *CategoryRuleController) HandleGetRules(ctx *fiber.Ctx) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+
+	rules, err := c.ruleService.GetAllRules(timeoutCtx)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal memuat aturan: " + err.Error(),
+		})
+	}
+
+	response := fiber.Map{"rules": rules}
+
+	// Sertakan data master agar form dapat menampilkan pilihan yang valid
+	if config, err := c.financeService.GetConfiguration(timeoutCtx); err == nil {
+		response["masterData"] = fiber.Map{
+			"expenseCategories": config.ExpenseCategories,
+			"incomeCategories":  config.IncomeCategories,
+			"paymentMethods":    config.PaymentMethods,
+			"storageMedias":     config.StorageMedias,
+		}
+	} else {
+		c.log.Warn("Gagal memuat data master untuk aturan kategori: %v", err)
+	}
+
+	return ctx.JSON(response)
+}
+
+// HandleAddRule menangani API untuk menambahkan aturan
+func (c *CategoryRuleController) HandleAddRule(ctx *fiber.Ctx) error {
+	var input categoryRuleInput
+	if err := ctx.BodyParser(&input); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error": "Format data tidak valid",
+		})
+	}
+
+	// Actor dashboard dicatat agar perubahan aturan dapat dibatalkan dari halaman aturan
+	timeoutCtx, cancel := context.WithTimeout(audit.WithActor(context.Background(), dashboardActor(ctx, c.config)), 5*time.Second)
+	defer cancel()
+
+	rule, err := c.ruleService.AddRule(timeoutCtx, input.toRule())
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error": "Gagal menambahkan aturan: " + err.Error(),
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"success": true,
+		"rule":    rule,
+	})
+}
+
+// HandleUpdateRule menangani API untuk memperbarui aturan
+func (c *CategoryRuleController) HandleUpdateRule(ctx *fiber.Ctx) error {
+	var input categoryRuleInput
+	if err := ctx.BodyParser(&input); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error": "Format data tidak valid",
+		})
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(audit.WithActor(context.Background(), dashboardActor(ctx, c.config)), 5*time.Second)
+	defer cancel()
+
+	rule, err := c.ruleService.UpdateRule(timeoutCtx, input.toRule())
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error": "Gagal memperbarui aturan: " + err.Error(),
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"success": true,
+		"rule":    rule,
+	})
+}
+
+// HandleDeleteRule menangani API untuk menghapus aturan
+func (c *CategoryRuleController) HandleDeleteRule(ctx *fiber.Ctx) error {
+	var input struct {
+		ID string `json:"id"`
+	}
+
+	if err := ctx.BodyParser(&input); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error": "Format data tidak valid",
+		})
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(audit.WithActor(context.Background(), dashboardActor(ctx, c.config)), 5*time.Second)
+	defer cancel()
+
+	if err := c.ruleService.DeleteRule(timeoutCtx, input.ID); err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menghapus aturan: " + err.Error(),
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"success": true,
+	})
+}
+
+// HandleTestRule menangani API untuk menguji aturan terhadap riwayat transaksi
+func (c *CategoryRuleController) HandleTestRule(ctx *fiber.Ctx) error {
+	var input categoryRuleInput
+	if err := ctx.BodyParser(&input); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error": "Format data tidak valid",
+		})
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx.Context(), 30*time.Second)
+	defer cancel()
+
+	result, err := c.ruleService.TestRule(timeoutCtx, input.toRule())
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menguji aturan: " + err.Error(),
+		})
+	}
+
+	samples := make([]*dto.FinanceRecordDTO, 0, len(result.Samples))
+	for _, record := range result.Samples {
+		samples = append(samples, dto.FromFinanceRecord(record))
+	}
+
+	return ctx.JSON(fiber.Map{
+		"success":      true,
+		"totalRecords": result.TotalRecords,
+		"matchCount":   result.MatchCount,
+		"changedCount": result.ChangedCount,
+		"samples":      samples,
+	})
+}
+
+// HandleGetSuggestions menangani API untuk mendapatkan saran aturan dari riwayat transaksi
+func (c *CategoryRuleController) HandleGetSuggestions(ctx *fiber.Ctx) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx.Context(), 30*time.Second)
+	defer cancel()
+
+	suggestions, err := c.ruleService.SuggestRules(timeoutCtx, ctx.QueryInt("minCount", 3))
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal memuat saran aturan: " + err.Error(),
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"suggestions": suggestions,
+	})
+}
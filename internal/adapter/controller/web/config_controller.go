@@ -39,6 +39,8 @@ func (c *ConfigController) HandleGetConfig(ctx *fiber.Ctx) error {
 		"spreadsheetId":   c.config.GoogleSheets.SpreadsheetID,
 		"driveFolderId":   c.config.GoogleSheets.DriveFolderID,
 		"credentialsFile": c.config.GoogleSheets.CredentialsFile,
+		"rolloverYears":   c.config.GoogleSheets.RolloverYears,
+		"templateSheetId": c.config.GoogleSheets.TemplateSpreadsheetID,
 	})
 }
 
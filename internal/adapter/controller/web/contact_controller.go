@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gwenziro/botopia/internal/domain/finance"
 	"github.com/gwenziro/botopia/internal/domain/service"
 	"github.com/gwenziro/botopia/internal/infrastructure/logger"
 )
@@ -13,13 +14,15 @@ import (
 // ContactController adalah controller untuk manajemen kontak
 type ContactController struct {
 	contactService service.ContactService
+	ledgerService  service.LedgerService
 	log            *logger.Logger
 }
 
 // NewContactController membuat instance contact controller baru
-func NewContactController(contactService service.ContactService) *ContactController {
+func NewContactController(contactService service.ContactService, ledgerService service.LedgerService) *ContactController {
 	return &ContactController{
 		contactService: contactService,
+		ledgerService:  ledgerService,
 		log:            logger.New("ContactController", logger.INFO, true),
 	}
 }
@@ -191,3 +194,109 @@ func (c *ContactController) HandleSetWhitelistStatus(ctx *fiber.Ctx) error {
 		"success": true,
 	})
 }
+
+// HandleGetLedgers menangani API untuk mendapatkan daftar ledger
+func (c *ContactController) HandleGetLedgers(ctx *fiber.Ctx) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx.Context(), 5*time.Second)
+	defer cancel()
+
+	ledgers, err := c.ledgerService.List(timeoutCtx)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal mendapatkan ledger: " + err.Error(),
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"ledgers": ledgers,
+	})
+}
+
+// HandleSaveLedger menangani API untuk menambah atau memperbarui ledger
+func (c *ContactController) HandleSaveLedger(ctx *fiber.Ctx) error {
+	var input struct {
+		ID            string `json:"id"`
+		Name          string `json:"name"`
+		SpreadsheetID string `json:"spreadsheetId"`
+		DriveFolderID string `json:"driveFolderId"`
+	}
+
+	if err := ctx.BodyParser(&input); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error": "Format data tidak valid",
+		})
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx.Context(), 5*time.Second)
+	defer cancel()
+
+	ledger, err := c.ledgerService.Save(timeoutCtx, &finance.Ledger{
+		ID:            input.ID,
+		Name:          input.Name,
+		SpreadsheetID: input.SpreadsheetID,
+		DriveFolderID: input.DriveFolderID,
+	})
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error": "Gagal menyimpan ledger: " + err.Error(),
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"success": true,
+		"ledger":  ledger,
+	})
+}
+
+// HandleDeleteLedger menangani API untuk menghapus ledger
+func (c *ContactController) HandleDeleteLedger(ctx *fiber.Ctx) error {
+	var input struct {
+		ID string `json:"id"`
+	}
+
+	if err := ctx.BodyParser(&input); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error": "Format data tidak valid",
+		})
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := c.ledgerService.Delete(timeoutCtx, input.ID); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error": "Gagal menghapus ledger: " + err.Error(),
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"success": true,
+	})
+}
+
+// HandleAssignLedger menangani API untuk mengarahkan kontak atau grup ke ledger
+func (c *ContactController) HandleAssignLedger(ctx *fiber.Ctx) error {
+	var input struct {
+		Phone    string `json:"phone"`
+		LedgerID string `json:"ledgerId"`
+	}
+
+	if err := ctx.BodyParser(&input); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error": "Format data tidak valid",
+		})
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := c.ledgerService.Assign(timeoutCtx, input.Phone, input.LedgerID); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error": "Gagal mengatur ledger kontak: " + err.Error(),
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"success": true,
+	})
+}
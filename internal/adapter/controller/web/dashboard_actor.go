@@ -0,0 +1,15 @@
+package web
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gwenziro/botopia/internal/domain/audit"
+	"github.com/gwenziro/botopia/internal/infrastructure/config"
+)
+
+// dashboardActor menentukan actor audit dan pemilik jurnal aksi untuk perubahan dari halaman web
+func dashboardActor(ctx *fiber.Ctx, cfg *config.Config) audit.Actor {
+	if cfg.WebAuthEnabled && ctx.Cookies("authenticated") == "true" {
+		return audit.Actor{Channel: audit.ChannelWeb, Name: cfg.WebAuthUsername}
+	}
+	return audit.Actor{Channel: audit.ChannelWeb, Name: "dashboard"}
+}
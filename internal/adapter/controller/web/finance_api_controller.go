@@ -0,0 +1,619 @@
+package web
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gwenziro/botopia/internal/domain/audit"
+	domainErrors "github.com/gwenziro/botopia/internal/domain/errors"
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/money"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/infrastructure/config"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+	"github.com/gwenziro/botopia/internal/utils"
+)
+
+const (
+	// financeAPIDefaultLimit adalah jumlah record default per halaman
+	financeAPIDefaultLimit = 50
+
+	// financeAPIMaxLimit adalah jumlah record maksimum per halaman
+	financeAPIMaxLimit = 500
+
+	// financeAPIDateLayout adalah format tanggal pada request dan response API
+	financeAPIDateLayout = "2006-01-02"
+
+	// financeActorLocal kunci fiber locals untuk actor audit dari request
+	financeActorLocal = "financeActor"
+
+	// financeAdminLocal kunci fiber locals yang menandai request dari admin yang login di dashboard
+	financeAdminLocal = "financeAdmin"
+)
+
+// FinanceRecordResponse representasi JSON record keuangan
+type FinanceRecordResponse struct {
+	Number        int                   `json:"number"`
+	Code          string                `json:"code"`
+	Type          finance.RecordType    `json:"type"`
+	Date          string                `json:"date"`
+	Description   string                `json:"description"`
+	Amount        money.Money           `json:"amount"`
+	Category      string                `json:"category"`
+	PaymentMethod string                `json:"paymentMethod,omitempty"`
+	StorageMedia  string                `json:"storageMedia"`
+	Notes         string                `json:"notes"`
+	ProofURL      string                `json:"proofUrl,omitempty"`
+	Tags          []string              `json:"tags"`
+	Attachments   []*finance.Attachment `json:"attachments,omitempty"`
+}
+
+// FinanceRecordRequest isi request untuk membuat atau memperbarui record
+type FinanceRecordRequest struct {
+	Type          finance.RecordType `json:"type"`
+	Date          string             `json:"date"`
+	Description   string             `json:"description"`
+	Amount        money.Money        `json:"amount"`
+	Category      string             `json:"category"`
+	PaymentMethod string             `json:"paymentMethod"`
+	StorageMedia  string             `json:"storageMedia"`
+	Notes         string             `json:"notes"`
+}
+
+// FinanceAPIController adalah controller REST API untuk record keuangan
+type FinanceAPIController struct {
+	financeService service.FinanceService
+	config         *config.Config
+	log            *logger.Logger
+
+	// approvals nil jika persetujuan pengeluaran tidak diaktifkan
+	approvals service.ApprovalService
+}
+
+// NewFinanceAPIController membuat instance controller baru
+func NewFinanceAPIController(financeService service.FinanceService, approvals service.ApprovalService, cfg *config.Config) *FinanceAPIController {
+	return &FinanceAPIController{
+		financeService: financeService,
+		approvals:      approvals,
+		config:         cfg,
+		log:            logger.New("FinanceAPIController", logger.INFO, true),
+	}
+}
+
+// HandleFinancePage menangani halaman daftar transaksi
+func (c *FinanceAPIController) HandleFinancePage(ctx *fiber.Ctx) error {
+	return ctx.Render("pages/finance", fiber.Map{
+		"Title": "Transaksi | Botopia",
+		"Page":  "finance",
+
+		// Tanpa login web, halaman meminta API key sebelum memanggil REST API
+		"RequireAPIKey": !c.config.WebAuthEnabled,
+		"HasAPIKey":     c.config.FinanceAPIKey != "",
+	}, "layouts/main")
+}
+
+// Authenticate middleware yang menerima API key atau sesi login web.
+// Tanpa login web, dashboard juga harus mengirim API key karena header request dapat dipalsukan siapa pun.
+// Override periode tertutup hanya diterima dari admin yang login di dashboard.
+func (c *FinanceAPIController) Authenticate(ctx *fiber.Ctx) error {
+	admin := false
+	switch {
+	case c.validAPIKey(ctx):
+		ctx.Locals(financeActorLocal, audit.Actor{Channel: audit.ChannelWeb, Name: "api"})
+
+	case c.config.WebAuthEnabled && ctx.Cookies("authenticated") == "true":
+		ctx.Locals(financeActorLocal, audit.Actor{Channel: audit.ChannelWeb, Name: c.config.WebAuthUsername})
+		admin = true
+
+	default:
+		c.log.Warn("Akses API keuangan ditolak dari %s", ctx.IP())
+		return ctx.Status(http.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Akses ditolak: API key tidak valid atau belum login",
+		})
+	}
+
+	if ctx.Query("override") == "true" && !admin {
+		c.log.Warn("Override periode tertutup ditolak dari %s", ctx.IP())
+		return ctx.Status(http.StatusForbidden).JSON(fiber.Map{
+			"error": "Perubahan periode tertutup hanya dapat dilakukan admin yang login di dashboard",
+		})
+	}
+
+	ctx.Locals(financeAdminLocal, admin)
+	return ctx.Next()
+}
+
+// validAPIKey memeriksa API key request terhadap kunci yang dikonfigurasi
+func (c *FinanceAPIController) validAPIKey(ctx *fiber.Ctx) bool {
+	key := c.requestAPIKey(ctx)
+	return key != "" && c.config.FinanceAPIKey != "" &&
+		subtle.ConstantTimeCompare([]byte(key), []byte(c.config.FinanceAPIKey)) == 1
+}
+
+// HandleListRecords menangani API daftar record dengan filter dan paginasi
+func (c *FinanceAPIController) HandleListRecords(ctx *fiber.Ctx) error {
+	filter, err := parseRecordFilter(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	page := ctx.QueryInt("page", 1)
+	limit := ctx.QueryInt("limit", financeAPIDefaultLimit)
+	if page < 1 || limit < 1 || limit > financeAPIMaxLimit {
+		return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("Parameter page minimal 1 dan limit antara 1 dan %d", financeAPIMaxLimit),
+		})
+	}
+
+	timeoutCtx, cancel := c.requestContext(ctx, 30*time.Second)
+	defer cancel()
+
+	records, err := c.financeService.ListRecords(timeoutCtx, filter)
+	if err != nil {
+		c.log.Error("Gagal memuat record keuangan: %v", err)
+		return ctx.Status(http.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal memuat record: " + err.Error(),
+		})
+	}
+
+	if err := sortRecords(records, ctx.Query("sort", "date"), ctx.Query("order", "desc")); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	// Total dihitung dari seluruh record yang memenuhi filter, bukan hanya halaman ini
+	var totalIncome, totalExpense money.Money
+	for _, record := range records {
+		if record.Type == finance.TypeIncome {
+			totalIncome = totalIncome.Add(record.Amount)
+		} else {
+			totalExpense = totalExpense.Add(record.Amount)
+		}
+	}
+
+	total := len(records)
+	start := (page - 1) * limit
+	if start > total {
+		start = total
+	}
+	end := start + limit
+	if end > total {
+		end = total
+	}
+
+	items := make([]*FinanceRecordResponse, 0, end-start)
+	for _, record := range records[start:end] {
+		items = append(items, newFinanceRecordResponse(record))
+	}
+
+	return ctx.JSON(fiber.Map{
+		"records": items,
+		"total":   total,
+		"page":    page,
+		"limit":   limit,
+		"totals": fiber.Map{
+			"income":  totalIncome,
+			"expense": totalExpense,
+			"net":     totalIncome.Sub(totalExpense),
+		},
+	})
+}
+
+// HandleGetRecord menangani API detail record berdasarkan kode
+func (c *FinanceAPIController) HandleGetRecord(ctx *fiber.Ctx) error {
+	timeoutCtx, cancel := c.requestContext(ctx, 30*time.Second)
+	defer cancel()
+
+	record, err := c.financeService.GetRecordByCode(timeoutCtx, ctx.Params("code"))
+	if err != nil {
+		return ctx.Status(recordErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.JSON(newFinanceRecordResponse(record))
+}
+
+// HandleGetOptions menangani API daftar kategori, metode pembayaran, dan media penyimpanan
+func (c *FinanceAPIController) HandleGetOptions(ctx *fiber.Ctx) error {
+	timeoutCtx, cancel := c.requestContext(ctx, 30*time.Second)
+	defer cancel()
+
+	config, err := c.financeService.GetConfiguration(timeoutCtx)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal memuat konfigurasi: " + err.Error(),
+		})
+	}
+
+	// Tag tidak punya daftar master, dikumpulkan dari transaksi yang sudah tercatat
+	records, err := c.financeService.ListRecords(timeoutCtx, nil)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal memuat tag: " + err.Error(),
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"expenseCategories": nonNilStrings(config.ExpenseCategories),
+		"incomeCategories":  nonNilStrings(config.IncomeCategories),
+		"paymentMethods":    nonNilStrings(config.PaymentMethods),
+		"storageMedias":     nonNilStrings(config.StorageMedias),
+		"tags":              nonNilStrings(finance.CollectTags(records)),
+		"canOverride":       ctx.Locals(financeAdminLocal) == true,
+	})
+}
+
+// HandleGetProof menyajikan isi lampiran bukti ke-index untuk pratinjau
+func (c *FinanceAPIController) HandleGetProof(ctx *fiber.Ctx) error {
+	index, err := ctx.ParamsInt("index")
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Indeks lampiran tidak valid"})
+	}
+
+	timeoutCtx, cancel := c.requestContext(ctx, 60*time.Second)
+	defer cancel()
+
+	record, err := c.financeService.GetRecordByCode(timeoutCtx, ctx.Params("code"))
+	if err != nil {
+		return ctx.Status(recordErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	// Bukti lama yang belum tercatat di sheet Lampiran tetap dapat ditampilkan
+	attachments := record.Attachments
+	if len(attachments) == 0 && record.HasProof() {
+		attachments = []*finance.Attachment{{
+			RecordCode: record.UniqueCode,
+			FileName:   "bukti-awal",
+			MimeType:   finance.MimeTypeFromPath(record.ProofURL),
+			URL:        record.ProofURL,
+		}}
+	}
+	if index < 0 || index >= len(attachments) {
+		return ctx.Status(http.StatusNotFound).JSON(fiber.Map{"error": "Lampiran tidak ditemukan"})
+	}
+
+	attachment := attachments[index]
+	data, err := c.financeService.DownloadAttachment(timeoutCtx, attachment)
+	if err != nil {
+		c.log.Error("Gagal mengunduh lampiran %s: %v", attachment.URL, err)
+		return ctx.Status(http.StatusBadGateway).JSON(fiber.Map{"error": "Gagal mengunduh lampiran: " + err.Error()})
+	}
+
+	ctx.Set(fiber.HeaderContentType, attachment.MimeType)
+	ctx.Set(fiber.HeaderCacheControl, "private, max-age=300")
+	return ctx.Send(data)
+}
+
+// HandleCreateRecord menangani API pencatatan transaksi baru.
+// Transaksi yang mirip dengan record tersimpan ditolak dengan 409 kecuali dikirim ulang dengan allowDuplicate=true,
+// dan pengeluaran yang mencapai batas persetujuan diajukan ke approver dengan status 202.
+func (c *FinanceAPIController) HandleCreateRecord(ctx *fiber.Ctx) error {
+	var req FinanceRecordRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Format request tidak valid"})
+	}
+
+	date := time.Now()
+	if req.Date != "" {
+		parsed, err := time.ParseInLocation(financeAPIDateLayout, req.Date, time.Local)
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Format tanggal harus YYYY-MM-DD"})
+		}
+		date = parsed
+	}
+
+	if req.Type != finance.TypeExpense && req.Type != finance.TypeIncome {
+		return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Tipe harus income atau expense"})
+	}
+
+	timeoutCtx, cancel := c.requestContext(ctx, 60*time.Second)
+	defer cancel()
+
+	draft := &finance.FinanceRecord{
+		Type:          req.Type,
+		Date:          date,
+		Description:   req.Description,
+		Amount:        req.Amount,
+		Category:      req.Category,
+		PaymentMethod: req.PaymentMethod,
+		StorageMedia:  req.StorageMedia,
+		Notes:         req.Notes,
+	}
+	if draft.Notes == "" {
+		draft.Notes = "-"
+	}
+
+	// Lengkapi field yang kosong menggunakan aturan kategori, lalu validasi sebelum cek duplikat dan persetujuan
+	_ = c.financeService.ApplyCategoryRules(timeoutCtx, draft)
+	if err := c.validateDraft(timeoutCtx, draft); err != nil {
+		return ctx.Status(recordErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if ctx.Query("allowDuplicate") != "true" {
+		duplicates, err := c.financeService.FindDuplicateRecords(timeoutCtx, draft)
+		if err == nil && len(duplicates) > 0 {
+			responses := make([]*FinanceRecordResponse, 0, len(duplicates))
+			for _, duplicate := range duplicates {
+				responses = append(responses, newFinanceRecordResponse(duplicate))
+			}
+			return ctx.Status(http.StatusConflict).JSON(fiber.Map{
+				"error":      "Transaksi serupa sudah tercatat. Kirim ulang dengan allowDuplicate=true jika memang berbeda",
+				"duplicates": responses,
+			})
+		}
+	}
+
+	// Pengeluaran besar menunggu persetujuan approver sebelum dicatat
+	if c.approvals != nil && c.approvals.RequiresApproval(draft, "") {
+		request, err := c.approvals.Submit(timeoutCtx, draft, "", nil)
+		if err != nil {
+			return ctx.Status(http.StatusInternalServerError).JSON(fiber.Map{
+				"error": fmt.Sprintf("Gagal mengajukan persetujuan pengeluaran: %v", err),
+			})
+		}
+		return ctx.Status(http.StatusAccepted).JSON(fiber.Map{
+			"approvalCode": request.Code,
+			"status":       request.Status,
+			"message": fmt.Sprintf("Pengeluaran mencapai batas Rp %s dan menunggu persetujuan approver",
+				utils.FormatMoney(c.approvals.GetThreshold())),
+		})
+	}
+
+	var record *finance.FinanceRecord
+	var err error
+	if draft.Type == finance.TypeExpense {
+		record, err = c.financeService.AddExpenseWithDate(timeoutCtx, draft.Date, draft.Description, draft.Amount,
+			draft.Category, draft.PaymentMethod, draft.StorageMedia, draft.Notes, "")
+	} else {
+		record, err = c.financeService.AddIncomeWithDate(timeoutCtx, draft.Date, draft.Description, draft.Amount,
+			draft.Category, draft.StorageMedia, draft.Notes, "")
+	}
+	if err != nil {
+		return ctx.Status(recordErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(newFinanceRecordResponse(record))
+}
+
+// validateDraft memeriksa draft transaksi terhadap konfigurasi dan aturan record
+func (c *FinanceAPIController) validateDraft(ctx context.Context, draft *finance.FinanceRecord) error {
+	var err error
+	if draft.Type == finance.TypeExpense {
+		err = c.financeService.ValidateAddExpenseParams(ctx, draft.Category, draft.PaymentMethod, draft.StorageMedia)
+	} else {
+		err = c.financeService.ValidateAddIncomeParams(ctx, draft.Category, draft.StorageMedia)
+	}
+	if err != nil {
+		return err
+	}
+	return draft.Validate()
+}
+
+// HandleUpdateRecord menangani API perubahan data transaksi
+func (c *FinanceAPIController) HandleUpdateRecord(ctx *fiber.Ctx) error {
+	var req FinanceRecordRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Format request tidak valid"})
+	}
+
+	date, err := time.ParseInLocation(financeAPIDateLayout, req.Date, time.Local)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Format tanggal harus YYYY-MM-DD"})
+	}
+
+	timeoutCtx, cancel := c.requestContext(ctx, 60*time.Second)
+	defer cancel()
+
+	record, err := c.financeService.UpdateRecord(timeoutCtx, &finance.FinanceRecord{
+		UniqueCode:    ctx.Params("code"),
+		Date:          date,
+		Description:   req.Description,
+		Amount:        req.Amount,
+		Category:      req.Category,
+		PaymentMethod: req.PaymentMethod,
+		StorageMedia:  req.StorageMedia,
+		Notes:         req.Notes,
+	})
+	if err != nil {
+		return ctx.Status(recordErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.JSON(newFinanceRecordResponse(record))
+}
+
+// HandleDeleteRecord menangani API penghapusan transaksi
+func (c *FinanceAPIController) HandleDeleteRecord(ctx *fiber.Ctx) error {
+	timeoutCtx, cancel := c.requestContext(ctx, 60*time.Second)
+	defer cancel()
+
+	code := ctx.Params("code")
+	if err := c.financeService.DeleteRecord(timeoutCtx, code); err != nil {
+		return ctx.Status(recordErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"success": true,
+		"message": fmt.Sprintf("Transaksi %s berhasil dihapus", code),
+	})
+}
+
+// HandleUploadProof menangani API unggah bukti transaksi (multipart field "file").
+// Kirim field replace=true untuk mengganti seluruh bukti yang ada.
+func (c *FinanceAPIController) HandleUploadProof(ctx *fiber.Ctx) error {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "File bukti wajib dikirim pada field 'file'"})
+	}
+
+	// Simpan ke file sementara dengan ekstensi asli agar tipe MIME terdeteksi
+	tempFile, err := os.CreateTemp("", "botopia-proof-*"+strings.ToLower(filepath.Ext(fileHeader.Filename)))
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": "Gagal menyiapkan file sementara"})
+	}
+	tempPath := tempFile.Name()
+	tempFile.Close()
+	defer os.Remove(tempPath)
+
+	if err := ctx.SaveFile(fileHeader, tempPath); err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": "Gagal menyimpan file bukti"})
+	}
+
+	timeoutCtx, cancel := c.requestContext(ctx, 120*time.Second)
+	defer cancel()
+
+	code := ctx.Params("code")
+	var record *finance.FinanceRecord
+	if ctx.FormValue("replace") == "true" {
+		record, err = c.financeService.ReplaceTransactionProof(timeoutCtx, code, tempPath)
+	} else {
+		record, err = c.financeService.UploadTransactionProof(timeoutCtx, code, tempPath)
+	}
+	if err != nil {
+		return ctx.Status(recordErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.JSON(newFinanceRecordResponse(record))
+}
+
+// requestContext membuat context dengan batas waktu dan actor audit dari request.
+// Query override=true dari admin mengizinkan perubahan pada periode yang sudah tutup buku (tercatat di audit log).
+func (c *FinanceAPIController) requestContext(ctx *fiber.Ctx, timeout time.Duration) (context.Context, context.CancelFunc) {
+	baseCtx := context.Background()
+	if actor, ok := ctx.Locals(financeActorLocal).(audit.Actor); ok {
+		baseCtx = audit.WithActor(baseCtx, actor)
+	}
+	if admin, _ := ctx.Locals(financeAdminLocal).(bool); admin && ctx.Query("override") == "true" {
+		baseCtx = finance.WithPeriodOverride(baseCtx)
+	}
+	return context.WithTimeout(baseCtx, timeout)
+}
+
+// requestAPIKey membaca API key dari header Authorization (Bearer) atau X-API-Key
+func (c *FinanceAPIController) requestAPIKey(ctx *fiber.Ctx) string {
+	if header := ctx.Get(fiber.HeaderAuthorization); strings.HasPrefix(header, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(header, "Bearer "))
+	}
+	return strings.TrimSpace(ctx.Get("X-API-Key"))
+}
+
+// parseRecordFilter membaca filter record dari query string
+func parseRecordFilter(ctx *fiber.Ctx) (*finance.RecordFilter, error) {
+	filter := &finance.RecordFilter{
+		Type:         finance.RecordType(ctx.Query("type")),
+		Category:     ctx.Query("category"),
+		StorageMedia: ctx.Query("media"),
+		Tag:          finance.NormalizeTag(ctx.Query("tag")),
+	}
+
+	if filter.Type != "" && filter.Type != finance.TypeIncome && filter.Type != finance.TypeExpense {
+		return nil, fmt.Errorf("tipe harus income atau expense")
+	}
+
+	if from := ctx.Query("from"); from != "" {
+		date, err := time.ParseInLocation(financeAPIDateLayout, from, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("tanggal awal tidak valid")
+		}
+		filter.From = date
+	}
+
+	if to := ctx.Query("to"); to != "" {
+		date, err := time.ParseInLocation(financeAPIDateLayout, to, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("tanggal akhir tidak valid")
+		}
+		// Sertakan seluruh hari terakhir
+		filter.To = date.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	return filter, nil
+}
+
+// sortRecords mengurutkan record berdasarkan kolom dan arah urutan
+func sortRecords(records []*finance.FinanceRecord, field, order string) error {
+	var less func(a, b *finance.FinanceRecord) bool
+	switch field {
+	case "date":
+		less = func(a, b *finance.FinanceRecord) bool { return a.Date.Before(b.Date) }
+	case "amount":
+		less = func(a, b *finance.FinanceRecord) bool { return a.Amount.LessThan(b.Amount) }
+	case "description":
+		less = func(a, b *finance.FinanceRecord) bool {
+			return strings.ToLower(a.Description) < strings.ToLower(b.Description)
+		}
+	case "category":
+		less = func(a, b *finance.FinanceRecord) bool {
+			return strings.ToLower(a.Category) < strings.ToLower(b.Category)
+		}
+	case "media":
+		less = func(a, b *finance.FinanceRecord) bool {
+			return strings.ToLower(a.StorageMedia) < strings.ToLower(b.StorageMedia)
+		}
+	default:
+		return fmt.Errorf("kolom urutan harus date, amount, description, category, atau media")
+	}
+
+	switch order {
+	case "asc":
+	case "desc":
+		ascending := less
+		less = func(a, b *finance.FinanceRecord) bool { return ascending(b, a) }
+	default:
+		return fmt.Errorf("arah urutan harus asc atau desc")
+	}
+
+	// Urutan stabil mempertahankan urutan terbaru untuk nilai yang sama
+	sort.SliceStable(records, func(i, j int) bool { return less(records[i], records[j]) })
+	return nil
+}
+
+// nonNilStrings memastikan slice tidak nil agar diserialisasi sebagai array kosong
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// recordErrorStatus memetakan error layanan keuangan ke status HTTP
+func recordErrorStatus(err error) int {
+	var periodClosed domainErrors.PeriodClosedError
+	var notFound domainErrors.RecordNotFoundError
+	var validation domainErrors.ValidationError
+	switch {
+	case errors.As(err, &periodClosed):
+		return http.StatusConflict
+	case errors.As(err, &notFound):
+		return http.StatusNotFound
+	case errors.As(err, &validation):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// newFinanceRecordResponse mengubah record menjadi representasi JSON
+func newFinanceRecordResponse(record *finance.FinanceRecord) *FinanceRecordResponse {
+	return &FinanceRecordResponse{
+		Number:        record.Number,
+		Code:          record.UniqueCode,
+		Type:          record.Type,
+		Date:          record.Date.Format(financeAPIDateLayout),
+		Description:   record.Description,
+		Amount:        record.Amount,
+		Category:      record.Category,
+		PaymentMethod: record.PaymentMethod,
+		StorageMedia:  record.StorageMedia,
+		Notes:         record.Notes,
+		ProofURL:      record.ProofURL,
+		Tags:          nonNilStrings(record.Tags),
+		Attachments:   record.Attachments,
+	}
+}
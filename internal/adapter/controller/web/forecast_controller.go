@@ -0,0 +1,49 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+// ForecastController adalah controller untuk proyeksi arus kas
+type ForecastController struct {
+	forecastService service.ForecastService
+	log             *logger.Logger
+}
+
+// NewForecastController membuat instance controller baru
+func NewForecastController(forecastService service.ForecastService) *ForecastController {
+	return &ForecastController{
+		forecastService: forecastService,
+		log:             logger.New("ForecastController", logger.INFO, true),
+	}
+}
+
+// HandleGetForecast menangani API proyeksi saldo per media penyimpanan
+func (c *ForecastController) HandleGetForecast(ctx *fiber.Ctx) error {
+	months := ctx.QueryInt("months", finance.DefaultForecastMonths)
+	if months < 0 || months > finance.MaxForecastMonths {
+		return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error": "Parameter months harus antara 0 dan 12",
+		})
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx.Context(), 60*time.Second)
+	defer cancel()
+
+	forecast, err := c.forecastService.GetForecast(timeoutCtx, months)
+	if err != nil {
+		c.log.Error("Gagal membuat proyeksi arus kas: %v", err)
+		return ctx.Status(http.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal membuat proyeksi: " + err.Error(),
+		})
+	}
+
+	return ctx.JSON(forecast)
+}
@@ -0,0 +1,40 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+// signedProofResolver memverifikasi URL bertanda tangan dan mengembalikan path file bukti
+type signedProofResolver interface {
+	ResolveSignedFile(name, expires, signature string) (string, error)
+}
+
+// ProofController menyajikan file bukti yang disimpan di penyimpanan lokal
+type ProofController struct {
+	resolver signedProofResolver
+	log      *logger.Logger
+}
+
+// NewProofController membuat instance controller baru
+func NewProofController(resolver signedProofResolver) *ProofController {
+	return &ProofController{
+		resolver: resolver,
+		log:      logger.New("ProofController", logger.INFO, true),
+	}
+}
+
+// HandleGetProof menyajikan file bukti jika tanda tangan URL valid
+func (c *ProofController) HandleGetProof(ctx *fiber.Ctx) error {
+	path, err := c.resolver.ResolveSignedFile(ctx.Params("name"), ctx.Query("exp"), ctx.Query("sig"))
+	if err != nil {
+		c.log.Warn("Akses bukti ditolak untuk %s: %v", ctx.Params("name"), err)
+		return ctx.Status(http.StatusForbidden).JSON(fiber.Map{
+			"error": "Akses bukti ditolak: " + err.Error(),
+		})
+	}
+
+	return ctx.SendFile(path)
+}
@@ -0,0 +1,60 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gwenziro/botopia/internal/domain/audit"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/infrastructure/config"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+// UndoController adalah controller pembatalan aksi terakhir dari dashboard,
+// padanan !batal untuk perubahan yang dilakukan lewat halaman web
+type UndoController struct {
+	undoService service.UndoService
+	config      *config.Config
+	log         *logger.Logger
+}
+
+// NewUndoController membuat instance controller baru
+func NewUndoController(undoService service.UndoService, cfg *config.Config) *UndoController {
+	return &UndoController{
+		undoService: undoService,
+		config:      cfg,
+		log:         logger.New("UndoController", logger.INFO, true),
+	}
+}
+
+// HandleUndo menangani API pembatalan aksi terakhir milik pengguna dashboard
+func (c *UndoController) HandleUndo(ctx *fiber.Ctx) error {
+	actor := dashboardActor(ctx, c.config)
+
+	timeoutCtx, cancel := context.WithTimeout(audit.WithActor(context.Background(), actor), 60*time.Second)
+	defer cancel()
+
+	entry, err := c.undoService.Undo(timeoutCtx, actor.String())
+	if errors.Is(err, service.ErrNothingToUndo) {
+		return ctx.Status(http.StatusNotFound).JSON(fiber.Map{
+			"error": fmt.Sprintf("Tidak ada aksi yang dapat dibatalkan dalam %d menit terakhir", int(c.undoService.GetWindow().Minutes())),
+		})
+	}
+	if err != nil {
+		c.log.Error("Gagal membatalkan aksi %s: %v", actor, err)
+		return ctx.Status(http.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal membatalkan aksi: " + err.Error(),
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"success":     true,
+		"kind":        entry.Kind,
+		"description": entry.Description,
+		"message":     "Aksi terakhir dibatalkan: " + entry.Description,
+	})
+}
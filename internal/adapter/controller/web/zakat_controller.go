@@ -0,0 +1,94 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gwenziro/botopia/internal/domain/audit"
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/infrastructure/config"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+// ZakatController adalah controller untuk laporan zakat dan pajak tahunan
+type ZakatController struct {
+	zakatService service.ZakatService
+	config       *config.Config
+	log          *logger.Logger
+}
+
+// NewZakatController membuat instance controller baru
+func NewZakatController(zakatService service.ZakatService, cfg *config.Config) *ZakatController {
+	return &ZakatController{
+		zakatService: zakatService,
+		config:       cfg,
+		log:          logger.New("ZakatController", logger.INFO, true),
+	}
+}
+
+// HandleReportPage menangani halaman laporan tahunan
+func (c *ZakatController) HandleReportPage(ctx *fiber.Ctx) error {
+	return ctx.Render("pages/laporan", fiber.Map{
+		"Title": "Laporan Tahunan | Botopia",
+		"Page":  "laporan",
+	}, "layouts/main")
+}
+
+// HandleGetReport menangani API laporan zakat dan pajak untuk satu tahun
+func (c *ZakatController) HandleGetReport(ctx *fiber.Ctx) error {
+	year := ctx.QueryInt("year", time.Now().Year())
+
+	timeoutCtx, cancel := context.WithTimeout(ctx.Context(), 60*time.Second)
+	defer cancel()
+
+	report, err := c.zakatService.Report(timeoutCtx, year)
+	if err != nil {
+		c.log.Error("Gagal menyusun laporan zakat %d: %v", year, err)
+		return ctx.Status(http.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal menyusun laporan: " + err.Error(),
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"report":     report,
+		"totalZakat": report.TotalZakat(),
+	})
+}
+
+// HandleGetSettings menangani API pengaturan zakat
+func (c *ZakatController) HandleGetSettings(ctx *fiber.Ctx) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx.Context(), 5*time.Second)
+	defer cancel()
+
+	settings, err := c.zakatService.GetSettings(timeoutCtx)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Gagal memuat pengaturan zakat: " + err.Error(),
+		})
+	}
+
+	return ctx.JSON(settings)
+}
+
+// HandleSaveSettings menangani API penyimpanan pengaturan zakat
+func (c *ZakatController) HandleSaveSettings(ctx *fiber.Ctx) error {
+	var settings finance.ZakatSettings
+	if err := ctx.BodyParser(&settings); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error": "Format data tidak valid",
+		})
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(audit.WithActor(context.Background(), dashboardActor(ctx, c.config)), 5*time.Second)
+	defer cancel()
+
+	saved, err := c.zakatService.SaveSettings(timeoutCtx, &settings)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return ctx.JSON(saved)
+}
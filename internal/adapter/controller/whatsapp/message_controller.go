@@ -2,9 +2,12 @@ package whatsapp
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gwenziro/botopia/internal/domain/event"
+	"github.com/gwenziro/botopia/internal/domain/finance"
 	"github.com/gwenziro/botopia/internal/domain/message"
 	"github.com/gwenziro/botopia/internal/domain/repository"
 	"github.com/gwenziro/botopia/internal/domain/service"
@@ -18,6 +21,9 @@ type MessageController struct {
 	connectionRepo        repository.ConnectionRepository
 	statsRepo             repository.StatsRepository
 	contactService        service.ContactService
+	confirmations         service.ConfirmationService
+	formSessions          service.FormSessionService
+	ledgers               service.LedgerService
 	log                   *logger.Logger
 	eventDispatcher       *event.EventDispatcher
 	UseWhitelist          bool // Diubah menjadi exported (huruf kapital)
@@ -29,12 +35,16 @@ func NewMessageController(
 	connectionRepo repository.ConnectionRepository,
 	statsRepo repository.StatsRepository,
 	contactService service.ContactService,
+	confirmations service.ConfirmationService,
+	formSessions service.FormSessionService,
 ) *MessageController {
 	return &MessageController{
 		executeCommandUseCase: executeUC,
 		connectionRepo:        connectionRepo,
 		statsRepo:             statsRepo,
 		contactService:        contactService,
+		confirmations:         confirmations,
+		formSessions:          formSessions,
 		log:                   logger.New("MessageController", logger.INFO, true),
 		eventDispatcher:       event.NewEventDispatcher(),
 		UseWhitelist:          false, // Default: nonaktif
@@ -47,6 +57,11 @@ func (c *MessageController) SetUseWhitelist(value bool) {
 	c.UseWhitelist = value
 }
 
+// SetLedgerService mengatur layanan ledger untuk mengarahkan pesan ke ledger kontak atau grup
+func (c *MessageController) SetLedgerService(ledgers service.LedgerService) {
+	c.ledgers = ledgers
+}
+
 // Setup menyiapkan controller
 func (c *MessageController) Setup() {
 	// Daftarkan handler pesan
@@ -84,6 +99,22 @@ func (c *MessageController) HandleMessage(msg *message.Message) {
 		}
 	}
 
+	// Tentukan ledger pengirim; pesan tidak diproses jika ledger tidak dapat dipastikan
+	// agar transaksi tidak tercatat di pembukuan milik pengguna lain
+	if c.ledgers != nil && msg.Sender != nil {
+		chatID, isGroup := "", false
+		if msg.Chat != nil {
+			chatID, isGroup = msg.Chat.ID, msg.Chat.IsGroup
+		}
+
+		ledgerID, err := c.ledgers.Resolve(context.Background(), chatID, isGroup, msg.Sender.Phone)
+		if err != nil {
+			c.log.Error("Gagal menentukan ledger untuk %s: %v", msg.Sender.Phone, err)
+			return
+		}
+		msg.LedgerID = ledgerID
+	}
+
 	// Gunakan caption sebagai text jika ada media dan caption
 	if msg.HasMedia() && msg.Caption != "" {
 		// Tambahkan log untuk debug
@@ -99,6 +130,30 @@ func (c *MessageController) HandleMessage(msg *message.Message) {
 	// Log pesan masuk
 	c.log.Debug("Message received: %s", msg.Text)
 
+	// Jawaban "ya/tidak" untuk konfirmasi yang tertunda
+	if c.confirmations != nil && msg.Sender != nil && msg.Chat != nil {
+		key := service.ConfirmationKey(msg.Chat.ID, msg.Sender.Phone)
+		if response, handled := c.confirmations.Resolve(key, msg.Text); handled {
+			c.sendReply(msg, response)
+			return
+		}
+	}
+
+	// Jawaban untuk sesi formulir interaktif yang sedang berjalan
+	if c.formSessions != nil && msg.Sender != nil && msg.Chat != nil {
+		key := service.FormSessionKey(msg.Chat.ID, msg.Sender.Phone)
+		if response, handled := c.formSessions.Handle(key, msg); handled {
+			c.sendReply(msg, response)
+			return
+		}
+	}
+
+	// Foto yang membalas pengingat bukti diteruskan sebagai !unggah <kode>
+	if response, handled := c.handleProofReminderReply(msg); handled {
+		c.sendReply(msg, response)
+		return
+	}
+
 	// Buat context dengan timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -126,6 +181,29 @@ func (c *MessageController) HandleMessage(msg *message.Message) {
 	}
 }
 
+// handleProofReminderReply mengubah balasan foto atas pengingat bukti menjadi command !unggah.
+// Mengembalikan handled=true beserta petunjuk jika transaksi yang dimaksud belum dapat dipastikan.
+func (c *MessageController) handleProofReminderReply(msg *message.Message) (string, bool) {
+	if !msg.HasMedia() || msg.ReplyingTo == nil || !msg.ReplyingTo.IsFromMe || strings.HasPrefix(strings.TrimSpace(msg.Text), "!") {
+		return "", false
+	}
+
+	codes := finance.ProofReminderCodes(msg.ReplyingTo.Text)
+	if len(codes) == 0 {
+		return "", false
+	}
+
+	code, ok := finance.SelectReminderCode(codes, msg.Text)
+	if !ok {
+		return fmt.Sprintf("Pengingat ini berisi %d transaksi. Balas lagi dengan foto dan caption nomor urutnya (1-%d) atau kode transaksinya.",
+			len(codes), len(codes)), true
+	}
+
+	c.log.Info("Foto balasan pengingat bukti diteruskan ke transaksi %s", code)
+	msg.Text = "!unggah " + code
+	return "", false
+}
+
 // sendReply mengirim balasan ke pesan
 func (c *MessageController) sendReply(msg *message.Message, text string) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
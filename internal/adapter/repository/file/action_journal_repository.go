@@ -0,0 +1,119 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/repository"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+// journalRetention lama entri jurnal disimpan sebelum dibuang
+const journalRetention = 7 * 24 * time.Hour
+
+// ActionJournalRepository implementasi repository jurnal aksi yang menyimpan data di file JSON
+type ActionJournalRepository struct {
+	entries  []*finance.JournalEntry // Terurut dari yang paling lama
+	mutex    sync.RWMutex
+	filePath string
+	log      *logger.Logger
+}
+
+// NewActionJournalRepository membuat instance repository jurnal aksi baru
+func NewActionJournalRepository(dataDir string, log *logger.Logger) *ActionJournalRepository {
+	repo := &ActionJournalRepository{
+		filePath: filepath.Join(dataDir, "action_journal.json"),
+		log:      log,
+	}
+
+	// Load data dari file saat inisialisasi
+	repo.loadEntries()
+
+	return repo
+}
+
+// Memastikan ActionJournalRepository mengimplementasikan interface repository.ActionJournalRepository
+var _ repository.ActionJournalRepository = (*ActionJournalRepository)(nil)
+
+// loadEntries memuat jurnal aksi dari file
+func (r *ActionJournalRepository) loadEntries() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, err := os.Stat(r.filePath); os.IsNotExist(err) {
+		r.log.Info("File jurnal aksi tidak ditemukan: %s, membuat baru", r.filePath)
+		return
+	}
+
+	data, err := os.ReadFile(r.filePath)
+	if err != nil {
+		r.log.Error("Gagal membaca file jurnal aksi: %v", err)
+		return
+	}
+
+	if err := json.Unmarshal(data, &r.entries); err != nil {
+		r.log.Error("Gagal parse data jurnal aksi: %v", err)
+		return
+	}
+
+	r.log.Info("Berhasil memuat %d entri jurnal aksi dari file", len(r.entries))
+}
+
+// saveEntries membuang entri lama lalu menyimpan jurnal ke file, pemanggil harus memegang lock
+func (r *ActionJournalRepository) saveEntries() error {
+	cutoff := time.Now().Add(-journalRetention)
+	kept := r.entries[:0]
+	for _, entry := range r.entries {
+		if entry.CreatedAt.After(cutoff) {
+			kept = append(kept, entry)
+		}
+	}
+	r.entries = kept
+
+	data, err := json.MarshalIndent(r.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.filePath), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.filePath, data, 0644)
+}
+
+// Save menyimpan atau memperbarui entri jurnal
+func (r *ActionJournalRepository) Save(ctx context.Context, entry *finance.JournalEntry) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, existing := range r.entries {
+		if existing.ID == entry.ID {
+			r.entries[i] = entry
+			return r.saveEntries()
+		}
+	}
+
+	r.entries = append(r.entries, entry)
+	return r.saveEntries()
+}
+
+// FindLatestByPhone mencari entri terbaru milik nomor pengirim yang belum dibatalkan
+func (r *ActionJournalRepository) FindLatestByPhone(ctx context.Context, phone string) (*finance.JournalEntry, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for i := len(r.entries) - 1; i >= 0; i-- {
+		entry := r.entries[i]
+		if entry.Phone == phone && entry.UndoneAt == nil {
+			return entry, nil
+		}
+	}
+
+	return nil, nil // Tidak ditemukan, bukan error
+}
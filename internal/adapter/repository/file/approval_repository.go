@@ -0,0 +1,127 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/repository"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+// ApprovalRepository implementasi repository pengajuan persetujuan yang menyimpan data di file JSON
+type ApprovalRepository struct {
+	requests map[string]*finance.ApprovalRequest // In-memory cache, key kode persetujuan
+	mutex    sync.RWMutex
+	filePath string
+	log      *logger.Logger
+}
+
+// NewApprovalRepository membuat instance repository pengajuan persetujuan baru
+func NewApprovalRepository(dataDir string, log *logger.Logger) *ApprovalRepository {
+	repo := &ApprovalRepository{
+		requests: make(map[string]*finance.ApprovalRequest),
+		filePath: filepath.Join(dataDir, "approvals.json"),
+		log:      log,
+	}
+
+	// Load data dari file saat inisialisasi
+	repo.loadRequests()
+
+	return repo
+}
+
+// Memastikan ApprovalRepository mengimplementasikan interface repository.ApprovalRepository
+var _ repository.ApprovalRepository = (*ApprovalRepository)(nil)
+
+// loadRequests memuat data pengajuan dari file
+func (r *ApprovalRepository) loadRequests() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, err := os.Stat(r.filePath); os.IsNotExist(err) {
+		r.log.Info("File pengajuan persetujuan tidak ditemukan: %s, membuat baru", r.filePath)
+		return
+	}
+
+	data, err := os.ReadFile(r.filePath)
+	if err != nil {
+		r.log.Error("Gagal membaca file pengajuan persetujuan: %v", err)
+		return
+	}
+
+	var requests []*finance.ApprovalRequest
+	if err := json.Unmarshal(data, &requests); err != nil {
+		r.log.Error("Gagal parse data pengajuan persetujuan: %v", err)
+		return
+	}
+
+	for _, request := range requests {
+		r.requests[request.Code] = request
+	}
+
+	r.log.Info("Berhasil memuat %d pengajuan persetujuan dari file", len(r.requests))
+}
+
+// saveRequests menyimpan data pengajuan ke file, pemanggil harus memegang lock
+func (r *ApprovalRepository) saveRequests() error {
+	data, err := json.MarshalIndent(r.sortedRequests(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.filePath), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.filePath, data, 0644)
+}
+
+// sortedRequests mengembalikan pengajuan terurut dari yang paling lama
+func (r *ApprovalRepository) sortedRequests() []*finance.ApprovalRequest {
+	requests := make([]*finance.ApprovalRequest, 0, len(r.requests))
+	for _, request := range r.requests {
+		requests = append(requests, request)
+	}
+
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].CreatedAt.Before(requests[j].CreatedAt)
+	})
+
+	return requests
+}
+
+// FindAll mendapatkan seluruh pengajuan, diurutkan dari yang paling lama
+func (r *ApprovalRepository) FindAll(ctx context.Context) ([]*finance.ApprovalRequest, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.sortedRequests(), nil
+}
+
+// FindByCode mencari pengajuan berdasarkan kode persetujuan
+func (r *ApprovalRepository) FindByCode(ctx context.Context, code string) (*finance.ApprovalRequest, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if request, exists := r.requests[code]; exists {
+		return request, nil
+	}
+
+	return nil, nil // Tidak ditemukan, bukan error
+}
+
+// Save menyimpan atau memperbarui pengajuan
+func (r *ApprovalRepository) Save(ctx context.Context, request *finance.ApprovalRequest) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.requests[request.Code] = request
+	r.log.Info("Pengajuan persetujuan disimpan: %s (%s)", request.Code, request.Status)
+
+	return r.saveRequests()
+}
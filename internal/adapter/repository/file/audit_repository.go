@@ -0,0 +1,124 @@
+package file
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/gwenziro/botopia/internal/domain/audit"
+	"github.com/gwenziro/botopia/internal/domain/repository"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+// AuditRepository implementasi audit log append-only dalam file JSON Lines.
+// FindAll selalu membaca ulang file agar perubahan langsung di disk ikut terdeteksi saat verifikasi.
+type AuditRepository struct {
+	last     *audit.Entry
+	mutex    sync.Mutex
+	filePath string
+	log      *logger.Logger
+}
+
+// NewAuditRepository membuat instance repository audit log baru
+func NewAuditRepository(dataDir string, log *logger.Logger) *AuditRepository {
+	repo := &AuditRepository{
+		filePath: filepath.Join(dataDir, "audit_log.jsonl"),
+		log:      log,
+	}
+
+	entries, err := repo.readEntries()
+	if err != nil {
+		log.Error("Gagal membaca audit log: %v", err)
+	} else if len(entries) > 0 {
+		repo.last = entries[len(entries)-1]
+		log.Info("Berhasil memuat %d entri audit log", len(entries))
+	}
+
+	return repo
+}
+
+// Memastikan AuditRepository mengimplementasikan interface repository.AuditRepository
+var _ repository.AuditRepository = (*AuditRepository)(nil)
+
+// readEntries membaca seluruh entri dari file
+func (r *AuditRepository) readEntries() ([]*audit.Entry, error) {
+	file, err := os.Open(r.filePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []*audit.Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var entry audit.Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("baris %d tidak valid: %v", line, err)
+		}
+		entries = append(entries, &entry)
+	}
+
+	return entries, scanner.Err()
+}
+
+// Append menambahkan entri di akhir log
+func (r *AuditRepository) Append(ctx context.Context, entry *audit.Entry) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.filePath), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(r.filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		return err
+	}
+
+	r.last = entry
+	return nil
+}
+
+// Last mendapatkan entri terakhir (nil jika log masih kosong)
+func (r *AuditRepository) Last(ctx context.Context) (*audit.Entry, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.last, nil
+}
+
+// FindAll mendapatkan seluruh entri sesuai urutan penulisan
+func (r *AuditRepository) FindAll(ctx context.Context) ([]*audit.Entry, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.readEntries()
+}
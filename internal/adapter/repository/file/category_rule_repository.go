@@ -0,0 +1,148 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+// CategoryRuleRepository implementasi repository aturan kategori yang menyimpan data di file JSON
+type CategoryRuleRepository struct {
+	rules    map[string]*finance.CategoryRule // In-memory cache
+	mutex    sync.RWMutex
+	filePath string
+	log      *logger.Logger
+}
+
+// NewCategoryRuleRepository membuat instance repository aturan kategori baru
+func NewCategoryRuleRepository(dataDir string, log *logger.Logger) *CategoryRuleRepository {
+	repo := &CategoryRuleRepository{
+		rules:    make(map[string]*finance.CategoryRule),
+		filePath: filepath.Join(dataDir, "category_rules.json"),
+		log:      log,
+	}
+
+	// Load data dari file saat inisialisasi
+	repo.loadRules()
+
+	return repo
+}
+
+// loadRules memuat data aturan dari file
+func (r *CategoryRuleRepository) loadRules() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, err := os.Stat(r.filePath); os.IsNotExist(err) {
+		r.log.Info("File aturan kategori tidak ditemukan: %s, membuat baru", r.filePath)
+		return
+	}
+
+	data, err := os.ReadFile(r.filePath)
+	if err != nil {
+		r.log.Error("Gagal membaca file aturan kategori: %v", err)
+		return
+	}
+
+	var rules []*finance.CategoryRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		r.log.Error("Gagal parse data aturan kategori: %v", err)
+		return
+	}
+
+	for _, rule := range rules {
+		r.rules[rule.ID] = rule
+	}
+
+	r.log.Info("Berhasil memuat %d aturan kategori dari file", len(r.rules))
+}
+
+// saveRules menyimpan data aturan ke file, pemanggil harus memegang lock
+func (r *CategoryRuleRepository) saveRules() error {
+	rules := make([]*finance.CategoryRule, 0, len(r.rules))
+	for _, rule := range r.rules {
+		rules = append(rules, rule)
+	}
+	sortRules(rules)
+
+	data, err := json.MarshalIndent(rules, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.filePath), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.filePath, data, 0644)
+}
+
+// FindAll mendapatkan semua aturan, diurutkan berdasarkan prioritas
+func (r *CategoryRuleRepository) FindAll(ctx context.Context) ([]*finance.CategoryRule, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	rules := make([]*finance.CategoryRule, 0, len(r.rules))
+	for _, rule := range r.rules {
+		rules = append(rules, rule)
+	}
+	sortRules(rules)
+
+	return rules, nil
+}
+
+// FindByID mencari aturan berdasarkan ID
+func (r *CategoryRuleRepository) FindByID(ctx context.Context, id string) (*finance.CategoryRule, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if rule, exists := r.rules[id]; exists {
+		return rule, nil
+	}
+
+	return nil, nil // Tidak ditemukan, bukan error
+}
+
+// Save menyimpan aturan baru atau memperbarui yang sudah ada
+func (r *CategoryRuleRepository) Save(ctx context.Context, rule *finance.CategoryRule) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	rule.UpdatedAt = time.Now()
+	r.rules[rule.ID] = rule
+	r.log.Info("Aturan kategori disimpan: %s (%s)", rule.Name, rule.ID)
+
+	return r.saveRules()
+}
+
+// Delete menghapus aturan
+func (r *CategoryRuleRepository) Delete(ctx context.Context, id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.rules[id]; !exists {
+		return nil // Tidak ada aturan yang dihapus, bukan error
+	}
+
+	delete(r.rules, id)
+	r.log.Info("Aturan kategori dihapus: %s", id)
+
+	return r.saveRules()
+}
+
+// sortRules mengurutkan aturan dari prioritas tertinggi, lalu yang paling lama dibuat
+func sortRules(rules []*finance.CategoryRule) {
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Priority != rules[j].Priority {
+			return rules[i].Priority > rules[j].Priority
+		}
+		return rules[i].CreatedAt.Before(rules[j].CreatedAt)
+	})
+}
@@ -0,0 +1,127 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/repository"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+// InstallmentRepository implementasi repository rencana cicilan yang menyimpan data di file JSON
+type InstallmentRepository struct {
+	plans    map[string]*finance.InstallmentPlan // In-memory cache, key kode cicilan
+	mutex    sync.RWMutex
+	filePath string
+	log      *logger.Logger
+}
+
+// NewInstallmentRepository membuat instance repository rencana cicilan baru
+func NewInstallmentRepository(dataDir string, log *logger.Logger) *InstallmentRepository {
+	repo := &InstallmentRepository{
+		plans:    make(map[string]*finance.InstallmentPlan),
+		filePath: filepath.Join(dataDir, "installments.json"),
+		log:      log,
+	}
+
+	// Load data dari file saat inisialisasi
+	repo.loadPlans()
+
+	return repo
+}
+
+// Memastikan InstallmentRepository mengimplementasikan interface repository.InstallmentRepository
+var _ repository.InstallmentRepository = (*InstallmentRepository)(nil)
+
+// loadPlans memuat data rencana cicilan dari file
+func (r *InstallmentRepository) loadPlans() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, err := os.Stat(r.filePath); os.IsNotExist(err) {
+		r.log.Info("File cicilan tidak ditemukan: %s, membuat baru", r.filePath)
+		return
+	}
+
+	data, err := os.ReadFile(r.filePath)
+	if err != nil {
+		r.log.Error("Gagal membaca file cicilan: %v", err)
+		return
+	}
+
+	var plans []*finance.InstallmentPlan
+	if err := json.Unmarshal(data, &plans); err != nil {
+		r.log.Error("Gagal parse data cicilan: %v", err)
+		return
+	}
+
+	for _, plan := range plans {
+		r.plans[plan.Code] = plan
+	}
+
+	r.log.Info("Berhasil memuat %d rencana cicilan dari file", len(r.plans))
+}
+
+// savePlans menyimpan data rencana cicilan ke file, pemanggil harus memegang lock
+func (r *InstallmentRepository) savePlans() error {
+	data, err := json.MarshalIndent(r.sortedPlans(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.filePath), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.filePath, data, 0644)
+}
+
+// sortedPlans mengembalikan rencana cicilan terurut dari yang paling lama
+func (r *InstallmentRepository) sortedPlans() []*finance.InstallmentPlan {
+	plans := make([]*finance.InstallmentPlan, 0, len(r.plans))
+	for _, plan := range r.plans {
+		plans = append(plans, plan)
+	}
+
+	sort.Slice(plans, func(i, j int) bool {
+		return plans[i].CreatedAt.Before(plans[j].CreatedAt)
+	})
+
+	return plans
+}
+
+// FindAll mendapatkan seluruh rencana cicilan, diurutkan dari yang paling lama
+func (r *InstallmentRepository) FindAll(ctx context.Context) ([]*finance.InstallmentPlan, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.sortedPlans(), nil
+}
+
+// FindByCode mencari rencana cicilan berdasarkan kode
+func (r *InstallmentRepository) FindByCode(ctx context.Context, code string) (*finance.InstallmentPlan, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if plan, exists := r.plans[code]; exists {
+		return plan, nil
+	}
+
+	return nil, nil // Tidak ditemukan, bukan error
+}
+
+// Save menyimpan atau memperbarui rencana cicilan
+func (r *InstallmentRepository) Save(ctx context.Context, plan *finance.InstallmentPlan) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.plans[plan.Code] = plan
+	r.log.Info("Rencana cicilan disimpan: %s (%s)", plan.Code, plan.Status)
+
+	return r.savePlans()
+}
@@ -0,0 +1,143 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/repository"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+// LedgerRepository implementasi repository ledger yang menyimpan data di file JSON
+type LedgerRepository struct {
+	ledgers  map[string]*finance.Ledger // In-memory cache, key ID ledger
+	mutex    sync.RWMutex
+	filePath string
+	log      *logger.Logger
+}
+
+// NewLedgerRepository membuat instance repository ledger baru
+func NewLedgerRepository(dataDir string, log *logger.Logger) *LedgerRepository {
+	repo := &LedgerRepository{
+		ledgers:  make(map[string]*finance.Ledger),
+		filePath: filepath.Join(dataDir, "ledgers.json"),
+		log:      log,
+	}
+
+	// Load data dari file saat inisialisasi
+	repo.loadLedgers()
+
+	return repo
+}
+
+// Memastikan LedgerRepository mengimplementasikan interface repository.LedgerRepository
+var _ repository.LedgerRepository = (*LedgerRepository)(nil)
+
+// loadLedgers memuat data ledger dari file
+func (r *LedgerRepository) loadLedgers() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, err := os.Stat(r.filePath); os.IsNotExist(err) {
+		r.log.Info("File ledger tidak ditemukan: %s, membuat baru", r.filePath)
+		return
+	}
+
+	data, err := os.ReadFile(r.filePath)
+	if err != nil {
+		r.log.Error("Gagal membaca file ledger: %v", err)
+		return
+	}
+
+	var ledgers []*finance.Ledger
+	if err := json.Unmarshal(data, &ledgers); err != nil {
+		r.log.Error("Gagal parse data ledger: %v", err)
+		return
+	}
+
+	for _, ledger := range ledgers {
+		r.ledgers[ledger.ID] = ledger
+	}
+
+	r.log.Info("Berhasil memuat %d ledger dari file", len(r.ledgers))
+}
+
+// saveLedgers menyimpan data ledger ke file, pemanggil harus memegang lock
+func (r *LedgerRepository) saveLedgers() error {
+	data, err := json.MarshalIndent(r.sortedLedgers(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.filePath), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.filePath, data, 0644)
+}
+
+// sortedLedgers mengembalikan ledger terurut berdasarkan nama
+func (r *LedgerRepository) sortedLedgers() []*finance.Ledger {
+	ledgers := make([]*finance.Ledger, 0, len(r.ledgers))
+	for _, ledger := range r.ledgers {
+		ledgers = append(ledgers, ledger)
+	}
+
+	sort.Slice(ledgers, func(i, j int) bool {
+		return strings.ToLower(ledgers[i].Name) < strings.ToLower(ledgers[j].Name)
+	})
+
+	return ledgers
+}
+
+// FindAll mendapatkan seluruh ledger, diurutkan berdasarkan nama
+func (r *LedgerRepository) FindAll(ctx context.Context) ([]*finance.Ledger, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.sortedLedgers(), nil
+}
+
+// FindByID mencari ledger berdasarkan ID
+func (r *LedgerRepository) FindByID(ctx context.Context, id string) (*finance.Ledger, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if ledger, exists := r.ledgers[id]; exists {
+		return ledger, nil
+	}
+
+	return nil, nil // Tidak ditemukan, bukan error
+}
+
+// Save menyimpan atau memperbarui ledger
+func (r *LedgerRepository) Save(ctx context.Context, ledger *finance.Ledger) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.ledgers[ledger.ID] = ledger
+	r.log.Info("Ledger disimpan: %s (%s)", ledger.ID, ledger.SpreadsheetID)
+
+	return r.saveLedgers()
+}
+
+// Delete menghapus ledger
+func (r *LedgerRepository) Delete(ctx context.Context, id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.ledgers[id]; !exists {
+		return nil // Tidak ada ledger yang dihapus, bukan error
+	}
+
+	delete(r.ledgers, id)
+	r.log.Info("Ledger dihapus: %s", id)
+
+	return r.saveLedgers()
+}
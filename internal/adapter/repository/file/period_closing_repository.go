@@ -0,0 +1,146 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/repository"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+// PeriodClosingRepository implementasi repository tutup buku yang menyimpan data di file JSON
+type PeriodClosingRepository struct {
+	closings map[string]*finance.PeriodClosing // In-memory cache, key periode YYYY-MM
+	mutex    sync.RWMutex
+	filePath string
+	log      *logger.Logger
+}
+
+// NewPeriodClosingRepository membuat instance repository tutup buku baru
+func NewPeriodClosingRepository(dataDir string, log *logger.Logger) *PeriodClosingRepository {
+	repo := &PeriodClosingRepository{
+		closings: make(map[string]*finance.PeriodClosing),
+		filePath: filepath.Join(dataDir, "period_closings.json"),
+		log:      log,
+	}
+
+	// Load data dari file saat inisialisasi
+	repo.loadClosings()
+
+	return repo
+}
+
+// Memastikan PeriodClosingRepository mengimplementasikan interface repository.PeriodClosingRepository
+var _ repository.PeriodClosingRepository = (*PeriodClosingRepository)(nil)
+
+// loadClosings memuat data tutup buku dari file
+func (r *PeriodClosingRepository) loadClosings() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, err := os.Stat(r.filePath); os.IsNotExist(err) {
+		r.log.Info("File tutup buku tidak ditemukan: %s, membuat baru", r.filePath)
+		return
+	}
+
+	data, err := os.ReadFile(r.filePath)
+	if err != nil {
+		r.log.Error("Gagal membaca file tutup buku: %v", err)
+		return
+	}
+
+	var closings []*finance.PeriodClosing
+	if err := json.Unmarshal(data, &closings); err != nil {
+		r.log.Error("Gagal parse data tutup buku: %v", err)
+		return
+	}
+
+	for _, closing := range closings {
+		r.closings[closing.Key()] = closing
+	}
+
+	r.log.Info("Berhasil memuat %d periode tutup buku dari file", len(r.closings))
+}
+
+// saveClosings menyimpan data tutup buku ke file, pemanggil harus memegang lock
+func (r *PeriodClosingRepository) saveClosings() error {
+	data, err := json.MarshalIndent(r.sortedClosings(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.filePath), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.filePath, data, 0644)
+}
+
+// sortedClosings mengembalikan periode tertutup terurut dari periode terlama
+func (r *PeriodClosingRepository) sortedClosings() []*finance.PeriodClosing {
+	closings := make([]*finance.PeriodClosing, 0, len(r.closings))
+	for _, closing := range r.closings {
+		closings = append(closings, closing)
+	}
+
+	sort.Slice(closings, func(i, j int) bool {
+		return closings[i].Period < closings[j].Period
+	})
+
+	return closings
+}
+
+// FindAll mendapatkan seluruh periode tertutup di ledger context, diurutkan dari periode terlama
+func (r *PeriodClosingRepository) FindAll(ctx context.Context) ([]*finance.PeriodClosing, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	ledgerID := finance.LedgerFromContext(ctx)
+	closings := make([]*finance.PeriodClosing, 0, len(r.closings))
+	for _, closing := range r.sortedClosings() {
+		if closing.Ledger == ledgerID {
+			closings = append(closings, closing)
+		}
+	}
+
+	return closings, nil
+}
+
+// FindByPeriod mencari periode tertutup di ledger context
+func (r *PeriodClosingRepository) FindByPeriod(ctx context.Context, period string) (*finance.PeriodClosing, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if closing, exists := r.closings[finance.LedgerScopedKey(finance.LedgerFromContext(ctx), period)]; exists {
+		return closing, nil
+	}
+
+	return nil, nil // Periode masih terbuka, bukan error
+}
+
+// Save menyimpan periode tertutup
+func (r *PeriodClosingRepository) Save(ctx context.Context, closing *finance.PeriodClosing) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.closings[closing.Key()] = closing
+	r.log.Info("Periode %s tutup buku disimpan", closing.Period)
+
+	return r.saveClosings()
+}
+
+// Delete membuka kembali periode dengan menghapus data tutup bukunya
+func (r *PeriodClosingRepository) Delete(ctx context.Context, period string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.closings, finance.LedgerScopedKey(finance.LedgerFromContext(ctx), period))
+	r.log.Info("Periode %s dibuka kembali", period)
+
+	return r.saveClosings()
+}
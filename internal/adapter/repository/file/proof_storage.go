@@ -0,0 +1,182 @@
+package file
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/repository"
+	"github.com/gwenziro/botopia/internal/infrastructure/config"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+// LocalProofStorage menyimpan bukti transaksi di disk lokal dan
+// menyajikannya melalui web server dengan URL bertanda tangan
+type LocalProofStorage struct {
+	dir        string
+	publicURL  string
+	signingKey []byte
+	urlTTL     time.Duration
+	log        *logger.Logger
+}
+
+// NewLocalProofStorage membuat instance penyimpanan bukti lokal baru
+func NewLocalProofStorage(cfg *config.Config, log *logger.Logger) *LocalProofStorage {
+	storageCfg := cfg.ProofStorage
+
+	s := &LocalProofStorage{
+		dir:       storageCfg.LocalDir,
+		publicURL: strings.TrimRight(storageCfg.PublicURL, "/"),
+		urlTTL:    time.Duration(storageCfg.URLTTLHours) * time.Hour,
+		log:       log,
+	}
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		log.Error("Gagal membuat direktori bukti %s: %v", s.dir, err)
+	}
+
+	if storageCfg.SigningKey != "" {
+		s.signingKey = []byte(storageCfg.SigningKey)
+	} else {
+		s.signingKey = loadOrCreateSigningKey(filepath.Join(cfg.DataDir, "proof_signing.key"), log)
+	}
+
+	return s
+}
+
+// Memastikan LocalProofStorage mengimplementasikan interface repository.ProofStorageRepository
+var _ repository.ProofStorageRepository = (*LocalProofStorage)(nil)
+
+// GetName mengembalikan nama backend penyimpanan
+func (s *LocalProofStorage) GetName() string {
+	return "local"
+}
+
+// IsConfigured memeriksa apakah penyimpanan sudah dikonfigurasi
+func (s *LocalProofStorage) IsConfigured() bool {
+	return s.dir != "" && s.publicURL != "" && len(s.signingKey) > 0
+}
+
+// UploadProof menyalin file bukti ke direktori penyimpanan dan mengembalikan URL bertanda tangan
+func (s *LocalProofStorage) UploadProof(_ context.Context, filePath string, transactionCode string) (string, error) {
+	src, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("gagal membuka file: %v", err)
+	}
+	defer src.Close()
+
+	// Tambahkan timestamp agar beberapa lampiran untuk transaksi yang sama tidak bertabrakan
+	name := fmt.Sprintf("Bukti_%s_%d%s", transactionCode, time.Now().UnixNano(), strings.ToLower(filepath.Ext(filePath)))
+
+	dst, err := os.Create(filepath.Join(s.dir, name))
+	if err != nil {
+		return "", fmt.Errorf("gagal membuat file bukti: %v", err)
+	}
+	defer dst.Close()
+
+	if _, err := io.Copy(dst, src); err != nil {
+		return "", fmt.Errorf("gagal menyimpan file bukti: %v", err)
+	}
+
+	s.log.Info("File %s berhasil disimpan ke penyimpanan lokal", name)
+	return s.SignedURL(name), nil
+}
+
+// DownloadProof membaca file bukti dari direktori penyimpanan berdasarkan URL-nya
+func (s *LocalProofStorage) DownloadProof(_ context.Context, proofURL string) ([]byte, error) {
+	parsed, err := url.Parse(proofURL)
+	if err != nil || !strings.HasPrefix(parsed.Path, "/proofs/") {
+		return nil, fmt.Errorf("URL bukan file bukti lokal: %s", proofURL)
+	}
+
+	name := strings.TrimPrefix(parsed.Path, "/proofs/")
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return nil, fmt.Errorf("nama file tidak valid")
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.dir, name))
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca file bukti: %v", err)
+	}
+
+	return data, nil
+}
+
+// SignedURL membuat URL bertanda tangan untuk file bukti
+func (s *LocalProofStorage) SignedURL(name string) string {
+	var expires int64
+	if s.urlTTL > 0 {
+		expires = time.Now().Add(s.urlTTL).Unix()
+	}
+
+	return fmt.Sprintf("%s/proofs/%s?exp=%d&sig=%s",
+		s.publicURL, url.PathEscape(name), expires, s.sign(name, expires))
+}
+
+// ResolveSignedFile memverifikasi tanda tangan URL dan mengembalikan path file bukti
+func (s *LocalProofStorage) ResolveSignedFile(name, expires, signature string) (string, error) {
+	// Tolak nama file yang mencoba keluar dari direktori bukti
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("nama file tidak valid")
+	}
+
+	exp, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("masa berlaku tidak valid")
+	}
+
+	expected := s.sign(name, exp)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return "", fmt.Errorf("tanda tangan tidak valid")
+	}
+
+	if exp > 0 && time.Now().Unix() > exp {
+		return "", fmt.Errorf("tautan sudah kedaluwarsa")
+	}
+
+	path := filepath.Join(s.dir, name)
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("file tidak ditemukan")
+	}
+
+	return path, nil
+}
+
+// sign menghitung tanda tangan HMAC untuk nama file dan waktu kedaluwarsa
+func (s *LocalProofStorage) sign(name string, expires int64) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(fmt.Sprintf("%s|%d", name, expires)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// loadOrCreateSigningKey membaca kunci tanda tangan dari file atau membuat kunci baru
+func loadOrCreateSigningKey(path string, log *logger.Logger) []byte {
+	if data, err := os.ReadFile(path); err == nil && len(data) > 0 {
+		return data
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		log.Error("Gagal membuat kunci tanda tangan bukti: %v", err)
+		return nil
+	}
+
+	encoded := []byte(hex.EncodeToString(key))
+	if err := os.WriteFile(path, encoded, 0600); err != nil {
+		log.Warn("Gagal menyimpan kunci tanda tangan bukti, URL akan berubah setelah restart: %v", err)
+	} else {
+		log.Info("Kunci tanda tangan bukti baru dibuat di %s", path)
+	}
+
+	return encoded
+}
@@ -0,0 +1,128 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/repository"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+// RecordAuthorRepository implementasi repository pembuat transaksi yang menyimpan data di file JSON
+type RecordAuthorRepository struct {
+	authors  map[string]*finance.RecordAuthor // In-memory cache, key kode transaksi
+	mutex    sync.RWMutex
+	filePath string
+	log      *logger.Logger
+}
+
+// NewRecordAuthorRepository membuat instance repository pembuat transaksi baru
+func NewRecordAuthorRepository(dataDir string, log *logger.Logger) *RecordAuthorRepository {
+	repo := &RecordAuthorRepository{
+		authors:  make(map[string]*finance.RecordAuthor),
+		filePath: filepath.Join(dataDir, "record_authors.json"),
+		log:      log,
+	}
+
+	// Load data dari file saat inisialisasi
+	repo.loadAuthors()
+
+	return repo
+}
+
+// Memastikan RecordAuthorRepository mengimplementasikan interface repository.RecordAuthorRepository
+var _ repository.RecordAuthorRepository = (*RecordAuthorRepository)(nil)
+
+// loadAuthors memuat data pembuat transaksi dari file
+func (r *RecordAuthorRepository) loadAuthors() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, err := os.Stat(r.filePath); os.IsNotExist(err) {
+		r.log.Info("File pembuat transaksi tidak ditemukan: %s, membuat baru", r.filePath)
+		return
+	}
+
+	data, err := os.ReadFile(r.filePath)
+	if err != nil {
+		r.log.Error("Gagal membaca file pembuat transaksi: %v", err)
+		return
+	}
+
+	var authors []*finance.RecordAuthor
+	if err := json.Unmarshal(data, &authors); err != nil {
+		r.log.Error("Gagal parse data pembuat transaksi: %v", err)
+		return
+	}
+
+	for _, author := range authors {
+		r.authors[author.Key()] = author
+	}
+
+	r.log.Info("Berhasil memuat %d data pembuat transaksi dari file", len(r.authors))
+}
+
+// saveAuthors menyimpan data pembuat transaksi ke file, pemanggil harus memegang lock
+func (r *RecordAuthorRepository) saveAuthors() error {
+	authors := r.sortedAuthors()
+
+	data, err := json.MarshalIndent(authors, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.filePath), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.filePath, data, 0644)
+}
+
+// sortedAuthors mengembalikan data pembuat transaksi terurut dari yang paling lama
+func (r *RecordAuthorRepository) sortedAuthors() []*finance.RecordAuthor {
+	authors := make([]*finance.RecordAuthor, 0, len(r.authors))
+	for _, author := range r.authors {
+		authors = append(authors, author)
+	}
+
+	sort.Slice(authors, func(i, j int) bool {
+		return authors[i].CreatedAt.Before(authors[j].CreatedAt)
+	})
+
+	return authors
+}
+
+// FindAll mendapatkan seluruh data pembuat transaksi
+func (r *RecordAuthorRepository) FindAll(ctx context.Context) ([]*finance.RecordAuthor, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.sortedAuthors(), nil
+}
+
+// FindByCode mencari pembuat transaksi berdasarkan kode transaksi di ledger context
+func (r *RecordAuthorRepository) FindByCode(ctx context.Context, code string) (*finance.RecordAuthor, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if author, exists := r.authors[finance.LedgerScopedKey(finance.LedgerFromContext(ctx), code)]; exists {
+		return author, nil
+	}
+
+	return nil, nil // Tidak ditemukan, bukan error
+}
+
+// Save menyimpan atau memperbarui data pembuat transaksi
+func (r *RecordAuthorRepository) Save(ctx context.Context, author *finance.RecordAuthor) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.authors[author.Key()] = author
+
+	return r.saveAuthors()
+}
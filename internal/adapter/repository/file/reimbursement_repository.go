@@ -0,0 +1,127 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/repository"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+// ReimbursementRepository implementasi repository klaim reimbursement yang menyimpan data di file JSON
+type ReimbursementRepository struct {
+	claims   map[string]*finance.ReimbursementClaim // In-memory cache, key kode klaim
+	mutex    sync.RWMutex
+	filePath string
+	log      *logger.Logger
+}
+
+// NewReimbursementRepository membuat instance repository klaim reimbursement baru
+func NewReimbursementRepository(dataDir string, log *logger.Logger) *ReimbursementRepository {
+	repo := &ReimbursementRepository{
+		claims:   make(map[string]*finance.ReimbursementClaim),
+		filePath: filepath.Join(dataDir, "reimbursements.json"),
+		log:      log,
+	}
+
+	// Load data dari file saat inisialisasi
+	repo.loadClaims()
+
+	return repo
+}
+
+// Memastikan ReimbursementRepository mengimplementasikan interface repository.ReimbursementRepository
+var _ repository.ReimbursementRepository = (*ReimbursementRepository)(nil)
+
+// loadClaims memuat data klaim reimbursement dari file
+func (r *ReimbursementRepository) loadClaims() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, err := os.Stat(r.filePath); os.IsNotExist(err) {
+		r.log.Info("File klaim reimbursement tidak ditemukan: %s, membuat baru", r.filePath)
+		return
+	}
+
+	data, err := os.ReadFile(r.filePath)
+	if err != nil {
+		r.log.Error("Gagal membaca file klaim reimbursement: %v", err)
+		return
+	}
+
+	var claims []*finance.ReimbursementClaim
+	if err := json.Unmarshal(data, &claims); err != nil {
+		r.log.Error("Gagal parse data klaim reimbursement: %v", err)
+		return
+	}
+
+	for _, claim := range claims {
+		r.claims[claim.Code] = claim
+	}
+
+	r.log.Info("Berhasil memuat %d klaim reimbursement dari file", len(r.claims))
+}
+
+// saveClaims menyimpan data klaim reimbursement ke file, pemanggil harus memegang lock
+func (r *ReimbursementRepository) saveClaims() error {
+	data, err := json.MarshalIndent(r.sortedClaims(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.filePath), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.filePath, data, 0644)
+}
+
+// sortedClaims mengembalikan klaim reimbursement terurut dari yang paling lama
+func (r *ReimbursementRepository) sortedClaims() []*finance.ReimbursementClaim {
+	claims := make([]*finance.ReimbursementClaim, 0, len(r.claims))
+	for _, claim := range r.claims {
+		claims = append(claims, claim)
+	}
+
+	sort.Slice(claims, func(i, j int) bool {
+		return claims[i].SubmittedAt.Before(claims[j].SubmittedAt)
+	})
+
+	return claims
+}
+
+// FindAll mendapatkan seluruh klaim reimbursement, diurutkan dari yang paling lama
+func (r *ReimbursementRepository) FindAll(ctx context.Context) ([]*finance.ReimbursementClaim, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.sortedClaims(), nil
+}
+
+// FindByCode mencari klaim reimbursement berdasarkan kode
+func (r *ReimbursementRepository) FindByCode(ctx context.Context, code string) (*finance.ReimbursementClaim, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if claim, exists := r.claims[code]; exists {
+		return claim, nil
+	}
+
+	return nil, nil // Tidak ditemukan, bukan error
+}
+
+// Save menyimpan atau memperbarui klaim reimbursement
+func (r *ReimbursementRepository) Save(ctx context.Context, claim *finance.ReimbursementClaim) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.claims[claim.Code] = claim
+	r.log.Info("Klaim reimbursement disimpan: %s (%s)", claim.Code, claim.Status)
+
+	return r.saveClaims()
+}
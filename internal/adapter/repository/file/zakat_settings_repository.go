@@ -0,0 +1,113 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/repository"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+// ZakatSettingsRepository implementasi repository pengaturan zakat yang menyimpan data di file JSON
+type ZakatSettingsRepository struct {
+	settings map[string]*finance.ZakatSettings // In-memory cache, key ID ledger
+	mutex    sync.RWMutex
+	filePath string
+	log      *logger.Logger
+}
+
+// NewZakatSettingsRepository membuat instance repository pengaturan zakat baru
+func NewZakatSettingsRepository(dataDir string, log *logger.Logger) *ZakatSettingsRepository {
+	repo := &ZakatSettingsRepository{
+		settings: make(map[string]*finance.ZakatSettings),
+		filePath: filepath.Join(dataDir, "zakat_settings.json"),
+		log:      log,
+	}
+
+	// Load data dari file saat inisialisasi
+	repo.loadSettings()
+
+	return repo
+}
+
+// Memastikan ZakatSettingsRepository mengimplementasikan interface repository.ZakatSettingsRepository
+var _ repository.ZakatSettingsRepository = (*ZakatSettingsRepository)(nil)
+
+// loadSettings memuat pengaturan zakat dari file
+func (r *ZakatSettingsRepository) loadSettings() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, err := os.Stat(r.filePath); os.IsNotExist(err) {
+		r.log.Info("File pengaturan zakat tidak ditemukan: %s, membuat baru", r.filePath)
+		return
+	}
+
+	data, err := os.ReadFile(r.filePath)
+	if err != nil {
+		r.log.Error("Gagal membaca file pengaturan zakat: %v", err)
+		return
+	}
+
+	var settings []*finance.ZakatSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		r.log.Error("Gagal parse data pengaturan zakat: %v", err)
+		return
+	}
+
+	for _, s := range settings {
+		r.settings[s.Ledger] = s
+	}
+
+	r.log.Info("Berhasil memuat pengaturan zakat %d ledger dari file", len(r.settings))
+}
+
+// saveSettings menyimpan pengaturan zakat ke file, pemanggil harus memegang lock
+func (r *ZakatSettingsRepository) saveSettings() error {
+	settings := make([]*finance.ZakatSettings, 0, len(r.settings))
+	for _, s := range r.settings {
+		settings = append(settings, s)
+	}
+	sort.Slice(settings, func(i, j int) bool {
+		return settings[i].Ledger < settings[j].Ledger
+	})
+
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.filePath), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.filePath, data, 0644)
+}
+
+// Get mendapatkan pengaturan zakat ledger context
+func (r *ZakatSettingsRepository) Get(ctx context.Context) (*finance.ZakatSettings, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if settings, exists := r.settings[finance.LedgerFromContext(ctx)]; exists {
+		return settings, nil
+	}
+
+	return nil, nil // Belum diatur, bukan error
+}
+
+// Save menyimpan pengaturan zakat sesuai ledger pada pengaturan
+func (r *ZakatSettingsRepository) Save(ctx context.Context, settings *finance.ZakatSettings) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.settings[settings.Ledger] = settings
+	r.log.Info("Pengaturan zakat ledger %s disimpan", finance.LedgerLabel(settings.Ledger))
+
+	return r.saveSettings()
+}
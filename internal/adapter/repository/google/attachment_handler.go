@@ -0,0 +1,259 @@
+package google
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/infrastructure/config"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+	"google.golang.org/api/sheets/v4"
+)
+
+// attachmentSheetName adalah nama sheet untuk lampiran bukti transaksi
+const attachmentSheetName = "Lampiran"
+
+// attachmentTimeFormat adalah format waktu unggah di sheet lampiran
+const attachmentTimeFormat = "02/01/2006 15:04:05"
+
+// AttachmentHandler menangani operasi untuk lampiran bukti transaksi
+type AttachmentHandler struct {
+	apiRepo *GoogleAPIRepository
+	config  *config.GoogleSheetsConfig
+	log     *logger.Logger
+
+	sheetID    int64
+	sheetReady bool
+	mutex      sync.Mutex
+}
+
+// NewAttachmentHandler membuat instance attachment handler baru
+func NewAttachmentHandler(
+	apiRepo *GoogleAPIRepository,
+	config *config.GoogleSheetsConfig,
+	log *logger.Logger,
+) *AttachmentHandler {
+	return &AttachmentHandler{
+		apiRepo: apiRepo,
+		config:  config,
+		log:     log,
+	}
+}
+
+// ensureSheet memastikan sheet Lampiran tersedia dan mengembalikan ID sheet-nya
+func (h *AttachmentHandler) ensureSheet(ctx context.Context, service *sheets.Service) (int64, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.sheetReady {
+		return h.sheetID, nil
+	}
+
+	spreadsheet, err := service.Spreadsheets.Get(h.config.SpreadsheetID).
+		Fields("sheets.properties").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return 0, fmt.Errorf("gagal membaca metadata spreadsheet: %v", err)
+	}
+
+	for _, sheet := range spreadsheet.Sheets {
+		if sheet.Properties != nil && sheet.Properties.Title == attachmentSheetName {
+			h.sheetID = sheet.Properties.SheetId
+			h.sheetReady = true
+			return h.sheetID, nil
+		}
+	}
+
+	// Buat sheet Lampiran beserta header jika belum ada
+	h.log.Info("Sheet %s belum ada, membuat sheet baru", attachmentSheetName)
+	resp, err := service.Spreadsheets.BatchUpdate(h.config.SpreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{{
+			AddSheet: &sheets.AddSheetRequest{
+				Properties: &sheets.SheetProperties{Title: attachmentSheetName},
+			},
+		}},
+	}).Context(ctx).Do()
+	if err != nil {
+		return 0, fmt.Errorf("gagal membuat sheet %s: %v", attachmentSheetName, err)
+	}
+
+	_, err = service.Spreadsheets.Values.Update(
+		h.config.SpreadsheetID,
+		attachmentSheetName+"!A1:G1",
+		&sheets.ValueRange{
+			Values: [][]interface{}{{"Kode Transaksi", "Waktu Unggah", "Nama File", "Tipe", "URL", "Hash", "Ditautkan Dari"}},
+		},
+	).ValueInputOption("RAW").Context(ctx).Do()
+	if err != nil {
+		return 0, fmt.Errorf("gagal menulis header sheet %s: %v", attachmentSheetName, err)
+	}
+
+	h.sheetID = resp.Replies[0].AddSheet.Properties.SheetId
+	h.sheetReady = true
+	return h.sheetID, nil
+}
+
+// GetAttachments mendapatkan seluruh lampiran untuk kode transaksi
+func (h *AttachmentHandler) GetAttachments(ctx context.Context, code string) ([]*finance.Attachment, error) {
+	attachments, _, err := h.findAttachments(ctx, func(a *finance.Attachment) bool {
+		return a.RecordCode == code
+	})
+	return attachments, err
+}
+
+// FindAttachmentByHash mencari lampiran pertama dengan hash konten tertentu
+func (h *AttachmentHandler) FindAttachmentByHash(ctx context.Context, hash string) (*finance.Attachment, error) {
+	if hash == "" {
+		return nil, nil
+	}
+
+	attachments, _, err := h.findAttachments(ctx, func(a *finance.Attachment) bool {
+		return a.Hash == hash
+	})
+	if err != nil || len(attachments) == 0 {
+		return nil, err
+	}
+
+	return attachments[0], nil
+}
+
+// findAttachments mencari lampiran yang cocok beserta nomor barisnya (0-based, termasuk header) di sheet
+func (h *AttachmentHandler) findAttachments(ctx context.Context, match func(*finance.Attachment) bool) ([]*finance.Attachment, []int64, error) {
+	service, err := h.apiRepo.GetSheetsService(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("gagal mendapatkan sheets service: %v", err)
+	}
+
+	if _, err := h.ensureSheet(ctx, service); err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := service.Spreadsheets.Values.Get(
+		h.config.SpreadsheetID,
+		attachmentSheetName+"!A2:G", // Skip header row
+	).Do()
+	if err != nil {
+		return nil, nil, fmt.Errorf("gagal membaca data lampiran: %v", err)
+	}
+
+	var attachments []*finance.Attachment
+	var rows []int64
+	for i, row := range resp.Values {
+		if len(row) < 5 {
+			continue
+		}
+
+		attachment := &finance.Attachment{
+			RecordCode: fmt.Sprintf("%v", row[0]),
+			FileName:   fmt.Sprintf("%v", row[2]),
+			MimeType:   fmt.Sprintf("%v", row[3]),
+			URL:        fmt.Sprintf("%v", row[4]),
+		}
+		if len(row) > 5 {
+			attachment.Hash = fmt.Sprintf("%v", row[5])
+		}
+		if len(row) > 6 {
+			attachment.LinkedFrom = fmt.Sprintf("%v", row[6])
+		}
+		if !match(attachment) {
+			continue
+		}
+
+		if uploadedAt, err := time.ParseInLocation(attachmentTimeFormat, fmt.Sprintf("%v", row[1]), time.Local); err == nil {
+			attachment.UploadedAt = uploadedAt
+		}
+
+		attachments = append(attachments, attachment)
+		rows = append(rows, int64(i+1)) // +1 karena data dimulai setelah header
+	}
+
+	return attachments, rows, nil
+}
+
+// AddAttachment menambahkan lampiran ke sheet
+func (h *AttachmentHandler) AddAttachment(ctx context.Context, attachment *finance.Attachment) error {
+	service, err := h.apiRepo.GetSheetsService(ctx)
+	if err != nil {
+		return fmt.Errorf("gagal mendapatkan sheets service: %v", err)
+	}
+
+	if _, err := h.ensureSheet(ctx, service); err != nil {
+		return err
+	}
+
+	values := []interface{}{
+		attachment.RecordCode,
+		attachment.UploadedAt.Format(attachmentTimeFormat),
+		attachment.FileName,
+		attachment.MimeType,
+		attachment.URL,
+		attachment.Hash,
+		attachment.LinkedFrom,
+	}
+
+	_, err = service.Spreadsheets.Values.Append(
+		h.config.SpreadsheetID,
+		attachmentSheetName+"!A:G",
+		&sheets.ValueRange{Values: [][]interface{}{values}},
+	).ValueInputOption("RAW").Do()
+	if err != nil {
+		return fmt.Errorf("gagal menambahkan lampiran: %v", err)
+	}
+
+	h.log.Info("Lampiran %s ditambahkan untuk transaksi %s", attachment.FileName, attachment.RecordCode)
+	return nil
+}
+
+// DeleteAttachments menghapus seluruh baris lampiran untuk kode transaksi
+func (h *AttachmentHandler) DeleteAttachments(ctx context.Context, code string) error {
+	_, rows, err := h.findAttachments(ctx, func(a *finance.Attachment) bool {
+		return a.RecordCode == code
+	})
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	service, err := h.apiRepo.GetSheetsService(ctx)
+	if err != nil {
+		return fmt.Errorf("gagal mendapatkan sheets service: %v", err)
+	}
+
+	sheetID, err := h.ensureSheet(ctx, service)
+	if err != nil {
+		return err
+	}
+
+	// Hapus dari baris paling bawah agar indeks baris lain tidak bergeser
+	sort.Slice(rows, func(i, j int) bool { return rows[i] > rows[j] })
+
+	requests := make([]*sheets.Request, 0, len(rows))
+	for _, row := range rows {
+		requests = append(requests, &sheets.Request{
+			DeleteDimension: &sheets.DeleteDimensionRequest{
+				Range: &sheets.DimensionRange{
+					SheetId:    sheetID,
+					Dimension:  "ROWS",
+					StartIndex: row,
+					EndIndex:   row + 1,
+				},
+			},
+		})
+	}
+
+	_, err = service.Spreadsheets.BatchUpdate(h.config.SpreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: requests,
+	}).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("gagal menghapus lampiran: %v", err)
+	}
+
+	h.log.Info("%d lampiran dihapus untuk transaksi %s", len(rows), code)
+	return nil
+}
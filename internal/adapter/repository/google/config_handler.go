@@ -3,16 +3,15 @@ package google
 import (
 	"context"
 	"fmt"
-	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/money"
 	"github.com/gwenziro/botopia/internal/infrastructure/config"
 	"github.com/gwenziro/botopia/internal/infrastructure/logger"
-	"github.com/gwenziro/botopia/internal/utils" // Added utils package import
 	"google.golang.org/api/sheets/v4"
 )
 
@@ -164,7 +163,7 @@ func (h *ConfigHandler) SortAndLimitRecords(records []*finance.FinanceRecord, li
 }
 
 // FindRecordByCode mencari record berdasarkan kode unik
-func (h *ConfigHandler) FindRecordByCode(ctx context.Context, code string) (*finance.FinanceRecord, error) {
+func (h *ConfigHandler) FindRecordByCode(ctx context.Context, spreadsheetID, code string) (*finance.FinanceRecord, error) {
 	// Tentukan sheet berdasarkan awalan kode
 	sheetName := "Pengeluaran"
 	if strings.HasPrefix(code, "m_") {
@@ -179,8 +178,8 @@ func (h *ConfigHandler) FindRecordByCode(ctx context.Context, code string) (*fin
 
 	// Ambil semua data dari sheet dengan range yang lebih lengkap untuk mendapatkan semua kolom
 	resp, err := service.Spreadsheets.Values.Get(
-		h.config.SpreadsheetID,
-		fmt.Sprintf("%s!A2:K", sheetName),
+		spreadsheetID,
+		fmt.Sprintf("%s!A2:M", sheetName),
 	).Do()
 	if err != nil {
 		return nil, fmt.Errorf("gagal membaca data sheet: %v", err)
@@ -226,37 +225,12 @@ func (h *ConfigHandler) FindRecordByCode(ctx context.Context, code string) (*fin
 					amountStr := fmt.Sprintf("%v", row[5])
 					h.log.Debug("Parsing amount from raw value: '%s'", amountStr)
 
-					// Use our utility function to parse money value
-					amount, err := utils.ParseMoney(amountStr)
+					amount, err := money.Parse(amountStr)
 					if err == nil {
 						record.Amount = amount
-						h.log.Debug("Successfully parsed amount: %.2f", amount)
+						h.log.Debug("Successfully parsed amount: %s", amount)
 					} else {
-						h.log.Warn("Failed to parse with utils.ParseMoney: %v, trying manual parsing", err)
-
-						// Fallback to additional methods for robustness
-
-						// Remove any non-numeric character except dots and commas
-						cleanStr := regexp.MustCompile(`[^\d,.]`).ReplaceAllString(amountStr, "")
-
-						// Try various number formats
-						formats := []string{
-							cleanStr,                                // As is
-							strings.Replace(cleanStr, ",", ".", -1), // Replace comma with dot
-							strings.Replace(strings.Replace(cleanStr, ".", "", -1), ",", ".", -1), // 1.234,56 -> 1234.56
-						}
-
-						for i, format := range formats {
-							if format == "" {
-								continue
-							}
-							amount, err := strconv.ParseFloat(format, 64)
-							if err == nil {
-								record.Amount = amount
-								h.log.Debug("Successfully parsed amount with format #%d: %.2f", i+1, amount)
-								break
-							}
-						}
+						h.log.Warn("Nominal %q tidak dapat dibaca: %v", amountStr, err)
 					}
 				}
 				if len(row) > 6 && row[6] != nil {
@@ -264,6 +238,7 @@ func (h *ConfigHandler) FindRecordByCode(ctx context.Context, code string) (*fin
 				}
 
 				// Sheet specific fields
+				tagColumn := ""
 				if sheetName == "Pengeluaran" {
 					if len(row) > 7 && row[7] != nil {
 						record.PaymentMethod = fmt.Sprintf("%v", row[7])
@@ -277,6 +252,12 @@ func (h *ConfigHandler) FindRecordByCode(ctx context.Context, code string) (*fin
 					if len(row) > 10 && row[10] != nil {
 						record.ProofURL = fmt.Sprintf("%v", row[10])
 					}
+					if len(row) > 11 && row[11] != nil {
+						tagColumn = fmt.Sprintf("%v", row[11])
+					}
+					if len(row) > 12 && row[12] != nil {
+						record.Member = fmt.Sprintf("%v", row[12])
+					}
 				} else { // Pemasukan
 					if len(row) > 7 && row[7] != nil {
 						record.StorageMedia = fmt.Sprintf("%v", row[7])
@@ -287,9 +268,16 @@ func (h *ConfigHandler) FindRecordByCode(ctx context.Context, code string) (*fin
 					if len(row) > 9 && row[9] != nil {
 						record.ProofURL = fmt.Sprintf("%v", row[9])
 					}
+					if len(row) > 10 && row[10] != nil {
+						tagColumn = fmt.Sprintf("%v", row[10])
+					}
+					if len(row) > 11 && row[11] != nil {
+						record.Member = fmt.Sprintf("%v", row[11])
+					}
 				}
+				record.Tags = finance.ParseTags(tagColumn, record.Description, record.Notes)
 
-				h.log.Info("Record ditemukan dengan kode %s, nominal: %.2f", code, record.Amount)
+				h.log.Info("Record ditemukan dengan kode %s, nominal: %s", code, record.Amount)
 				return record, nil
 			}
 		}
@@ -299,8 +287,37 @@ func (h *ConfigHandler) FindRecordByCode(ctx context.Context, code string) (*fin
 	return nil, nil
 }
 
+// HasRecord memeriksa apakah kode transaksi ada di spreadsheet tertentu
+func (h *ConfigHandler) HasRecord(ctx context.Context, spreadsheetID, code string) (bool, error) {
+	// Tentukan sheet berdasarkan awalan kode
+	sheetName := "Pengeluaran"
+	if strings.HasPrefix(code, "m_") {
+		sheetName = "Pemasukan"
+	}
+
+	service, err := h.apiRepo.GetSheetsService(ctx)
+	if err != nil {
+		return false, fmt.Errorf("gagal mendapatkan sheets service: %v", err)
+	}
+
+	resp, err := service.Spreadsheets.Values.Get(
+		spreadsheetID,
+		fmt.Sprintf("%s!B2:B", sheetName),
+	).Do()
+	if err != nil {
+		return false, fmt.Errorf("gagal membaca data sheet: %v", err)
+	}
+
+	for _, row := range resp.Values {
+		if len(row) >= 1 && fmt.Sprintf("%v", row[0]) == code {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // UpdateRecordProof memperbarui URL bukti transaksi
-func (h *ConfigHandler) UpdateRecordProof(ctx context.Context, code string, proofURL string) error {
+func (h *ConfigHandler) UpdateRecordProof(ctx context.Context, spreadsheetID, code string, proofURL string) error {
 	// Tentukan sheet berdasarkan awalan kode
 	sheetName := "Pengeluaran"
 	if strings.HasPrefix(code, "m_") {
@@ -321,7 +338,7 @@ func (h *ConfigHandler) UpdateRecordProof(ctx context.Context, code string, proo
 
 	// Ambil semua data dari sheet
 	resp, err := service.Spreadsheets.Values.Get(
-		h.config.SpreadsheetID,
+		spreadsheetID,
 		fmt.Sprintf("%s!A2:B", sheetName),
 	).Do()
 	if err != nil {
@@ -350,7 +367,7 @@ func (h *ConfigHandler) UpdateRecordProof(ctx context.Context, code string, proo
 	}
 
 	_, err = service.Spreadsheets.Values.Update(
-		h.config.SpreadsheetID,
+		spreadsheetID,
 		updateRange,
 		valueRange,
 	).ValueInputOption("USER_ENTERED").Do()
@@ -361,3 +378,151 @@ func (h *ConfigHandler) UpdateRecordProof(ctx context.Context, code string, proo
 
 	return nil
 }
+
+// UpdateRecord memperbarui kolom data transaksi (tanggal hingga keterangan) dan kolom Tag berdasarkan kode unik
+func (h *ConfigHandler) UpdateRecord(ctx context.Context, spreadsheetID string, record *finance.FinanceRecord) error {
+	// Tentukan sheet berdasarkan awalan kode
+	sheetName := "Pengeluaran"
+	if strings.HasPrefix(record.UniqueCode, "m_") {
+		sheetName = "Pemasukan"
+	}
+
+	service, err := h.apiRepo.GetSheetsService(ctx)
+	if err != nil {
+		return fmt.Errorf("gagal mendapatkan sheets service: %v", err)
+	}
+
+	// Ambil kolom kode untuk mencari baris
+	resp, err := service.Spreadsheets.Values.Get(
+		spreadsheetID,
+		fmt.Sprintf("%s!A2:B", sheetName),
+	).Do()
+	if err != nil {
+		return fmt.Errorf("gagal membaca data sheet: %v", err)
+	}
+
+	rowIndex := -1
+	for i, row := range resp.Values {
+		if len(row) >= 2 && fmt.Sprintf("%v", row[1]) == record.UniqueCode {
+			rowIndex = i + 2 // +2 karena kita mulai dari A2
+			break
+		}
+	}
+
+	if rowIndex == -1 {
+		return fmt.Errorf("record dengan kode %s tidak ditemukan", record.UniqueCode)
+	}
+
+	// Kolom C sampai kolom keterangan, nomor, kode, dan bukti tidak diubah
+	values := []interface{}{
+		record.Date.Format("02/01/2006"),
+		record.Description,
+		"",
+		record.Amount.Float64(),
+		record.Category,
+	}
+	lastColumn, tagColumn := "I", "K"
+	if sheetName == "Pengeluaran" {
+		values = append(values, record.PaymentMethod)
+		lastColumn, tagColumn = "J", "L"
+	}
+	values = append(values, record.StorageMedia, record.Notes)
+
+	// Kolom Tag berada setelah kolom bukti sehingga diperbarui sebagai range terpisah
+	_, err = service.Spreadsheets.Values.BatchUpdate(
+		spreadsheetID,
+		&sheets.BatchUpdateValuesRequest{
+			ValueInputOption: "USER_ENTERED",
+			Data: []*sheets.ValueRange{
+				{
+					Range:  fmt.Sprintf("%s!C%d:%s%d", sheetName, rowIndex, lastColumn, rowIndex),
+					Values: [][]interface{}{values},
+				},
+				{
+					Range:  fmt.Sprintf("%s!%s%d", sheetName, tagColumn, rowIndex),
+					Values: [][]interface{}{{finance.FormatTags(record.Tags)}},
+				},
+			},
+		},
+	).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("gagal memperbarui sheet: %v", err)
+	}
+
+	h.log.Info("Record dengan kode %s diperbarui di sheet %s", record.UniqueCode, sheetName)
+	return nil
+}
+
+// DeleteRecord menghapus baris transaksi berdasarkan kode unik
+func (h *ConfigHandler) DeleteRecord(ctx context.Context, spreadsheetID, code string) error {
+	// Tentukan sheet berdasarkan awalan kode
+	sheetName := "Pengeluaran"
+	if strings.HasPrefix(code, "m_") {
+		sheetName = "Pemasukan"
+	}
+
+	service, err := h.apiRepo.GetSheetsService(ctx)
+	if err != nil {
+		return fmt.Errorf("gagal mendapatkan sheets service: %v", err)
+	}
+
+	// Cari ID sheet untuk permintaan hapus baris
+	spreadsheet, err := service.Spreadsheets.Get(spreadsheetID).
+		Fields("sheets.properties").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return fmt.Errorf("gagal membaca metadata spreadsheet: %v", err)
+	}
+
+	var sheetID int64 = -1
+	for _, sheet := range spreadsheet.Sheets {
+		if sheet.Properties != nil && sheet.Properties.Title == sheetName {
+			sheetID = sheet.Properties.SheetId
+			break
+		}
+	}
+	if sheetID < 0 {
+		return fmt.Errorf("sheet %s tidak ditemukan", sheetName)
+	}
+
+	// Ambil kolom kode untuk mencari baris
+	resp, err := service.Spreadsheets.Values.Get(
+		spreadsheetID,
+		fmt.Sprintf("%s!A2:B", sheetName),
+	).Do()
+	if err != nil {
+		return fmt.Errorf("gagal membaca data sheet: %v", err)
+	}
+
+	rowIndex := int64(-1)
+	for i, row := range resp.Values {
+		if len(row) >= 2 && fmt.Sprintf("%v", row[1]) == code {
+			rowIndex = int64(i) + 1 // +1 karena data dimulai dari baris kedua (indeks 0 adalah header)
+			break
+		}
+	}
+
+	if rowIndex == -1 {
+		return fmt.Errorf("record dengan kode %s tidak ditemukan", code)
+	}
+
+	_, err = service.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{{
+			DeleteDimension: &sheets.DeleteDimensionRequest{
+				Range: &sheets.DimensionRange{
+					SheetId:    sheetID,
+					Dimension:  "ROWS",
+					StartIndex: rowIndex,
+					EndIndex:   rowIndex + 1,
+				},
+			},
+		}},
+	}).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("gagal menghapus baris transaksi: %v", err)
+	}
+
+	h.log.Info("Record dengan kode %s dihapus dari sheet %s", code, sheetName)
+	return nil
+}
@@ -6,8 +6,10 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"strings"
+	"regexp"
 
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/repository"
 	"github.com/gwenziro/botopia/internal/infrastructure/config"
 	"github.com/gwenziro/botopia/internal/infrastructure/logger"
 
@@ -82,31 +84,24 @@ func (r *DriveRepository) IsConfigured() bool {
 	return r.apiRepo.IsConfigured()
 }
 
-// UploadImage mengunggah file gambar ke Google Drive
-func (r *DriveRepository) UploadImage(ctx context.Context, filePath string, transactionCode string) (string, error) {
+// GetName mengembalikan nama backend penyimpanan
+func (r *DriveRepository) GetName() string {
+	return "google"
+}
+
+// UploadProof mengunggah file bukti transaksi ke Google Drive
+func (r *DriveRepository) UploadProof(ctx context.Context, filePath string, transactionCode string) (string, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return "", fmt.Errorf("gagal membuka file: %v", err)
 	}
 	defer file.Close()
 
-	// Deteksi jenis mime dari ekstensi file
-	mimeType := "image/jpeg" // Default
-	fileExt := strings.ToLower(filepath.Ext(filePath))
-	switch fileExt {
-	case ".png":
-		mimeType = "image/png"
-	case ".pdf":
-		mimeType = "application/pdf"
-	case ".mp4":
-		mimeType = "video/mp4"
-	}
-
 	// Buat nama file yang sesuai
 	fileName := fmt.Sprintf("Bukti_%s_%s", transactionCode, filepath.Base(filePath))
 
 	// Upload file dan dapatkan ID
-	fileID, err := r.UploadFile(ctx, fileName, mimeType, file)
+	fileID, err := r.UploadFile(ctx, fileName, finance.MimeTypeFromPath(filePath), file)
 	if err != nil {
 		return "", fmt.Errorf("gagal mengupload file: %v", err)
 	}
@@ -115,7 +110,29 @@ func (r *DriveRepository) UploadImage(ctx context.Context, filePath string, tran
 	return r.GetFileURL(fileID), nil
 }
 
-// GetDriveService mendapatkan akses ke service Google Drive
-func (r *DriveRepository) GetDriveService(ctx context.Context) (*drive.Service, error) {
-	return r.apiRepo.GetDriveService(ctx)
+// driveFileIDPattern mengambil ID file dari URL Google Drive
+var driveFileIDPattern = regexp.MustCompile(`drive\.google\.com/(?:file/d/|open\?id=)([A-Za-z0-9_-]+)`)
+
+// DownloadProof mengunduh isi file bukti dari Google Drive
+func (r *DriveRepository) DownloadProof(ctx context.Context, proofURL string) ([]byte, error) {
+	matches := driveFileIDPattern.FindStringSubmatch(proofURL)
+	if len(matches) < 2 {
+		return nil, fmt.Errorf("URL bukan file Google Drive: %s", proofURL)
+	}
+
+	service, err := r.apiRepo.GetDriveService(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mendapatkan drive service: %v", err)
+	}
+
+	resp, err := service.Files.Get(matches[1]).Context(ctx).Download()
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengunduh file: %v", err)
+	}
+	defer resp.Body.Close()
+
+	return io.ReadAll(resp.Body)
 }
+
+// Memastikan DriveRepository mengimplementasikan interface repository.ProofStorageRepository
+var _ repository.ProofStorageRepository = (*DriveRepository)(nil)
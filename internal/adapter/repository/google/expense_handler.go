@@ -4,10 +4,10 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/money"
 	"github.com/gwenziro/botopia/internal/infrastructure/config"
 	"github.com/gwenziro/botopia/internal/infrastructure/logger"
 	"google.golang.org/api/sheets/v4"
@@ -37,7 +37,7 @@ func NewExpenseHandler(
 }
 
 // AddRecord menambahkan record pengeluaran ke sheet
-func (h *ExpenseHandler) AddRecord(ctx context.Context, record *finance.FinanceRecord) error {
+func (h *ExpenseHandler) AddRecord(ctx context.Context, spreadsheetID string, record *finance.FinanceRecord) error {
 	h.log.Info("Memulai penambahan record pengeluaran...")
 
 	service, err := h.apiRepo.GetSheetsService(ctx)
@@ -54,7 +54,7 @@ func (h *ExpenseHandler) AddRecord(ctx context.Context, record *finance.FinanceR
 	h.log.Debug("Validasi record berhasil")
 
 	// Dapatkan nomor urut untuk kode unik
-	sequenceNumber, err := h.seqHandler.GetNextSequenceNumber(ctx, service, "Pengeluaran")
+	sequenceNumber, err := h.seqHandler.GetNextSequenceNumber(ctx, service, spreadsheetID, "Pengeluaran", record.Date)
 	if err != nil {
 		h.log.Error("Gagal mendapatkan nomor urut: %v", err)
 		return fmt.Errorf("gagal mendapatkan nomor urut: %v", err)
@@ -70,7 +70,7 @@ func (h *ExpenseHandler) AddRecord(ctx context.Context, record *finance.FinanceR
 	h.log.Debug("Kode unik dibuat: %s", record.UniqueCode)
 
 	// Dapatkan nomor global untuk kolom nomor
-	globalNumber, err := h.seqHandler.GetGlobalRecordNumber(ctx, service, "Pengeluaran")
+	globalNumber, err := h.seqHandler.GetGlobalRecordNumber(ctx, service, spreadsheetID, "Pengeluaran")
 	if err != nil {
 		h.log.Error("Gagal mendapatkan nomor global: %v", err)
 		return fmt.Errorf("gagal mendapatkan nomor global: %v", err)
@@ -83,12 +83,14 @@ func (h *ExpenseHandler) AddRecord(ctx context.Context, record *finance.FinanceR
 		record.Date.Format("02/01/2006"), // Format tanggal DD/MM/YYYY
 		record.Description,               // Deskripsi
 		"",                               // Deskripsi merged dengan kolom sebelumnya
-		record.Amount,                    // Nominal
+		record.Amount.Float64(),          // Nominal, angka sheet lossless hingga ±90 triliun
 		record.Category,                  // Kategori
 		record.PaymentMethod,             // Metode Pembayaran
 		record.StorageMedia,              // Sumber Dana
 		record.Notes,                     // Keterangan (opsional)
 		record.ProofURL,                  // Bukti URL (opsional)
+		finance.FormatTags(record.Tags),  // Tag (opsional)
+		record.Member,                    // Dicatat Oleh (opsional)
 	}
 
 	// Append ke sheet Pengeluaran
@@ -97,8 +99,8 @@ func (h *ExpenseHandler) AddRecord(ctx context.Context, record *finance.FinanceR
 	}
 
 	_, err = service.Spreadsheets.Values.Append(
-		h.config.SpreadsheetID,
-		"Pengeluaran!A:K", // Range sesuai struktur sheet
+		spreadsheetID,
+		"Pengeluaran!A:M", // Range sesuai struktur sheet
 		valueRange,
 	).ValueInputOption("USER_ENTERED").Do()
 
@@ -112,7 +114,7 @@ func (h *ExpenseHandler) AddRecord(ctx context.Context, record *finance.FinanceR
 }
 
 // GetRecords mendapatkan semua record pengeluaran
-func (h *ExpenseHandler) GetRecords(ctx context.Context) ([]*finance.FinanceRecord, error) {
+func (h *ExpenseHandler) GetRecords(ctx context.Context, spreadsheetID string) ([]*finance.FinanceRecord, error) {
 	service, err := h.apiRepo.GetSheetsService(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("gagal mendapatkan sheets service: %v", err)
@@ -122,8 +124,8 @@ func (h *ExpenseHandler) GetRecords(ctx context.Context) ([]*finance.FinanceReco
 
 	// Ambil data pengeluaran
 	expenseResp, err := service.Spreadsheets.Values.Get(
-		h.config.SpreadsheetID,
-		"Pengeluaran!A2:K", // Skip header row
+		spreadsheetID,
+		"Pengeluaran!A2:M", // Skip header row
 	).Do()
 	if err != nil {
 		return nil, fmt.Errorf("gagal membaca data pengeluaran: %v", err)
@@ -184,7 +186,7 @@ func (h *ExpenseHandler) parseRow(row []interface{}) (*finance.FinanceRecord, er
 	}
 
 	if len(row) > 5 && row[5] != nil {
-		amount, err := strconv.ParseFloat(strings.Replace(fmt.Sprintf("%v", row[5]), ",", ".", -1), 64)
+		amount, err := money.Parse(fmt.Sprintf("%v", row[5]))
 		if err != nil {
 			return nil, fmt.Errorf("invalid amount: %v", row[5])
 		}
@@ -211,5 +213,16 @@ func (h *ExpenseHandler) parseRow(row []interface{}) (*finance.FinanceRecord, er
 		record.ProofURL = fmt.Sprintf("%v", row[10])
 	}
 
+	// Baris lama tanpa kolom Tag tetap mendapat tag dari deskripsi dan catatan
+	tagColumn := ""
+	if len(row) > 11 && row[11] != nil {
+		tagColumn = fmt.Sprintf("%v", row[11])
+	}
+	record.Tags = finance.ParseTags(tagColumn, record.Description, record.Notes)
+
+	if len(row) > 12 && row[12] != nil {
+		record.Member = fmt.Sprintf("%v", row[12])
+	}
+
 	return record, nil
 }
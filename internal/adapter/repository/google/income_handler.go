@@ -4,10 +4,10 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/money"
 	"github.com/gwenziro/botopia/internal/infrastructure/config"
 	"github.com/gwenziro/botopia/internal/infrastructure/logger"
 	"google.golang.org/api/sheets/v4"
@@ -37,7 +37,7 @@ func NewIncomeHandler(
 }
 
 // AddRecord menambahkan record pemasukan ke sheet
-func (h *IncomeHandler) AddRecord(ctx context.Context, record *finance.FinanceRecord) error {
+func (h *IncomeHandler) AddRecord(ctx context.Context, spreadsheetID string, record *finance.FinanceRecord) error {
 	h.log.Info("Memulai penambahan record pemasukan...")
 
 	service, err := h.apiRepo.GetSheetsService(ctx)
@@ -54,7 +54,7 @@ func (h *IncomeHandler) AddRecord(ctx context.Context, record *finance.FinanceRe
 	h.log.Debug("Validasi record berhasil")
 
 	// Dapatkan nomor urut dan buat kode unik
-	sequenceNumber, err := h.seqHandler.GetNextSequenceNumber(ctx, service, "Pemasukan")
+	sequenceNumber, err := h.seqHandler.GetNextSequenceNumber(ctx, service, spreadsheetID, "Pemasukan", record.Date)
 	if err != nil {
 		h.log.Error("Gagal mendapatkan nomor urut: %v", err)
 		return fmt.Errorf("gagal mendapatkan nomor urut: %v", err)
@@ -70,7 +70,7 @@ func (h *IncomeHandler) AddRecord(ctx context.Context, record *finance.FinanceRe
 	h.log.Debug("Kode unik dibuat: %s", record.UniqueCode)
 
 	// Dapatkan nomor global untuk kolom nomor
-	globalNumber, err := h.seqHandler.GetGlobalRecordNumber(ctx, service, "Pemasukan")
+	globalNumber, err := h.seqHandler.GetGlobalRecordNumber(ctx, service, spreadsheetID, "Pemasukan")
 	if err != nil {
 		h.log.Error("Gagal mendapatkan nomor global: %v", err)
 		return fmt.Errorf("gagal mendapatkan nomor global: %v", err)
@@ -83,11 +83,13 @@ func (h *IncomeHandler) AddRecord(ctx context.Context, record *finance.FinanceRe
 		record.Date.Format("02/01/2006"), // Format tanggal DD/MM/YYYY
 		record.Description,               // Deskripsi
 		"",                               // Deskripsi merged dengan kolom sebelumnya
-		record.Amount,                    // Nominal
+		record.Amount.Float64(),          // Nominal, angka sheet lossless hingga ±90 triliun
 		record.Category,                  // Kategori
 		record.StorageMedia,              // Media Penyimpanan
 		record.Notes,                     // Keterangan (opsional)
 		record.ProofURL,                  // Bukti URL (opsional)
+		finance.FormatTags(record.Tags),  // Tag (opsional)
+		record.Member,                    // Dicatat Oleh (opsional)
 	}
 
 	// Append ke sheet Pemasukan
@@ -96,8 +98,8 @@ func (h *IncomeHandler) AddRecord(ctx context.Context, record *finance.FinanceRe
 	}
 
 	_, err = service.Spreadsheets.Values.Append(
-		h.config.SpreadsheetID,
-		"Pemasukan!A:J", // Range sesuai struktur sheet
+		spreadsheetID,
+		"Pemasukan!A:L", // Range sesuai struktur sheet
 		valueRange,
 	).ValueInputOption("USER_ENTERED").Do()
 
@@ -111,7 +113,7 @@ func (h *IncomeHandler) AddRecord(ctx context.Context, record *finance.FinanceRe
 }
 
 // GetRecords mendapatkan semua record pemasukan
-func (h *IncomeHandler) GetRecords(ctx context.Context) ([]*finance.FinanceRecord, error) {
+func (h *IncomeHandler) GetRecords(ctx context.Context, spreadsheetID string) ([]*finance.FinanceRecord, error) {
 	service, err := h.apiRepo.GetSheetsService(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("gagal mendapatkan sheets service: %v", err)
@@ -121,8 +123,8 @@ func (h *IncomeHandler) GetRecords(ctx context.Context) ([]*finance.FinanceRecor
 
 	// Ambil data pemasukan
 	incomeResp, err := service.Spreadsheets.Values.Get(
-		h.config.SpreadsheetID,
-		"Pemasukan!A2:J", // Skip header row
+		spreadsheetID,
+		"Pemasukan!A2:L", // Skip header row
 	).Do()
 	if err != nil {
 		return nil, fmt.Errorf("gagal membaca data pemasukan: %v", err)
@@ -183,7 +185,7 @@ func (h *IncomeHandler) parseRow(row []interface{}) (*finance.FinanceRecord, err
 	}
 
 	if len(row) > 5 && row[5] != nil {
-		amount, err := strconv.ParseFloat(strings.Replace(fmt.Sprintf("%v", row[5]), ",", ".", -1), 64)
+		amount, err := money.Parse(fmt.Sprintf("%v", row[5]))
 		if err != nil {
 			return nil, fmt.Errorf("invalid amount: %v", row[5])
 		}
@@ -206,5 +208,16 @@ func (h *IncomeHandler) parseRow(row []interface{}) (*finance.FinanceRecord, err
 		record.ProofURL = fmt.Sprintf("%v", row[9])
 	}
 
+	// Baris lama tanpa kolom Tag tetap mendapat tag dari deskripsi dan catatan
+	tagColumn := ""
+	if len(row) > 10 && row[10] != nil {
+		tagColumn = fmt.Sprintf("%v", row[10])
+	}
+	record.Tags = finance.ParseTags(tagColumn, record.Description, record.Notes)
+
+	if len(row) > 11 && row[11] != nil {
+		record.Member = fmt.Sprintf("%v", row[11])
+	}
+
 	return record, nil
 }
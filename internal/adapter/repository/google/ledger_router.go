@@ -0,0 +1,260 @@
+package google
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/repository"
+	"github.com/gwenziro/botopia/internal/infrastructure/config"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+// ledgerSheets repository spreadsheet milik satu ledger beserta ID yang dipakai saat dibuat
+type ledgerSheets struct {
+	spreadsheetID string
+	driveFolderID string
+	repo          *SheetsRepository
+}
+
+// LedgerRouter mengarahkan operasi keuangan ke spreadsheet ledger yang tersimpan di context.
+// Context tanpa ledger memakai spreadsheet utama dari konfigurasi aplikasi.
+type LedgerRouter struct {
+	apiRepo    *GoogleAPIRepository
+	config     *config.Config
+	ledgerRepo repository.LedgerRepository
+	main       *SheetsRepository
+	log        *logger.Logger
+
+	// ledgers cache repository per ID ledger; dibuat ulang jika spreadsheet ledger diganti
+	ledgers map[string]*ledgerSheets
+	mutex   sync.Mutex
+}
+
+// NewLedgerRouter membuat instance router ledger baru
+func NewLedgerRouter(
+	apiRepo *GoogleAPIRepository,
+	config *config.Config,
+	ledgerRepo repository.LedgerRepository,
+	main *SheetsRepository,
+	log *logger.Logger,
+) *LedgerRouter {
+	return &LedgerRouter{
+		apiRepo:    apiRepo,
+		config:     config,
+		ledgerRepo: ledgerRepo,
+		main:       main,
+		log:        log,
+		ledgers:    make(map[string]*ledgerSheets),
+	}
+}
+
+// Memastikan LedgerRouter mengimplementasikan interface repository.FinanceRepository
+var _ repository.FinanceRepository = (*LedgerRouter)(nil)
+
+// repoFor mengembalikan repository spreadsheet untuk ledger di context
+func (r *LedgerRouter) repoFor(ctx context.Context) (*SheetsRepository, error) {
+	ledgerID := finance.LedgerFromContext(ctx)
+	if ledgerID == finance.DefaultLedgerID {
+		return r.main, nil
+	}
+
+	ledger, err := r.ledgerRepo.FindByID(ctx, ledgerID)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca ledger %s: %v", ledgerID, err)
+	}
+	if ledger == nil {
+		// Transaksi tidak boleh jatuh ke spreadsheet utama milik pengguna lain
+		return nil, fmt.Errorf("ledger %s tidak ditemukan", ledgerID)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	cached := r.ledgers[ledgerID]
+	if cached != nil && cached.spreadsheetID == ledger.SpreadsheetID && cached.driveFolderID == ledger.DriveFolderID {
+		return cached.repo, nil
+	}
+
+	// Setiap ledger memakai salinan konfigurasi Google Sheets dengan spreadsheet dan folder miliknya
+	sheetsConfig := *r.config.GoogleSheets
+	sheetsConfig.SpreadsheetID = ledger.SpreadsheetID
+	// Partisi ledger disalin dari spreadsheet ledger itu sendiri, bukan template ledger utama
+	sheetsConfig.TemplateSpreadsheetID = ""
+	if ledger.DriveFolderID != "" {
+		sheetsConfig.DriveFolderID = ledger.DriveFolderID
+	}
+	appConfig := *r.config
+	appConfig.GoogleSheets = &sheetsConfig
+
+	cached = &ledgerSheets{
+		spreadsheetID: ledger.SpreadsheetID,
+		driveFolderID: ledger.DriveFolderID,
+		repo:          NewSheetsRepository(r.apiRepo, &appConfig, r.log),
+	}
+	r.ledgers[ledgerID] = cached
+	r.log.Info("Ledger %s memakai spreadsheet %s", ledgerID, ledger.SpreadsheetID)
+
+	return cached.repo, nil
+}
+
+// AddExpenseRecord menambahkan record pengeluaran ke ledger di context
+func (r *LedgerRouter) AddExpenseRecord(ctx context.Context, record *finance.FinanceRecord) error {
+	repo, err := r.repoFor(ctx)
+	if err != nil {
+		return err
+	}
+	return repo.AddExpenseRecord(ctx, record)
+}
+
+// AddIncomeRecord menambahkan record pemasukan ke ledger di context
+func (r *LedgerRouter) AddIncomeRecord(ctx context.Context, record *finance.FinanceRecord) error {
+	repo, err := r.repoFor(ctx)
+	if err != nil {
+		return err
+	}
+	return repo.AddIncomeRecord(ctx, record)
+}
+
+// GetRecentRecords mendapatkan record terbaru dari ledger di context
+func (r *LedgerRouter) GetRecentRecords(ctx context.Context, limit int) ([]*finance.FinanceRecord, error) {
+	repo, err := r.repoFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repo.GetRecentRecords(ctx, limit)
+}
+
+// GetAllRecords mendapatkan seluruh record dari ledger di context
+func (r *LedgerRouter) GetAllRecords(ctx context.Context) ([]*finance.FinanceRecord, error) {
+	repo, err := r.repoFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repo.GetAllRecords(ctx)
+}
+
+// GetRecordsBetween mendapatkan record ledger di context yang mencakup rentang tanggal
+func (r *LedgerRouter) GetRecordsBetween(ctx context.Context, from, to time.Time) ([]*finance.FinanceRecord, error) {
+	repo, err := r.repoFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repo.GetRecordsBetween(ctx, from, to)
+}
+
+// EnsurePartition memastikan spreadsheet partisi ledger di context tersedia
+func (r *LedgerRouter) EnsurePartition(ctx context.Context, date time.Time) (string, bool, error) {
+	repo, err := r.repoFor(ctx)
+	if err != nil {
+		return "", false, err
+	}
+	return repo.EnsurePartition(ctx, date)
+}
+
+// GetConfiguration mendapatkan konfigurasi dari sheet Konfigurasi ledger di context
+func (r *LedgerRouter) GetConfiguration(ctx context.Context) (*finance.Configuration, error) {
+	repo, err := r.repoFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repo.GetConfiguration(ctx)
+}
+
+// IsConfigured memeriksa apakah spreadsheet utama sudah dikonfigurasi
+func (r *LedgerRouter) IsConfigured() bool {
+	return r.main.IsConfigured()
+}
+
+// GetSpreadsheetURL mendapatkan URL spreadsheet utama
+func (r *LedgerRouter) GetSpreadsheetURL() string {
+	return r.main.GetSpreadsheetURL()
+}
+
+// GetSheetsService mendapatkan akses ke service Google Sheets
+func (r *LedgerRouter) GetSheetsService(ctx context.Context) (interface{}, error) {
+	return r.main.GetSheetsService(ctx)
+}
+
+// FindRecordByCode mencari record berdasarkan kode unik di ledger context
+func (r *LedgerRouter) FindRecordByCode(ctx context.Context, code string) (*finance.FinanceRecord, error) {
+	repo, err := r.repoFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repo.FindRecordByCode(ctx, code)
+}
+
+// UpdateRecordProof memperbarui URL bukti transaksi di ledger context
+func (r *LedgerRouter) UpdateRecordProof(ctx context.Context, code string, proofURL string) error {
+	repo, err := r.repoFor(ctx)
+	if err != nil {
+		return err
+	}
+	return repo.UpdateRecordProof(ctx, code, proofURL)
+}
+
+// UpdateRecord memperbarui data record di ledger context
+func (r *LedgerRouter) UpdateRecord(ctx context.Context, record *finance.FinanceRecord) error {
+	repo, err := r.repoFor(ctx)
+	if err != nil {
+		return err
+	}
+	return repo.UpdateRecord(ctx, record)
+}
+
+// DeleteRecord menghapus record di ledger context
+func (r *LedgerRouter) DeleteRecord(ctx context.Context, code string) error {
+	repo, err := r.repoFor(ctx)
+	if err != nil {
+		return err
+	}
+	return repo.DeleteRecord(ctx, code)
+}
+
+// GetAttachments mendapatkan lampiran bukti dari ledger context
+func (r *LedgerRouter) GetAttachments(ctx context.Context, code string) ([]*finance.Attachment, error) {
+	repo, err := r.repoFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repo.GetAttachments(ctx, code)
+}
+
+// AddAttachment menambahkan lampiran bukti ke ledger context
+func (r *LedgerRouter) AddAttachment(ctx context.Context, attachment *finance.Attachment) error {
+	repo, err := r.repoFor(ctx)
+	if err != nil {
+		return err
+	}
+	return repo.AddAttachment(ctx, attachment)
+}
+
+// FindAttachmentByHash mencari lampiran dengan hash konten tertentu di ledger context
+func (r *LedgerRouter) FindAttachmentByHash(ctx context.Context, hash string) (*finance.Attachment, error) {
+	repo, err := r.repoFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repo.FindAttachmentByHash(ctx, hash)
+}
+
+// DeleteAttachments menghapus lampiran bukti di ledger context
+func (r *LedgerRouter) DeleteAttachments(ctx context.Context, code string) error {
+	repo, err := r.repoFor(ctx)
+	if err != nil {
+		return err
+	}
+	return repo.DeleteAttachments(ctx, code)
+}
+
+// UpdateConfiguration memperbarui konfigurasi ledger context
+func (r *LedgerRouter) UpdateConfiguration(ctx context.Context, config *finance.Configuration) error {
+	repo, err := r.repoFor(ctx)
+	if err != nil {
+		return err
+	}
+	return repo.UpdateConfiguration(ctx, config)
+}
@@ -0,0 +1,351 @@
+package google
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/infrastructure/config"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/sheets/v4"
+)
+
+// partitionRange adalah kolom sheet Konfigurasi (spreadsheet utama) yang menyimpan peta tahun ke ID spreadsheet
+const partitionRange = "Konfigurasi!H2:I"
+
+// partitionMaxFutureYears batas tahun ke depan yang masih diterima untuk tanggal transaksi;
+// tanggal yang lebih jauh hampir pasti salah ketik dan tidak boleh membuat partisi baru
+const partitionMaxFutureYears = 1
+
+// partitionCacheTTL adalah lama peta partisi disimpan sebelum dibaca ulang dari sheet Konfigurasi
+const partitionCacheTTL = 10 * time.Minute
+
+// partitionDataRanges adalah baris data yang dikosongkan pada spreadsheet partisi baru
+var partitionDataRanges = map[string]string{
+	"Pengeluaran":       "Pengeluaran!A2:M",
+	"Pemasukan":         "Pemasukan!A2:L",
+	attachmentSheetName: attachmentSheetName + "!A2:G",
+	"Konfigurasi":       partitionRange,
+}
+
+// PartitionHandler mengatur pembagian transaksi ke spreadsheet per periode (misalnya per tahun).
+// Spreadsheet utama tetap menyimpan Konfigurasi, Lampiran, dan transaksi tahun yang belum dipartisi.
+type PartitionHandler struct {
+	apiRepo *GoogleAPIRepository
+	config  *config.GoogleSheetsConfig
+	log     *logger.Logger
+
+	// spreadsheets peta tahun awal partisi ke ID spreadsheet
+	spreadsheets map[int]string
+	loadedAt     time.Time
+	mutex        sync.RWMutex
+
+	// createMutex mencegah partisi yang sama dibuat dua kali secara bersamaan
+	createMutex sync.Mutex
+}
+
+// NewPartitionHandler membuat instance partition handler baru
+func NewPartitionHandler(
+	apiRepo *GoogleAPIRepository,
+	config *config.GoogleSheetsConfig,
+	log *logger.Logger,
+) *PartitionHandler {
+	return &PartitionHandler{
+		apiRepo: apiRepo,
+		config:  config,
+		log:     log,
+	}
+}
+
+// Spreadsheets mengembalikan salinan peta tahun awal partisi ke ID spreadsheet
+func (h *PartitionHandler) Spreadsheets(ctx context.Context) (map[int]string, error) {
+	if err := h.load(ctx, false); err != nil {
+		return nil, err
+	}
+
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	copied := make(map[int]string, len(h.spreadsheets))
+	for year, id := range h.spreadsheets {
+		copied[year] = id
+	}
+	return copied, nil
+}
+
+// SpreadsheetForDate mengembalikan spreadsheet yang menyimpan transaksi pada tanggal tertentu
+func (h *PartitionHandler) SpreadsheetForDate(ctx context.Context, date time.Time) string {
+	if h.config.RolloverYears <= 0 {
+		return h.config.SpreadsheetID
+	}
+
+	spreadsheets, err := h.Spreadsheets(ctx)
+	if err != nil {
+		h.log.Warn("Gagal membaca peta spreadsheet, menggunakan spreadsheet utama: %v", err)
+		return h.config.SpreadsheetID
+	}
+
+	if id, exists := spreadsheets[finance.PartitionStart(date.Year(), h.config.RolloverYears)]; exists {
+		return id
+	}
+	return h.config.SpreadsheetID
+}
+
+// SpreadsheetsForCode mengembalikan kandidat spreadsheet untuk kode transaksi: partisi tahun kode,
+// lalu spreadsheet utama untuk transaksi yang dicatat sebelum partisi tersebut dibuat
+func (h *PartitionHandler) SpreadsheetsForCode(ctx context.Context, code string) []string {
+	candidates := []string{}
+	if year, ok := finance.CodeYear(code); ok {
+		candidates = append(candidates, h.SpreadsheetForDate(ctx, time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)))
+	}
+	if len(candidates) == 0 || candidates[0] != h.config.SpreadsheetID {
+		candidates = append(candidates, h.config.SpreadsheetID)
+	}
+	return candidates
+}
+
+// SpreadsheetsBetween mengembalikan seluruh spreadsheet yang mungkin berisi transaksi dalam rentang tanggal.
+// Spreadsheet utama selalu ikut karena menyimpan transaksi sebelum partisi dibuat.
+func (h *PartitionHandler) SpreadsheetsBetween(ctx context.Context, from, to time.Time) ([]string, error) {
+	ids := []string{h.config.SpreadsheetID}
+	if h.config.RolloverYears <= 0 {
+		return ids, nil
+	}
+
+	spreadsheets, err := h.Spreadsheets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	years := make([]int, 0, len(spreadsheets))
+	for year := range spreadsheets {
+		years = append(years, year)
+	}
+	sort.Ints(years)
+
+	seen := map[string]bool{h.config.SpreadsheetID: true}
+	for _, year := range years {
+		id := spreadsheets[year]
+		if seen[id] || !finance.PartitionOverlaps(year, h.config.RolloverYears, from, to) {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// EnsurePartition memastikan partisi untuk tanggal tertentu sudah memiliki spreadsheet dan mengembalikan ID-nya.
+// Saat pertama kali dipakai, spreadsheet utama dicatat sebagai partisi berjalan. Partisi baru hanya dibuat
+// untuk periode berjalan atau periode berikutnya; tanggal lain disimpan di partisi yang sudah mencakupnya
+// atau di spreadsheet utama, dan tanggal yang terlalu jauh di masa depan ditolak.
+func (h *PartitionHandler) EnsurePartition(ctx context.Context, date time.Time) (string, bool, error) {
+	if h.config.RolloverYears <= 0 {
+		return h.config.SpreadsheetID, false, nil
+	}
+
+	now := time.Now()
+	if date.Year() > now.Year()+partitionMaxFutureYears {
+		return "", false, fmt.Errorf("tanggal %s terlalu jauh di masa depan, periksa kembali tahunnya",
+			date.Format("02-01-2006"))
+	}
+	current := finance.PartitionStart(now.Year(), h.config.RolloverYears)
+
+	h.createMutex.Lock()
+	defer h.createMutex.Unlock()
+
+	if err := h.load(ctx, false); err != nil {
+		return "", false, err
+	}
+
+	start := finance.PartitionStart(date.Year(), h.config.RolloverYears)
+
+	h.mutex.RLock()
+	id, exists := h.spreadsheets[start]
+	hasPartition := len(h.spreadsheets) > 0
+	h.mutex.RUnlock()
+
+	if exists {
+		return id, false, nil
+	}
+
+	// Belum ada partisi sama sekali: seluruh transaksi saat ini berada di spreadsheet utama
+	// dan dicatat sebagai partisi periode berjalan, bukan periode tanggal transaksi yang kebetulan pertama
+	if !hasPartition {
+		if err := h.register(ctx, current, h.config.SpreadsheetID); err != nil {
+			return "", false, err
+		}
+		h.log.Info("Spreadsheet utama dicatat sebagai partisi %s",
+			finance.PartitionLabel(current, h.config.RolloverYears))
+		if start == current {
+			return h.config.SpreadsheetID, false, nil
+		}
+	}
+
+	// Hanya periode berjalan dan berikutnya yang boleh membuat spreadsheet baru
+	if start != current && start != current+h.config.RolloverYears {
+		return h.config.SpreadsheetID, false, nil
+	}
+
+	id, err := h.create(ctx, start)
+	if err != nil {
+		return "", false, err
+	}
+	if err := h.register(ctx, start, id); err != nil {
+		return "", false, fmt.Errorf("spreadsheet %s dibuat tapi gagal dicatat di Konfigurasi: %v", id, err)
+	}
+
+	h.log.Info("Spreadsheet partisi %s dibuat dengan ID: %s",
+		finance.PartitionLabel(start, h.config.RolloverYears), id)
+	return id, true, nil
+}
+
+// load membaca peta partisi dari sheet Konfigurasi jika cache sudah kedaluwarsa atau force bernilai true
+func (h *PartitionHandler) load(ctx context.Context, force bool) error {
+	h.mutex.RLock()
+	fresh := h.spreadsheets != nil && time.Since(h.loadedAt) < partitionCacheTTL
+	h.mutex.RUnlock()
+	if fresh && !force {
+		return nil
+	}
+
+	service, err := h.apiRepo.GetSheetsService(ctx)
+	if err != nil {
+		return fmt.Errorf("gagal mendapatkan sheets service: %v", err)
+	}
+
+	resp, err := service.Spreadsheets.Values.Get(h.config.SpreadsheetID, partitionRange).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("gagal membaca peta spreadsheet: %v", err)
+	}
+
+	spreadsheets := make(map[int]string)
+	for _, row := range resp.Values {
+		if len(row) < 2 {
+			continue
+		}
+		year, err := strconv.Atoi(strings.TrimSpace(fmt.Sprintf("%v", row[0])))
+		id := strings.TrimSpace(fmt.Sprintf("%v", row[1]))
+		if err != nil || id == "" {
+			continue
+		}
+		spreadsheets[year] = id
+	}
+
+	h.mutex.Lock()
+	h.spreadsheets = spreadsheets
+	h.loadedAt = time.Now()
+	h.mutex.Unlock()
+
+	return nil
+}
+
+// register mencatat partisi baru di sheet Konfigurasi spreadsheet utama beserta header kolomnya
+func (h *PartitionHandler) register(ctx context.Context, start int, id string) error {
+	service, err := h.apiRepo.GetSheetsService(ctx)
+	if err != nil {
+		return fmt.Errorf("gagal mendapatkan sheets service: %v", err)
+	}
+
+	resp, err := service.Spreadsheets.Values.Get(h.config.SpreadsheetID, partitionRange).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("gagal membaca peta spreadsheet: %v", err)
+	}
+	row := len(resp.Values) + 2
+
+	_, err = service.Spreadsheets.Values.BatchUpdate(h.config.SpreadsheetID, &sheets.BatchUpdateValuesRequest{
+		ValueInputOption: "RAW",
+		Data: []*sheets.ValueRange{
+			{
+				Range:  "Konfigurasi!H1:I1",
+				Values: [][]interface{}{{"Tahun Spreadsheet", "ID Spreadsheet"}},
+			},
+			{
+				Range:  fmt.Sprintf("Konfigurasi!H%d:I%d", row, row),
+				Values: [][]interface{}{{start, id}},
+			},
+		},
+	}).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("gagal mencatat peta spreadsheet: %v", err)
+	}
+
+	h.mutex.Lock()
+	if h.spreadsheets == nil {
+		h.spreadsheets = make(map[int]string)
+	}
+	h.spreadsheets[start] = id
+	h.mutex.Unlock()
+
+	return nil
+}
+
+// create menyalin template (atau spreadsheet utama) menjadi spreadsheet partisi baru tanpa data transaksi
+func (h *PartitionHandler) create(ctx context.Context, start int) (string, error) {
+	sheetsService, err := h.apiRepo.GetSheetsService(ctx)
+	if err != nil {
+		return "", fmt.Errorf("gagal mendapatkan sheets service: %v", err)
+	}
+	driveService, err := h.apiRepo.GetDriveService(ctx)
+	if err != nil {
+		return "", fmt.Errorf("gagal mendapatkan drive service: %v", err)
+	}
+
+	sourceID := h.config.TemplateSpreadsheetID
+	if sourceID == "" {
+		sourceID = h.config.SpreadsheetID
+	}
+
+	main, err := sheetsService.Spreadsheets.Get(h.config.SpreadsheetID).Fields("properties.title").Context(ctx).Do()
+	if err != nil {
+		return "", fmt.Errorf("gagal membaca judul spreadsheet utama: %v", err)
+	}
+
+	file := &drive.File{
+		Name: fmt.Sprintf("%s %s", main.Properties.Title, finance.PartitionLabel(start, h.config.RolloverYears)),
+	}
+	if h.config.DriveFolderID != "" {
+		file.Parents = []string{h.config.DriveFolderID}
+	}
+
+	copied, err := driveService.Files.Copy(sourceID, file).
+		Fields("id").
+		SupportsAllDrives(true).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return "", fmt.Errorf("gagal menyalin spreadsheet template: %v", err)
+	}
+
+	// Kosongkan baris data yang ikut tersalin, header dan format tetap dipertahankan
+	spreadsheet, err := sheetsService.Spreadsheets.Get(copied.Id).Fields("sheets.properties.title").Context(ctx).Do()
+	if err != nil {
+		return "", fmt.Errorf("gagal membaca spreadsheet baru: %v", err)
+	}
+
+	var ranges []string
+	for _, sheet := range spreadsheet.Sheets {
+		if sheet.Properties == nil {
+			continue
+		}
+		if dataRange, exists := partitionDataRanges[sheet.Properties.Title]; exists {
+			ranges = append(ranges, dataRange)
+		}
+	}
+	if len(ranges) > 0 {
+		_, err = sheetsService.Spreadsheets.Values.BatchClear(copied.Id, &sheets.BatchClearValuesRequest{
+			Ranges: ranges,
+		}).Context(ctx).Do()
+		if err != nil {
+			return "", fmt.Errorf("gagal mengosongkan data spreadsheet baru: %v", err)
+		}
+	}
+
+	return copied.Id, nil
+}
@@ -33,23 +33,23 @@ func NewSequenceHandler(
 	}
 }
 
-// GetNextSequenceNumber mendapatkan nomor urut berikutnya dalam bulan ini (untuk kode unik)
-func (h *SequenceHandler) GetNextSequenceNumber(_ context.Context, service *sheets.Service, sheetName string) (int, error) {
-	// Ambil data bulan ini
-	now := time.Now()
-	currentMonth := finance.GetMonthAbbr(now.Month())
-	currentYear := now.Year() % 100
+// GetNextSequenceNumber mendapatkan nomor urut berikutnya dalam bulan transaksi (untuk kode unik).
+// Bulan diambil dari tanggal transaksi agar urutan sesuai dengan kode yang dibuat dan spreadsheet partisinya.
+func (h *SequenceHandler) GetNextSequenceNumber(_ context.Context, service *sheets.Service, spreadsheetID, sheetName string, date time.Time) (int, error) {
+	// Ambil data bulan transaksi
+	currentMonth := finance.GetMonthAbbr(date.Month())
+	currentYear := date.Year() % 100
 	prefix := "k"
 	if sheetName == "Pemasukan" {
 		prefix = "m"
 	}
 
-	// Pattern untuk bulan dan tahun ini: k_mei25_ atau m_mei25_
+	// Pattern untuk bulan dan tahun transaksi: k_mei25_ atau m_mei25_
 	pattern := fmt.Sprintf("%s_%s%02d_", prefix, currentMonth, currentYear)
 
 	// Ambil semua data
 	resp, err := service.Spreadsheets.Values.Get(
-		h.config.SpreadsheetID,
+		spreadsheetID,
 		sheetName+"!A:B", // Kolom A & B (No dan Kode Unik)
 	).Do()
 
@@ -59,7 +59,7 @@ func (h *SequenceHandler) GetNextSequenceNumber(_ context.Context, service *shee
 
 	maxSeq := 0
 
-	// Cari nomor urut maksimal untuk bulan ini
+	// Cari nomor urut maksimal untuk bulan transaksi
 	if len(resp.Values) > 0 {
 		for _, row := range resp.Values {
 			if len(row) >= 2 && row[1] != nil {
@@ -81,10 +81,10 @@ func (h *SequenceHandler) GetNextSequenceNumber(_ context.Context, service *shee
 }
 
 // GetGlobalRecordNumber mendapatkan nomor urut global untuk sheet tertentu
-func (h *SequenceHandler) GetGlobalRecordNumber(_ context.Context, service *sheets.Service, sheetName string) (int, error) {
+func (h *SequenceHandler) GetGlobalRecordNumber(_ context.Context, service *sheets.Service, spreadsheetID, sheetName string) (int, error) {
 	// Ambil semua data
 	resp, err := service.Spreadsheets.Values.Get(
-		h.config.SpreadsheetID,
+		spreadsheetID,
 		sheetName+"!A:A", // Hanya kolom A (nomor)
 	).Do()
 
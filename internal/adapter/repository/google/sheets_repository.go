@@ -3,6 +3,7 @@ package google
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/gwenziro/botopia/internal/domain/finance"
 	"github.com/gwenziro/botopia/internal/infrastructure/config"
@@ -18,6 +19,8 @@ type SheetsRepository struct {
 	incomeHandler  *IncomeHandler
 	configHandler  *ConfigHandler
 	seqHandler     *SequenceHandler
+	attachHandler  *AttachmentHandler
+	partitions     *PartitionHandler
 }
 
 // NewSheetsRepository membuat instance repository baru
@@ -37,6 +40,8 @@ func NewSheetsRepository(
 	repo.expenseHandler = NewExpenseHandler(apiRepo, config.GoogleSheets, repo.seqHandler, log)
 	repo.incomeHandler = NewIncomeHandler(apiRepo, config.GoogleSheets, repo.seqHandler, log)
 	repo.configHandler = NewConfigHandler(apiRepo, config.GoogleSheets, log)
+	repo.attachHandler = NewAttachmentHandler(apiRepo, config.GoogleSheets, log)
+	repo.partitions = NewPartitionHandler(apiRepo, config.GoogleSheets, log)
 
 	return repo
 }
@@ -57,47 +62,166 @@ func (r *SheetsRepository) GetSpreadsheetURL() string {
 	return "https://docs.google.com/spreadsheets/d/" + r.config.SpreadsheetID
 }
 
-// AddExpenseRecord menambahkan record pengeluaran ke sheet
+// AddExpenseRecord menambahkan record pengeluaran ke sheet spreadsheet partisi tanggal transaksi
 func (r *SheetsRepository) AddExpenseRecord(ctx context.Context, record *finance.FinanceRecord) error {
-	return r.expenseHandler.AddRecord(ctx, record)
+	spreadsheetID, _, err := r.partitions.EnsurePartition(ctx, record.Date)
+	if err != nil {
+		return fmt.Errorf("gagal menyiapkan spreadsheet partisi: %v", err)
+	}
+	return r.expenseHandler.AddRecord(ctx, spreadsheetID, record)
 }
 
-// AddIncomeRecord menambahkan record pemasukan ke sheet
+// AddIncomeRecord menambahkan record pemasukan ke sheet spreadsheet partisi tanggal transaksi
 func (r *SheetsRepository) AddIncomeRecord(ctx context.Context, record *finance.FinanceRecord) error {
-	return r.incomeHandler.AddRecord(ctx, record)
+	spreadsheetID, _, err := r.partitions.EnsurePartition(ctx, record.Date)
+	if err != nil {
+		return fmt.Errorf("gagal menyiapkan spreadsheet partisi: %v", err)
+	}
+	return r.incomeHandler.AddRecord(ctx, spreadsheetID, record)
 }
 
-// GetConfiguration mendapatkan konfigurasi dari sheet
+// GetConfiguration mendapatkan konfigurasi dari sheet beserta peta spreadsheet partisi
 func (r *SheetsRepository) GetConfiguration(ctx context.Context) (*finance.Configuration, error) {
-	return r.configHandler.GetConfiguration(ctx)
+	config, err := r.configHandler.GetConfiguration(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	spreadsheets, err := r.partitions.Spreadsheets(ctx)
+	if err != nil {
+		r.log.Warn("Gagal membaca peta spreadsheet partisi: %v", err)
+		spreadsheets = map[int]string{}
+	}
+	config.Spreadsheets = spreadsheets
+
+	return config, nil
+}
+
+// EnsurePartition memastikan spreadsheet partisi untuk tanggal tertentu tersedia
+func (r *SheetsRepository) EnsurePartition(ctx context.Context, date time.Time) (string, bool, error) {
+	return r.partitions.EnsurePartition(ctx, date)
 }
 
 // GetRecentRecords mendapatkan record terbaru (gabungan pemasukan & pengeluaran)
 func (r *SheetsRepository) GetRecentRecords(ctx context.Context, limit int) ([]*finance.FinanceRecord, error) {
-	// Ambil data dari kedua handler
-	incomeRecords, err := r.incomeHandler.GetRecords(ctx)
+	records, err := r.GetAllRecords(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("gagal mengambil data pemasukan: %v", err)
+		return nil, err
 	}
 
-	expenseRecords, err := r.expenseHandler.GetRecords(ctx)
+	return r.configHandler.SortAndLimitRecords(records, limit), nil
+}
+
+// GetAllRecords mendapatkan seluruh record (gabungan pemasukan & pengeluaran) dari semua spreadsheet
+func (r *SheetsRepository) GetAllRecords(ctx context.Context) ([]*finance.FinanceRecord, error) {
+	return r.GetRecordsBetween(ctx, time.Time{}, time.Time{})
+}
+
+// GetRecordsBetween mendapatkan record dari spreadsheet yang mencakup rentang tanggal.
+// Record di luar rentang tetap dapat ikut terbawa; pemanggil menyaring sesuai kebutuhan.
+func (r *SheetsRepository) GetRecordsBetween(ctx context.Context, from, to time.Time) ([]*finance.FinanceRecord, error) {
+	spreadsheetIDs, err := r.partitions.SpreadsheetsBetween(ctx, from, to)
 	if err != nil {
-		return nil, fmt.Errorf("gagal mengambil data pengeluaran: %v", err)
+		return nil, fmt.Errorf("gagal membaca peta spreadsheet partisi: %v", err)
 	}
 
-	// Gabung dan urutkan
-	records := append(incomeRecords, expenseRecords...)
-	return r.configHandler.SortAndLimitRecords(records, limit), nil
+	var records []*finance.FinanceRecord
+	for _, spreadsheetID := range spreadsheetIDs {
+		// Ambil data dari kedua handler
+		incomeRecords, err := r.incomeHandler.GetRecords(ctx, spreadsheetID)
+		if err != nil {
+			return nil, fmt.Errorf("gagal mengambil data pemasukan: %v", err)
+		}
+
+		expenseRecords, err := r.expenseHandler.GetRecords(ctx, spreadsheetID)
+		if err != nil {
+			return nil, fmt.Errorf("gagal mengambil data pengeluaran: %v", err)
+		}
+
+		records = append(records, incomeRecords...)
+		records = append(records, expenseRecords...)
+	}
+
+	return records, nil
 }
 
-// FindRecordByCode mencari record berdasarkan kode unik
+// FindRecordByCode mencari record berdasarkan kode unik di spreadsheet partisi tahun kode
 func (r *SheetsRepository) FindRecordByCode(ctx context.Context, code string) (*finance.FinanceRecord, error) {
-	return r.configHandler.FindRecordByCode(ctx, code)
+	for _, spreadsheetID := range r.partitions.SpreadsheetsForCode(ctx, code) {
+		record, err := r.configHandler.FindRecordByCode(ctx, spreadsheetID, code)
+		if err != nil || record != nil {
+			return record, err
+		}
+	}
+	return nil, nil
 }
 
 // UpdateRecordProof memperbarui URL bukti transaksi
 func (r *SheetsRepository) UpdateRecordProof(ctx context.Context, code string, proofURL string) error {
-	return r.configHandler.UpdateRecordProof(ctx, code, proofURL)
+	spreadsheetID, err := r.spreadsheetForCode(ctx, code)
+	if err != nil {
+		return err
+	}
+	return r.configHandler.UpdateRecordProof(ctx, spreadsheetID, code, proofURL)
+}
+
+// UpdateRecord memperbarui data record berdasarkan kode unik.
+// Record tetap berada di spreadsheet asalnya walaupun tanggalnya dipindah ke tahun lain.
+func (r *SheetsRepository) UpdateRecord(ctx context.Context, record *finance.FinanceRecord) error {
+	spreadsheetID, err := r.spreadsheetForCode(ctx, record.UniqueCode)
+	if err != nil {
+		return err
+	}
+	return r.configHandler.UpdateRecord(ctx, spreadsheetID, record)
+}
+
+// DeleteRecord menghapus record berdasarkan kode unik
+func (r *SheetsRepository) DeleteRecord(ctx context.Context, code string) error {
+	spreadsheetID, err := r.spreadsheetForCode(ctx, code)
+	if err != nil {
+		return err
+	}
+	return r.configHandler.DeleteRecord(ctx, spreadsheetID, code)
+}
+
+// spreadsheetForCode mencari spreadsheet yang menyimpan kode transaksi; jika tidak ditemukan di mana pun,
+// kandidat pertama dikembalikan agar handler melaporkan record tidak ditemukan
+func (r *SheetsRepository) spreadsheetForCode(ctx context.Context, code string) (string, error) {
+	candidates := r.partitions.SpreadsheetsForCode(ctx, code)
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	}
+
+	for _, spreadsheetID := range candidates {
+		found, err := r.configHandler.HasRecord(ctx, spreadsheetID, code)
+		if err != nil {
+			return "", err
+		}
+		if found {
+			return spreadsheetID, nil
+		}
+	}
+	return candidates[0], nil
+}
+
+// GetAttachments mendapatkan seluruh lampiran bukti untuk kode transaksi
+func (r *SheetsRepository) GetAttachments(ctx context.Context, code string) ([]*finance.Attachment, error) {
+	return r.attachHandler.GetAttachments(ctx, code)
+}
+
+// AddAttachment menambahkan lampiran bukti transaksi
+func (r *SheetsRepository) AddAttachment(ctx context.Context, attachment *finance.Attachment) error {
+	return r.attachHandler.AddAttachment(ctx, attachment)
+}
+
+// FindAttachmentByHash mencari lampiran dengan hash konten tertentu
+func (r *SheetsRepository) FindAttachmentByHash(ctx context.Context, hash string) (*finance.Attachment, error) {
+	return r.attachHandler.FindAttachmentByHash(ctx, hash)
+}
+
+// DeleteAttachments menghapus seluruh lampiran bukti untuk kode transaksi
+func (r *SheetsRepository) DeleteAttachments(ctx context.Context, code string) error {
+	return r.attachHandler.DeleteAttachments(ctx, code)
 }
 
 // UpdateConfiguration memperbarui konfigurasi
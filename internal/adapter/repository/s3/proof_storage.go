@@ -0,0 +1,260 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/repository"
+	"github.com/gwenziro/botopia/internal/infrastructure/config"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+// ProofStorage menyimpan bukti transaksi di object storage S3-compatible.
+// Menggunakan path-style URL (endpoint/bucket/key) agar kompatibel dengan MinIO.
+type ProofStorage struct {
+	endpoint  string
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	publicURL string
+	client    *http.Client
+	log       *logger.Logger
+}
+
+// NewProofStorage membuat instance penyimpanan bukti S3 baru
+func NewProofStorage(cfg *config.ProofStorageConfig, log *logger.Logger) *ProofStorage {
+	return &ProofStorage{
+		endpoint:  strings.TrimRight(cfg.S3Endpoint, "/"),
+		region:    cfg.S3Region,
+		bucket:    cfg.S3Bucket,
+		accessKey: cfg.S3AccessKey,
+		secretKey: cfg.S3SecretKey,
+		publicURL: strings.TrimRight(cfg.S3PublicURL, "/"),
+		client:    &http.Client{Timeout: 60 * time.Second},
+		log:       log,
+	}
+}
+
+// Memastikan ProofStorage mengimplementasikan interface repository.ProofStorageRepository
+var _ repository.ProofStorageRepository = (*ProofStorage)(nil)
+
+// GetName mengembalikan nama backend penyimpanan
+func (s *ProofStorage) GetName() string {
+	return "s3"
+}
+
+// IsConfigured memeriksa apakah penyimpanan sudah dikonfigurasi
+func (s *ProofStorage) IsConfigured() bool {
+	return s.endpoint != "" && s.bucket != "" && s.accessKey != "" && s.secretKey != ""
+}
+
+// UploadProof mengunggah file bukti ke bucket dan mengembalikan URL objek
+func (s *ProofStorage) UploadProof(ctx context.Context, filePath string, transactionCode string) (string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("gagal membuka file: %v", err)
+	}
+
+	key := fmt.Sprintf("proofs/Bukti_%s_%d%s", transactionCode, time.Now().UnixNano(), strings.ToLower(filepath.Ext(filePath)))
+	if err := s.putObject(ctx, key, finance.MimeTypeFromPath(filePath), content); err != nil {
+		return "", err
+	}
+
+	s.log.Info("File %s berhasil diunggah ke bucket %s", key, s.bucket)
+	return s.objectURL(key), nil
+}
+
+// DownloadProof mengunduh objek bukti dari bucket berdasarkan URL-nya
+func (s *ProofStorage) DownloadProof(ctx context.Context, proofURL string) ([]byte, error) {
+	key, ok := s.objectKey(proofURL)
+	if !ok {
+		return nil, fmt.Errorf("URL bukan objek bucket %s: %s", s.bucket, proofURL)
+	}
+
+	return s.getObject(ctx, key)
+}
+
+// objectKey mengambil key objek dari URL yang dihasilkan objectURL
+func (s *ProofStorage) objectKey(objectURL string) (string, bool) {
+	prefixes := []string{fmt.Sprintf("%s/%s/", s.endpoint, s.bucket)}
+	if s.publicURL != "" {
+		prefixes = append(prefixes, s.publicURL+"/")
+	}
+
+	for _, prefix := range prefixes {
+		if !strings.HasPrefix(objectURL, prefix) {
+			continue
+		}
+		key, err := url.PathUnescape(strings.TrimPrefix(objectURL, prefix))
+		if err != nil || key == "" || strings.Contains("/"+key+"/", "/../") {
+			return "", false
+		}
+		return key, true
+	}
+
+	return "", false
+}
+
+// objectURL mengembalikan URL publik objek
+func (s *ProofStorage) objectURL(key string) string {
+	if s.publicURL != "" {
+		return s.publicURL + "/" + encodePath(key)
+	}
+	return fmt.Sprintf("%s/%s/%s", s.endpoint, s.bucket, encodePath(key))
+}
+
+// putObject mengirim objek ke bucket menggunakan tanda tangan AWS Signature V4
+func (s *ProofStorage) putObject(ctx context.Context, key, contentType string, content []byte) error {
+	resp, err := s.do(ctx, http.MethodPut, key, contentType, content)
+	if err != nil {
+		return fmt.Errorf("gagal mengunggah ke S3: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("S3 menolak unggahan (status %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+// getObject mengambil isi objek dari bucket
+func (s *ProofStorage) getObject(ctx context.Context, key string) ([]byte, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengunduh dari S3: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("S3 menolak unduhan (status %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// do mengirim request bertanda tangan untuk objek di bucket
+func (s *ProofStorage) do(ctx context.Context, method, key, contentType string, content []byte) (*http.Response, error) {
+	endpoint, err := url.Parse(s.endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("endpoint S3 tidak valid: %v", err)
+	}
+
+	// Prefix path endpoint (misalnya http://host/minio) dipertahankan di depan bucket
+	canonicalURI := strings.TrimRight(endpoint.EscapedPath(), "/") + "/" + encodePath(s.bucket+"/"+key)
+	requestURL := fmt.Sprintf("%s://%s%s", endpoint.Scheme, endpoint.Host, canonicalURI)
+
+	var body io.Reader
+	if content != nil {
+		body = bytes.NewReader(content)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat request S3: %v", err)
+	}
+
+	now := time.Now().UTC()
+	payloadHash := sha256Hex(content)
+
+	req.ContentLength = int64(len(content))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	req.Header.Set("X-Amz-Date", now.Format("20060102T150405Z"))
+	req.Header.Set("Authorization", s.authorization(req, endpoint.Host, canonicalURI, payloadHash, now))
+
+	return s.client.Do(req)
+}
+
+// authorization membuat header Authorization AWS Signature V4
+func (s *ProofStorage) authorization(req *http.Request, host, canonicalURI, payloadHash string, now time.Time) string {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, s.region)
+
+	headers := map[string]string{
+		"host":                 host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           amzDate,
+	}
+	if contentType := req.Header.Get("Content-Type"); contentType != "" {
+		headers["content-type"] = contentType
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI,
+		"", // Tanpa query string
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	signingKey = hmacSHA256(signingKey, s.region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	return fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature)
+}
+
+// encodePath meng-encode setiap segmen path sesuai aturan URI encoding S3
+func encodePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = strings.ReplaceAll(url.PathEscape(segment), "+", "%2B")
+	}
+	return strings.Join(segments, "/")
+}
+
+// sha256Hex menghitung hash SHA-256 dalam bentuk hex
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hmacSHA256 menghitung HMAC-SHA256
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
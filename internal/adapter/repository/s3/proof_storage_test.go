@@ -0,0 +1,206 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gwenziro/botopia/internal/infrastructure/config"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+const (
+	testAccessKey = "minio-access"
+	testSecretKey = "minio-secret"
+	testRegion    = "us-east-1"
+	testBucket    = "bukti"
+)
+
+// fakeS3 tiruan MinIO minimal: menyimpan objek di memori dan memverifikasi tanda tangan SigV4
+// berdasarkan request yang benar-benar diterima server
+type fakeS3 struct {
+	prefix  string
+	mutex   sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := f.verifySignature(r); err != nil {
+		http.Error(w, "SignatureDoesNotMatch: "+err.Error(), http.StatusForbidden)
+		return
+	}
+
+	path := r.URL.EscapedPath()
+	if !strings.HasPrefix(path, f.prefix+"/"+testBucket+"/") {
+		http.Error(w, "NoSuchBucket", http.StatusNotFound)
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, f.prefix+"/"+testBucket+"/")
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[key] = body
+	case http.MethodGet:
+		body, ok := f.objects[key]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Write(body)
+	default:
+		http.Error(w, "MethodNotAllowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// verifySignature menyusun ulang canonical request dari request masuk dan membandingkan tanda tangannya
+func (f *fakeS3) verifySignature(r *http.Request) error {
+	auth := r.Header.Get("Authorization")
+	var credential, signedHeaders, signature string
+	for _, part := range strings.Split(strings.TrimPrefix(auth, "AWS4-HMAC-SHA256 "), ", ") {
+		name, value, _ := strings.Cut(part, "=")
+		switch name {
+		case "Credential":
+			credential = value
+		case "SignedHeaders":
+			signedHeaders = value
+		case "Signature":
+			signature = value
+		}
+	}
+
+	credentialParts := strings.Split(credential, "/")
+	if len(credentialParts) != 5 || credentialParts[0] != testAccessKey {
+		return fmt.Errorf("credential %q tidak dikenal", credential)
+	}
+	date, region := credentialParts[1], credentialParts[2]
+
+	body, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	payloadHash := sha256Hex(body)
+	if r.Header.Get("X-Amz-Content-Sha256") != payloadHash {
+		return fmt.Errorf("hash payload tidak cocok")
+	}
+
+	names := strings.Split(signedHeaders, ";")
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+
+	canonicalRequest := strings.Join([]string{
+		r.Method, r.URL.EscapedPath(), r.URL.RawQuery,
+		canonicalHeaders.String(), strings.Join(names, ";"), payloadHash,
+	}, "\n")
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256", r.Header.Get("X-Amz-Date"),
+		fmt.Sprintf("%s/%s/s3/aws4_request", date, region),
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+testSecretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	if expected := hex.EncodeToString(hmacSHA256(key, stringToSign)); expected != signature {
+		return fmt.Errorf("tanda tangan %s, seharusnya %s", signature, expected)
+	}
+	return nil
+}
+
+func newTestStorage(t *testing.T, prefix string) (*ProofStorage, *fakeS3) {
+	t.Helper()
+
+	fake := &fakeS3{prefix: prefix, objects: make(map[string][]byte)}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	storage := NewProofStorage(&config.ProofStorageConfig{
+		S3Endpoint:  server.URL + prefix,
+		S3Region:    testRegion,
+		S3Bucket:    testBucket,
+		S3AccessKey: testAccessKey,
+		S3SecretKey: testSecretKey,
+	}, logger.New("S3Test", logger.ERROR, false))
+
+	return storage, fake
+}
+
+func TestUploadAndDownloadProof(t *testing.T) {
+	for _, prefix := range []string{"", "/minio"} {
+		t.Run("prefix="+prefix, func(t *testing.T) {
+			storage, fake := newTestStorage(t, prefix)
+
+			content := []byte("isi bukti transaksi")
+			filePath := filepath.Join(t.TempDir(), "struk belanja.jpg")
+			if err := os.WriteFile(filePath, content, 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			proofURL, err := storage.UploadProof(context.Background(), filePath, "TRX-001")
+			if err != nil {
+				t.Fatalf("UploadProof error: %v", err)
+			}
+			if !strings.HasPrefix(proofURL, storage.endpoint+"/"+testBucket+"/proofs/") {
+				t.Errorf("URL bukti %s tidak berada di bawah endpoint %s", proofURL, storage.endpoint)
+			}
+			if len(fake.objects) != 1 {
+				t.Fatalf("jumlah objek tersimpan %d, ingin 1", len(fake.objects))
+			}
+
+			downloaded, err := storage.DownloadProof(context.Background(), proofURL)
+			if err != nil {
+				t.Fatalf("DownloadProof error: %v", err)
+			}
+			if !bytes.Equal(downloaded, content) {
+				t.Errorf("isi unduhan %q, ingin %q", downloaded, content)
+			}
+		})
+	}
+}
+
+func TestDownloadProofRejectsForeignURL(t *testing.T) {
+	storage, _ := newTestStorage(t, "")
+
+	for _, proofURL := range []string{
+		"http://169.254.169.254/latest/meta-data/",
+		storage.endpoint + "/bucket-lain/proofs/a.jpg",
+		storage.endpoint + "/" + testBucket + "/../bucket-lain/a.jpg",
+	} {
+		if _, err := storage.DownloadProof(context.Background(), proofURL); err == nil {
+			t.Errorf("DownloadProof(%s) seharusnya ditolak", proofURL)
+		}
+	}
+}
+
+func TestWrongSecretIsRejected(t *testing.T) {
+	storage, _ := newTestStorage(t, "")
+	storage.secretKey = "salah"
+
+	filePath := filepath.Join(t.TempDir(), "bukti.png")
+	if err := os.WriteFile(filePath, []byte("x"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := storage.UploadProof(context.Background(), filePath, "TRX-002"); err == nil {
+		t.Error("UploadProof dengan secret salah seharusnya ditolak stand-in")
+	}
+}
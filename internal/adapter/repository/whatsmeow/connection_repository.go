@@ -193,6 +193,55 @@ func (r *ConnectionRepository) SendMessage(ctx context.Context, chatID string, t
 	return err
 }
 
+// SendMedia mengirim gambar atau dokumen
+func (r *ConnectionRepository) SendMedia(ctx context.Context, chatID string, media *message.OutgoingMedia) error {
+	jid, err := waTypes.ParseJID(chatID)
+	if err != nil {
+		return err
+	}
+
+	// Gambar dikirim sebagai pesan gambar, selain itu sebagai dokumen
+	mediaType := whatsmeow.MediaDocument
+	if media.IsImage() {
+		mediaType = whatsmeow.MediaImage
+	}
+
+	uploaded, err := r.client.Upload(ctx, media.Data, mediaType)
+	if err != nil {
+		return fmt.Errorf("gagal mengunggah media ke WhatsApp: %v", err)
+	}
+
+	msg := &waProto.Message{}
+	if media.IsImage() {
+		msg.ImageMessage = &waProto.ImageMessage{
+			Caption:       proto.String(media.Caption),
+			Mimetype:      proto.String(media.MimeType),
+			URL:           &uploaded.URL,
+			DirectPath:    &uploaded.DirectPath,
+			MediaKey:      uploaded.MediaKey,
+			FileEncSHA256: uploaded.FileEncSHA256,
+			FileSHA256:    uploaded.FileSHA256,
+			FileLength:    &uploaded.FileLength,
+		}
+	} else {
+		msg.DocumentMessage = &waProto.DocumentMessage{
+			Caption:       proto.String(media.Caption),
+			Title:         proto.String(media.FileName),
+			FileName:      proto.String(media.FileName),
+			Mimetype:      proto.String(media.MimeType),
+			URL:           &uploaded.URL,
+			DirectPath:    &uploaded.DirectPath,
+			MediaKey:      uploaded.MediaKey,
+			FileEncSHA256: uploaded.FileEncSHA256,
+			FileSHA256:    uploaded.FileSHA256,
+			FileLength:    &uploaded.FileLength,
+		}
+	}
+
+	_, err = r.client.SendMessage(ctx, jid, msg)
+	return err
+}
+
 // RegisterMessageHandler mendaftarkan handler untuk pesan masuk
 func (r *ConnectionRepository) RegisterMessageHandler(handler func(*message.Message)) {
 	r.handlersMutex.Lock()
@@ -408,6 +457,49 @@ func (r *ConnectionRepository) handleEvent(evt interface{}) {
 	}
 }
 
+// quotedMessage mengambil pesan yang dibalas (reply) beserta penanda apakah pesan tersebut dikirim bot
+func (r *ConnectionRepository) quotedMessage(evt *events.Message) *message.Message {
+	var contextInfo *waProto.ContextInfo
+	switch {
+	case evt.Message.GetImageMessage() != nil:
+		contextInfo = evt.Message.GetImageMessage().GetContextInfo()
+	case evt.Message.GetDocumentMessage() != nil:
+		contextInfo = evt.Message.GetDocumentMessage().GetContextInfo()
+	case evt.Message.GetVideoMessage() != nil:
+		contextInfo = evt.Message.GetVideoMessage().GetContextInfo()
+	case evt.Message.GetExtendedTextMessage() != nil:
+		contextInfo = evt.Message.GetExtendedTextMessage().GetContextInfo()
+	}
+	if contextInfo == nil || contextInfo.GetStanzaID() == "" {
+		return nil
+	}
+
+	quoted := contextInfo.GetQuotedMessage()
+	text := quoted.GetConversation()
+	if text == "" {
+		text = quoted.GetExtendedTextMessage().GetText()
+	}
+
+	replyingTo := &message.Message{
+		ID:   contextInfo.GetStanzaID(),
+		Text: text,
+	}
+	if participant, err := waTypes.ParseJID(contextInfo.GetParticipant()); err == nil && participant.User != "" {
+		replyingTo.Sender = &user.User{ID: participant.User, Phone: "+" + participant.User}
+		replyingTo.IsFromMe = r.isOwnUser(participant.User)
+	}
+
+	return replyingTo
+}
+
+// isOwnUser memeriksa apakah user JID (nomor telepon atau LID) adalah akun bot sendiri
+func (r *ConnectionRepository) isOwnUser(userID string) bool {
+	if r.client == nil || r.client.Store == nil || r.client.Store.ID == nil {
+		return false
+	}
+	return userID == r.client.Store.ID.User || (!r.client.Store.LID.IsEmpty() && userID == r.client.Store.LID.User)
+}
+
 // convertToMessage mengubah event message ke domain message
 func (r *ConnectionRepository) convertToMessage(evt *events.Message) *message.Message {
 	// Ekstrak text message
@@ -465,6 +557,8 @@ func (r *ConnectionRepository) convertToMessage(evt *events.Message) *message.Me
 		Caption:   caption,
 	}
 
+	msg.ReplyingTo = r.quotedMessage(evt)
+
 	// Log detail pesan media untuk debugging
 	if mediaType != "" {
 		r.log.Debug("Konversi message dengan media type: %s, caption: %s", string(mediaType), caption)
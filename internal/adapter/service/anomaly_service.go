@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gwenziro/botopia/internal/domain/audit"
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/repository"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+	"github.com/gwenziro/botopia/internal/utils"
+)
+
+// AnomalyService implementasi peringatan pengeluaran tidak biasa
+type AnomalyService struct {
+	financeRepo    repository.FinanceRepository
+	connectionRepo repository.ConnectionRepository
+	policy         *finance.AnomalyPolicy
+	owners         []string
+	log            *logger.Logger
+}
+
+// NewAnomalyService membuat instance layanan peringatan pengeluaran baru
+func NewAnomalyService(
+	financeRepo repository.FinanceRepository,
+	connectionRepo repository.ConnectionRepository,
+	policy *finance.AnomalyPolicy,
+	owners []string,
+	log *logger.Logger,
+) *AnomalyService {
+	normalized := make([]string, 0, len(owners))
+	for _, phone := range owners {
+		if phone = strings.TrimSpace(phone); phone != "" {
+			normalized = append(normalized, normalizePhone(phone))
+		}
+	}
+
+	return &AnomalyService{
+		financeRepo:    financeRepo,
+		connectionRepo: connectionRepo,
+		policy:         policy,
+		owners:         normalized,
+		log:            log,
+	}
+}
+
+// Memastikan AnomalyService mengimplementasikan interface service.AnomalyService
+var _ service.AnomalyService = (*AnomalyService)(nil)
+
+// Inspect memeriksa pengeluaran yang baru dicatat; kejanggalan juga dikirim ke pemilik selain pengirim
+func (s *AnomalyService) Inspect(ctx context.Context, record *finance.FinanceRecord) ([]*finance.SpendingAnomaly, error) {
+	if record == nil || record.Type != finance.TypeExpense {
+		return nil, nil
+	}
+
+	history, err := s.financeRepo.GetAllRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil riwayat transaksi: %v", err)
+	}
+
+	anomalies := s.policy.DetectAnomalies(record, history)
+	if len(anomalies) == 0 {
+		return nil, nil
+	}
+
+	s.log.Info("Pengeluaran %s (%s) terdeteksi tidak biasa: %d peringatan", record.UniqueCode, record.Description, len(anomalies))
+	s.notifyOwners(ctx, record, anomalies)
+	return anomalies, nil
+}
+
+// notifyOwners mengirim pemberitahuan ke chat pribadi pemilik, kecuali pemilik yang mencatat sendiri
+func (s *AnomalyService) notifyOwners(ctx context.Context, record *finance.FinanceRecord, anomalies []*finance.SpendingAnomaly) {
+	actor := audit.ActorFromContext(ctx)
+	sender := ""
+	if actor.Phone != "" {
+		sender = normalizePhone(actor.Phone)
+	}
+
+	notification := formatAnomalyNotification(finance.LedgerFromContext(ctx), record, anomalies)
+	for _, owner := range s.owners {
+		if owner == sender {
+			continue
+		}
+		chatID := strings.TrimPrefix(owner, "+") + "@s.whatsapp.net"
+		if err := s.connectionRepo.SendMessage(ctx, chatID, notification); err != nil {
+			s.log.Warn("Gagal mengirim peringatan pengeluaran %s ke %s: %v", record.UniqueCode, owner, err)
+		}
+	}
+}
+
+// formatAnomalyNotification memformat pemberitahuan pengeluaran tidak biasa untuk pemilik
+func formatAnomalyNotification(ledgerID string, record *finance.FinanceRecord, anomalies []*finance.SpendingAnomaly) string {
+	member := record.Member
+	if member == "" {
+		member = "-"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("────────────────────────\n")
+	sb.WriteString("🚨 PENGELUARAN TIDAK BIASA 🚨\n")
+	sb.WriteString("────────────────────────\n")
+	for _, anomaly := range anomalies {
+		sb.WriteString(fmt.Sprintf("• %s\n", anomaly.Describe(record)))
+	}
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("📌 Kode Transaksi: %s\n", record.UniqueCode))
+	sb.WriteString(fmt.Sprintf("👤 Dicatat oleh: %s\n", member))
+	sb.WriteString(fmt.Sprintf("📒 Ledger: %s\n", finance.LedgerLabel(ledgerID)))
+	sb.WriteString(fmt.Sprintf("📅 Tanggal: %s\n", utils.FormatDateID(record.Date)))
+	sb.WriteString(fmt.Sprintf("📖 Deskripsi: %s\n", record.Description))
+	sb.WriteString(fmt.Sprintf("💰 Jumlah: Rp %s\n", utils.FormatMoney(record.Amount)))
+	sb.WriteString(fmt.Sprintf("🏷 Kategori: %s\n", record.Category))
+	sb.WriteString("────────────────────────\n")
+	sb.WriteString(fmt.Sprintf("Ketik !detail %s untuk melihat transaksi.\n", record.UniqueCode))
+	sb.WriteString("────────────────────────")
+
+	return sb.String()
+}
@@ -0,0 +1,379 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/money"
+	"github.com/gwenziro/botopia/internal/domain/repository"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+	"github.com/gwenziro/botopia/internal/utils"
+)
+
+// ApprovalService implementasi alur persetujuan pengeluaran besar melalui WhatsApp
+type ApprovalService struct {
+	approvalRepo   repository.ApprovalRepository
+	financeService service.FinanceService
+	connectionRepo repository.ConnectionRepository
+	threshold      money.Money
+	approvers      []string
+	proofDir       string
+	log            *logger.Logger
+
+	// mutex mencegah satu pengajuan diputuskan dua kali secara bersamaan
+	mutex sync.Mutex
+}
+
+// NewApprovalService membuat instance layanan persetujuan baru
+func NewApprovalService(
+	approvalRepo repository.ApprovalRepository,
+	financeService service.FinanceService,
+	connectionRepo repository.ConnectionRepository,
+	threshold money.Money,
+	approvers []string,
+	proofDir string,
+	log *logger.Logger,
+) *ApprovalService {
+	normalized := make([]string, 0, len(approvers))
+	for _, phone := range approvers {
+		if phone = strings.TrimSpace(phone); phone != "" {
+			normalized = append(normalized, normalizePhone(phone))
+		}
+	}
+
+	if err := os.MkdirAll(proofDir, 0755); err != nil {
+		log.Error("Gagal membuat direktori bukti pengajuan %s: %v", proofDir, err)
+	}
+
+	return &ApprovalService{
+		approvalRepo:   approvalRepo,
+		financeService: financeService,
+		connectionRepo: connectionRepo,
+		threshold:      threshold,
+		approvers:      normalized,
+		proofDir:       proofDir,
+		log:            log,
+	}
+}
+
+// Memastikan ApprovalService mengimplementasikan interface service.ApprovalService
+var _ service.ApprovalService = (*ApprovalService)(nil)
+
+// GetThreshold mengembalikan batas nominal pengeluaran yang memerlukan persetujuan
+func (s *ApprovalService) GetThreshold() money.Money {
+	return s.threshold
+}
+
+// IsApprover memeriksa apakah nomor telepon termasuk approver
+func (s *ApprovalService) IsApprover(phone string) bool {
+	phone = normalizePhone(phone)
+	for _, approver := range s.approvers {
+		if approver == phone {
+			return true
+		}
+	}
+	return false
+}
+
+// RequiresApproval memeriksa apakah record perlu disetujui.
+// Pengeluaran yang dicatat sendiri oleh approver langsung masuk pembukuan.
+func (s *ApprovalService) RequiresApproval(record *finance.FinanceRecord, requesterPhone string) bool {
+	if !s.threshold.IsPositive() || len(s.approvers) == 0 || record.Type != finance.TypeExpense {
+		return false
+	}
+
+	// Nominal bermata uang lain tidak sebanding dengan batas; validasi record yang akan menolaknya
+	cmp, err := record.Amount.Compare(s.threshold)
+	if err != nil {
+		return false
+	}
+
+	return cmp >= 0 && !s.IsApprover(requesterPhone)
+}
+
+// Submit menyimpan pengajuan dan memberi tahu para approver
+func (s *ApprovalService) Submit(ctx context.Context, record *finance.FinanceRecord, proofPath string, requester *finance.RecordAuthor) (*finance.ApprovalRequest, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	code, err := s.nextCode(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	request := &finance.ApprovalRequest{
+		Code:      code,
+		Record:    record,
+		Requester: requester,
+		Status:    finance.ApprovalPending,
+		CreatedAt: time.Now(),
+		Ledger:    finance.LedgerFromContext(ctx),
+	}
+
+	// Salin bukti karena file unduhan asli akan dihapus setelah command selesai
+	if proofPath != "" {
+		storedPath := filepath.Join(s.proofDir, code+strings.ToLower(filepath.Ext(proofPath)))
+		if err := copyFile(proofPath, storedPath); err != nil {
+			s.log.Warn("Gagal menyimpan bukti pengajuan %s: %v", code, err)
+		} else {
+			request.ProofPath = storedPath
+		}
+	}
+
+	if err := s.approvalRepo.Save(ctx, request); err != nil {
+		return nil, fmt.Errorf("gagal menyimpan pengajuan: %v", err)
+	}
+
+	notification := formatApprovalNotification(request, s.threshold)
+	for _, approver := range s.approvers {
+		chatID := strings.TrimPrefix(approver, "+") + "@s.whatsapp.net"
+		if err := s.connectionRepo.SendMessage(ctx, chatID, notification); err != nil {
+			s.log.Warn("Gagal mengirim pengajuan %s ke approver %s: %v", code, approver, err)
+		}
+	}
+
+	return request, nil
+}
+
+// ListPending mendapatkan pengajuan yang masih menunggu keputusan
+func (s *ApprovalService) ListPending(ctx context.Context) ([]*finance.ApprovalRequest, error) {
+	requests, err := s.approvalRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make([]*finance.ApprovalRequest, 0, len(requests))
+	for _, request := range requests {
+		if request.IsPending() {
+			pending = append(pending, request)
+		}
+	}
+
+	return pending, nil
+}
+
+// Approve mencatat pengajuan ke pembukuan dan memberi tahu pengaju
+func (s *ApprovalService) Approve(ctx context.Context, code string, approverPhone string) (*finance.ApprovalRequest, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	request, err := s.findPending(ctx, code, approverPhone)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pengeluaran dicatat di ledger pengaju atas nama pengaju, bukan approver
+	ctx = finance.WithLedger(ctx, request.Ledger)
+	if request.Requester != nil {
+		ctx = finance.WithMember(ctx, request.Requester.DisplayName())
+	}
+
+	draft := request.Record
+	record, err := s.financeService.AddExpenseWithDate(
+		ctx, draft.Date, draft.Description, draft.Amount, draft.Category,
+		draft.PaymentMethod, draft.StorageMedia, draft.Notes, "",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mencatat pengeluaran: %v", err)
+	}
+
+	request.Status = finance.ApprovalApproved
+	request.DecidedBy = normalizePhone(approverPhone)
+	request.DecidedAt = time.Now()
+	request.RecordCode = record.UniqueCode
+	request.Record = record
+
+	if request.Requester != nil {
+		author := *request.Requester
+		author.RecordCode = record.UniqueCode
+		author.CreatedAt = time.Now()
+		if err := s.financeService.SaveRecordAuthor(ctx, &author); err != nil {
+			s.log.Warn("Gagal menyimpan pembuat transaksi %s: %v", record.UniqueCode, err)
+		}
+	}
+
+	proofNote := ""
+	if request.ProofPath != "" {
+		if _, err := s.financeService.UploadTransactionProof(ctx, record.UniqueCode, request.ProofPath); err != nil {
+			s.log.Warn("Gagal mengunggah bukti pengajuan %s: %v", code, err)
+			proofNote = fmt.Sprintf("\n⚠️ Bukti gagal diunggah, silakan !unggah %s ulang.", record.UniqueCode)
+		} else {
+			os.Remove(request.ProofPath)
+			request.ProofPath = ""
+		}
+	}
+
+	if err := s.approvalRepo.Save(ctx, request); err != nil {
+		s.log.Error("Gagal memperbarui pengajuan %s: %v", code, err)
+	}
+
+	s.notifyRequester(ctx, request, fmt.Sprintf(`────────────────────────
+✅ PENGELUARAN DISETUJUI ✅
+────────────────────────
+Pengajuan %s (%s, Rp %s) telah disetujui dan dicatat.
+ℹ Kode Transaksi: %s%s
+────────────────────────`,
+		request.Code, record.Description, utils.FormatMoney(record.Amount), record.UniqueCode, proofNote))
+
+	return request, nil
+}
+
+// Reject menolak pengajuan dengan alasan dan memberi tahu pengaju
+func (s *ApprovalService) Reject(ctx context.Context, code string, approverPhone string, reason string) (*finance.ApprovalRequest, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	request, err := s.findPending(ctx, code, approverPhone)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Status = finance.ApprovalRejected
+	request.DecidedBy = normalizePhone(approverPhone)
+	request.DecidedAt = time.Now()
+	request.Reason = reason
+
+	if request.ProofPath != "" {
+		os.Remove(request.ProofPath)
+		request.ProofPath = ""
+	}
+
+	if err := s.approvalRepo.Save(ctx, request); err != nil {
+		return nil, fmt.Errorf("gagal memperbarui pengajuan: %v", err)
+	}
+
+	s.notifyRequester(ctx, request, fmt.Sprintf(`────────────────────────
+❌ PENGELUARAN DITOLAK ❌
+────────────────────────
+Pengajuan %s (%s, Rp %s) ditolak.
+📝 Alasan: %s
+────────────────────────`,
+		request.Code, request.Record.Description, utils.FormatMoney(request.Record.Amount), reason))
+
+	return request, nil
+}
+
+// findPending mencari pengajuan yang masih menunggu dan memastikan pemanggil adalah approver
+func (s *ApprovalService) findPending(ctx context.Context, code string, approverPhone string) (*finance.ApprovalRequest, error) {
+	if !s.IsApprover(approverPhone) {
+		return nil, fmt.Errorf("nomor %s bukan approver", approverPhone)
+	}
+
+	request, err := s.approvalRepo.FindByCode(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mencari pengajuan: %v", err)
+	}
+	if request == nil {
+		return nil, fmt.Errorf("pengajuan dengan kode %s tidak ditemukan", code)
+	}
+	if !request.IsPending() {
+		return nil, fmt.Errorf("pengajuan %s sudah diputuskan (%s)", code, request.Status)
+	}
+
+	return request, nil
+}
+
+// notifyRequester mengirim hasil keputusan ke chat tempat pengajuan dibuat
+func (s *ApprovalService) notifyRequester(ctx context.Context, request *finance.ApprovalRequest, text string) {
+	if request.Requester == nil || request.Requester.ChatID == "" {
+		return
+	}
+
+	if err := s.connectionRepo.SendMessage(ctx, request.Requester.ChatID, text); err != nil {
+		s.log.Warn("Gagal memberi tahu pengaju %s: %v", request.Code, err)
+	}
+}
+
+// nextCode membuat kode persetujuan berikutnya untuk bulan berjalan, pemanggil harus memegang lock
+func (s *ApprovalService) nextCode(ctx context.Context, now time.Time) (string, error) {
+	requests, err := s.approvalRepo.FindAll(ctx)
+	if err != nil {
+		return "", fmt.Errorf("gagal membaca pengajuan: %v", err)
+	}
+
+	prefix := finance.ApprovalCodePrefix(now)
+	count := 0
+	for _, request := range requests {
+		if strings.HasPrefix(request.Code, prefix) {
+			count++
+		}
+	}
+
+	return fmt.Sprintf("%s%03d", prefix, count+1), nil
+}
+
+// formatApprovalNotification memformat pesan pengajuan untuk approver
+func formatApprovalNotification(request *finance.ApprovalRequest, threshold money.Money) string {
+	record := request.Record
+
+	requester := "-"
+	if request.Requester != nil {
+		requester = request.Requester.Phone
+	}
+
+	proofStatus := "Belum tersedia"
+	if request.ProofPath != "" {
+		proofStatus = "✅ Terlampir"
+	}
+
+	return fmt.Sprintf(`────────────────────────
+⏳ PERSETUJUAN PENGELUARAN ⏳
+────────────────────────
+Pengeluaran berikut mencapai batas Rp %s dan menunggu persetujuan.
+
+📌 Kode Persetujuan: %s
+👤 Diajukan oleh: %s
+📒 Ledger: %s
+📅 Tanggal: %s
+📖 Deskripsi: %s
+💰 Jumlah: Rp %s
+🏷 Kategori: %s
+💳 Metode: %s
+🏦 Sumber Dana: %s
+📝 Catatan: %s
+🧾 Bukti Transaksi: %s
+────────────────────────
+Balas !setuju %s untuk mencatat, atau
+!tolak %s <alasan> untuk menolak.
+────────────────────────`,
+		utils.FormatMoney(threshold),
+		request.Code,
+		requester,
+		finance.LedgerLabel(request.Ledger),
+		utils.FormatDateID(record.Date),
+		record.Description,
+		utils.FormatMoney(record.Amount),
+		record.Category,
+		record.PaymentMethod,
+		record.StorageMedia,
+		record.Notes,
+		proofStatus,
+		request.Code,
+		request.Code)
+}
+
+// copyFile menyalin isi file ke lokasi baru
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
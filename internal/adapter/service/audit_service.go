@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/audit"
+	"github.com/gwenziro/botopia/internal/domain/repository"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+// AuditService implementasi audit log berantai hash
+type AuditService struct {
+	auditRepo repository.AuditRepository
+	log       *logger.Logger
+
+	// mutex menjaga urutan dan tautan hash saat beberapa perubahan terjadi bersamaan
+	mutex sync.Mutex
+}
+
+// NewAuditService membuat instance layanan audit baru
+func NewAuditService(auditRepo repository.AuditRepository, log *logger.Logger) *AuditService {
+	return &AuditService{
+		auditRepo: auditRepo,
+		log:       log,
+	}
+}
+
+// Memastikan AuditService mengimplementasikan interface service.AuditService
+var _ service.AuditService = (*AuditService)(nil)
+
+// Record mencatat perubahan beserta nilai sebelum dan sesudahnya
+func (s *AuditService) Record(ctx context.Context, action audit.Action, target string, before, after interface{}) error {
+	beforeJSON, err := marshalAuditValue(before)
+	if err != nil {
+		return fmt.Errorf("gagal menyimpan nilai sebelum: %v", err)
+	}
+	afterJSON, err := marshalAuditValue(after)
+	if err != nil {
+		return fmt.Errorf("gagal menyimpan nilai sesudah: %v", err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	last, err := s.auditRepo.Last(ctx)
+	if err != nil {
+		return fmt.Errorf("gagal membaca audit log: %v", err)
+	}
+
+	entry := &audit.Entry{
+		Sequence:  1,
+		Timestamp: time.Now().UTC(),
+		Action:    action,
+		Target:    target,
+		Actor:     audit.ActorFromContext(ctx),
+		Before:    beforeJSON,
+		After:     afterJSON,
+		PrevHash:  audit.GenesisHash,
+	}
+	if last != nil {
+		entry.Sequence = last.Sequence + 1
+		entry.PrevHash = last.Hash
+	}
+	entry.Hash = entry.ComputeHash()
+
+	if err := s.auditRepo.Append(ctx, entry); err != nil {
+		return fmt.Errorf("gagal menulis audit log: %v", err)
+	}
+
+	s.log.Debug("Audit #%d: %s %s oleh %s", entry.Sequence, action, target, entry.Actor.String())
+	return nil
+}
+
+// List mendapatkan entri yang sesuai filter, terbaru lebih dulu
+func (s *AuditService) List(ctx context.Context, filter *audit.Filter) ([]*audit.Entry, error) {
+	entries, err := s.auditRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*audit.Entry, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		if filter == nil || filter.Matches(entries[i]) {
+			result = append(result, entries[i])
+		}
+	}
+
+	return result, nil
+}
+
+// Verify memeriksa keutuhan rantai hash audit log
+func (s *AuditService) Verify(ctx context.Context) (*audit.VerifyResult, error) {
+	entries, err := s.auditRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := audit.Verify(entries)
+	if !result.Valid {
+		s.log.Warn("Audit log tidak utuh pada entri #%d: %s", result.BrokenAt, result.Reason)
+	}
+
+	return result, nil
+}
+
+// marshalAuditValue mengubah nilai menjadi JSON ringkas (nil tetap kosong)
+func marshalAuditValue(value interface{}) (json.RawMessage, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(value)
+}
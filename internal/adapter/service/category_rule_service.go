@@ -0,0 +1,378 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/repository"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+const (
+	// maxRuleTestSamples adalah jumlah contoh record yang dikembalikan saat menguji aturan
+	maxRuleTestSamples = 10
+
+	// maxRuleSuggestions adalah jumlah maksimal saran aturan
+	maxRuleSuggestions = 20
+
+	// minSuggestionConfidence adalah proporsi minimal kategori dominan untuk sebuah kata kunci
+	minSuggestionConfidence = 0.75
+)
+
+// suggestionStopwords adalah kata umum yang tidak berguna sebagai kata kunci
+var suggestionStopwords = map[string]bool{
+	"dan": true, "yang": true, "untuk": true, "dari": true, "ke": true, "di": true,
+	"dengan": true, "beli": true, "bayar": true, "pembayaran": true, "pembelian": true,
+	"the": true, "for": true, "and": true,
+}
+
+// CategoryRuleService implementasi layanan aturan kategori
+type CategoryRuleService struct {
+	ruleRepo    repository.CategoryRuleRepository
+	financeRepo repository.FinanceRepository
+	journalRepo repository.ActionJournalRepository
+	log         *logger.Logger
+}
+
+// NewCategoryRuleService membuat instance layanan aturan kategori baru
+func NewCategoryRuleService(
+	ruleRepo repository.CategoryRuleRepository,
+	financeRepo repository.FinanceRepository,
+	log *logger.Logger,
+) *CategoryRuleService {
+	return &CategoryRuleService{
+		ruleRepo:    ruleRepo,
+		financeRepo: financeRepo,
+		log:         log,
+	}
+}
+
+// Memastikan CategoryRuleService mengimplementasikan interface service.CategoryRuleService
+var _ service.CategoryRuleService = (*CategoryRuleService)(nil)
+
+// SetJournalRepository mengatur penyimpanan jurnal aksi agar perubahan aturan dapat dibatalkan
+func (s *CategoryRuleService) SetJournalRepository(journalRepo repository.ActionJournalRepository) {
+	s.journalRepo = journalRepo
+}
+
+// GetAllRules mendapatkan semua aturan kategori
+func (s *CategoryRuleService) GetAllRules(ctx context.Context) ([]*finance.CategoryRule, error) {
+	return s.ruleRepo.FindAll(ctx)
+}
+
+// GetRule mendapatkan aturan berdasarkan ID
+func (s *CategoryRuleService) GetRule(ctx context.Context, id string) (*finance.CategoryRule, error) {
+	return s.ruleRepo.FindByID(ctx, id)
+}
+
+// AddRule menambahkan aturan baru
+func (s *CategoryRuleService) AddRule(ctx context.Context, rule *finance.CategoryRule) (*finance.CategoryRule, error) {
+	if rule == nil {
+		return nil, fmt.Errorf("aturan tidak boleh kosong")
+	}
+
+	newRule := finance.NewCategoryRule(rule.Name, rule.Keywords)
+	newRule.Type = rule.Type
+	newRule.Category = strings.TrimSpace(rule.Category)
+	newRule.PaymentMethod = strings.TrimSpace(rule.PaymentMethod)
+	newRule.StorageMedia = strings.TrimSpace(rule.StorageMedia)
+	newRule.Override = rule.Override
+	newRule.Priority = rule.Priority
+	newRule.IsActive = rule.IsActive
+
+	if err := newRule.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.Save(ctx, newRule); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("Aturan kategori '%s' ditambahkan dengan %d kata kunci", newRule.Name, len(newRule.Keywords))
+	s.journal(ctx, &finance.JournalEntry{
+		Kind:        finance.UndoDeleteCategoryRule,
+		RuleID:      newRule.ID,
+		Description: fmt.Sprintf("penambahan aturan kategori '%s'", newRule.Name),
+	})
+	return newRule, nil
+}
+
+// UpdateRule memperbarui aturan yang sudah ada
+func (s *CategoryRuleService) UpdateRule(ctx context.Context, rule *finance.CategoryRule) (*finance.CategoryRule, error) {
+	if rule == nil {
+		return nil, fmt.Errorf("aturan tidak boleh kosong")
+	}
+
+	existing, err := s.ruleRepo.FindByID(ctx, rule.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, fmt.Errorf("aturan dengan ID %s tidak ditemukan", rule.ID)
+	}
+	previous := copyCategoryRule(existing)
+
+	existing.Name = rule.Name
+	existing.Keywords = finance.NormalizeKeywords(rule.Keywords)
+	existing.Type = rule.Type
+	existing.Category = strings.TrimSpace(rule.Category)
+	existing.PaymentMethod = strings.TrimSpace(rule.PaymentMethod)
+	existing.StorageMedia = strings.TrimSpace(rule.StorageMedia)
+	existing.Override = rule.Override
+	existing.Priority = rule.Priority
+	existing.IsActive = rule.IsActive
+
+	if err := existing.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.Save(ctx, existing); err != nil {
+		return nil, err
+	}
+
+	s.journal(ctx, &finance.JournalEntry{
+		Kind:         finance.UndoRestoreCategoryRule,
+		RuleID:       previous.ID,
+		CategoryRule: previous,
+		Description:  fmt.Sprintf("perubahan aturan kategori '%s'", previous.Name),
+	})
+	return existing, nil
+}
+
+// DeleteRule menghapus aturan
+func (s *CategoryRuleService) DeleteRule(ctx context.Context, id string) error {
+	existing, err := s.ruleRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.ruleRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	if existing != nil {
+		s.journal(ctx, &finance.JournalEntry{
+			Kind:         finance.UndoRestoreCategoryRule,
+			RuleID:       existing.ID,
+			CategoryRule: existing,
+			Description:  fmt.Sprintf("penghapusan aturan kategori '%s'", existing.Name),
+		})
+	}
+	return nil
+}
+
+// RestoreRule menyimpan kembali aturan persis seperti data yang diberikan, termasuk ID-nya.
+// Dipakai untuk membatalkan perubahan atau penghapusan aturan.
+func (s *CategoryRuleService) RestoreRule(ctx context.Context, rule *finance.CategoryRule) (*finance.CategoryRule, error) {
+	if rule == nil || rule.ID == "" {
+		return nil, fmt.Errorf("aturan yang akan dikembalikan tidak valid")
+	}
+
+	restored := copyCategoryRule(rule)
+	if err := s.ruleRepo.Save(ctx, restored); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("Aturan kategori '%s' dikembalikan", restored.Name)
+	return restored, nil
+}
+
+// journal mencatat perubahan aturan ke jurnal aksi pengguna
+func (s *CategoryRuleService) journal(ctx context.Context, entry *finance.JournalEntry) {
+	saveJournalEntry(ctx, s.journalRepo, s.log, entry)
+}
+
+// copyCategoryRule menyalin aturan beserta daftar kata kuncinya
+func copyCategoryRule(rule *finance.CategoryRule) *finance.CategoryRule {
+	copied := *rule
+	copied.Keywords = append([]string(nil), rule.Keywords...)
+	return &copied
+}
+
+// ApplyRules menerapkan aturan aktif ke record dan mengembalikan aturan yang cocok.
+// Aturan diproses dari prioritas tertinggi; hanya aturan pertama yang cocok yang boleh
+// menimpa nilai yang sudah ada, aturan berikutnya hanya mengisi field yang masih kosong.
+func (s *CategoryRuleService) ApplyRules(ctx context.Context, record *finance.FinanceRecord) ([]*finance.CategoryRule, error) {
+	if record == nil {
+		return nil, nil
+	}
+
+	rules, err := s.ruleRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []*finance.CategoryRule
+	for _, rule := range rules {
+		if !rule.Matches(record.Type, record.Description) {
+			continue
+		}
+
+		if rule.Apply(record, len(matched) == 0) {
+			s.log.Debug("Aturan '%s' diterapkan pada '%s'", rule.Name, record.Description)
+		}
+		matched = append(matched, rule)
+	}
+
+	return matched, nil
+}
+
+// TestRule menguji aturan terhadap riwayat transaksi
+func (s *CategoryRuleService) TestRule(ctx context.Context, rule *finance.CategoryRule) (*finance.RuleTestResult, error) {
+	if rule == nil {
+		return nil, fmt.Errorf("aturan tidak boleh kosong")
+	}
+
+	// Uji salinan aturan agar aturan nonaktif tetap bisa dicoba
+	candidate := *rule
+	candidate.Keywords = finance.NormalizeKeywords(rule.Keywords)
+	candidate.IsActive = true
+
+	records, err := s.financeRepo.GetAllRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil riwayat transaksi: %v", err)
+	}
+
+	result := &finance.RuleTestResult{
+		TotalRecords: len(records),
+		Samples:      []*finance.FinanceRecord{},
+	}
+
+	for _, record := range records {
+		if !candidate.Matches(record.Type, record.Description) {
+			continue
+		}
+		result.MatchCount++
+
+		preview := *record
+		if candidate.Apply(&preview, true) {
+			result.ChangedCount++
+		}
+
+		if len(result.Samples) < maxRuleTestSamples {
+			result.Samples = append(result.Samples, record)
+		}
+	}
+
+	s.log.Info("Aturan '%s' cocok dengan %d dari %d transaksi", candidate.Name, result.MatchCount, result.TotalRecords)
+	return result, nil
+}
+
+// SuggestRules menyarankan aturan berdasarkan pasangan deskripsi→kategori di riwayat
+func (s *CategoryRuleService) SuggestRules(ctx context.Context, minCount int) ([]*finance.CategorySuggestion, error) {
+	if minCount < 2 {
+		minCount = 2
+	}
+
+	records, err := s.financeRepo.GetAllRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil riwayat transaksi: %v", err)
+	}
+
+	rules, err := s.ruleRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hitung kemunculan kategori untuk setiap kata kunci per tipe transaksi
+	type keywordKey struct {
+		typ     finance.RecordType
+		keyword string
+	}
+	counts := make(map[keywordKey]map[string]int)
+
+	for _, record := range records {
+		if record.Category == "" {
+			continue
+		}
+		for _, keyword := range extractKeywords(record.Description) {
+			key := keywordKey{typ: record.Type, keyword: keyword}
+			if counts[key] == nil {
+				counts[key] = make(map[string]int)
+			}
+			counts[key][record.Category]++
+		}
+	}
+
+	suggestions := make([]*finance.CategorySuggestion, 0)
+	for key, categories := range counts {
+		total, bestCategory, bestCount := 0, "", 0
+		for category, count := range categories {
+			total += count
+			if count > bestCount || (count == bestCount && category < bestCategory) {
+				bestCategory, bestCount = category, count
+			}
+		}
+
+		if bestCount < minCount {
+			continue
+		}
+
+		confidence := float64(bestCount) / float64(total)
+		if confidence < minSuggestionConfidence {
+			continue
+		}
+
+		if isCoveredByRule(rules, key.typ, key.keyword, bestCategory) {
+			continue
+		}
+
+		suggestions = append(suggestions, &finance.CategorySuggestion{
+			Keyword:    key.keyword,
+			Type:       key.typ,
+			Category:   bestCategory,
+			Count:      bestCount,
+			Confidence: confidence,
+		})
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Count != suggestions[j].Count {
+			return suggestions[i].Count > suggestions[j].Count
+		}
+		return suggestions[i].Keyword < suggestions[j].Keyword
+	})
+
+	if len(suggestions) > maxRuleSuggestions {
+		suggestions = suggestions[:maxRuleSuggestions]
+	}
+
+	return suggestions, nil
+}
+
+// extractKeywords memecah deskripsi menjadi kata kunci unik yang layak dipakai
+func extractKeywords(description string) []string {
+	words := strings.FieldsFunc(strings.ToLower(description), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	seen := make(map[string]bool)
+	keywords := make([]string, 0, len(words))
+	for _, word := range words {
+		if len([]rune(word)) < 3 || suggestionStopwords[word] || seen[word] {
+			continue
+		}
+		if strings.IndexFunc(word, unicode.IsLetter) == -1 {
+			continue // Abaikan angka murni
+		}
+		seen[word] = true
+		keywords = append(keywords, word)
+	}
+
+	return keywords
+}
+
+// isCoveredByRule memeriksa apakah kata kunci sudah ditangani oleh aturan yang ada
+func isCoveredByRule(rules []*finance.CategoryRule, typ finance.RecordType, keyword, category string) bool {
+	for _, rule := range rules {
+		if rule.Category == category && rule.Matches(typ, keyword) {
+			return true
+		}
+	}
+	return false
+}
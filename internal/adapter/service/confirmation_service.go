@@ -0,0 +1,123 @@
+package service
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+// defaultConfirmationTTL adalah batas waktu default untuk menjawab konfirmasi
+const defaultConfirmationTTL = 5 * time.Minute
+
+var (
+	confirmAnswers = map[string]bool{"ya": true, "y": true, "iya": true, "yes": true, "ok": true}
+	cancelAnswers  = map[string]bool{"tidak": true, "t": true, "tdk": true, "no": true, "gak": true, "nggak": true}
+)
+
+// ConfirmationService implementasi in-memory layanan konfirmasi
+type ConfirmationService struct {
+	pending map[string]*service.PendingConfirmation
+	mutex   sync.Mutex
+	log     *logger.Logger
+}
+
+// NewConfirmationService membuat instance layanan konfirmasi baru
+func NewConfirmationService(log *logger.Logger) *ConfirmationService {
+	return &ConfirmationService{
+		pending: make(map[string]*service.PendingConfirmation),
+		log:     log,
+	}
+}
+
+// Memastikan ConfirmationService mengimplementasikan interface service.ConfirmationService
+var _ service.ConfirmationService = (*ConfirmationService)(nil)
+
+// Ask menyimpan konfirmasi baru, menggantikan konfirmasi sebelumnya untuk key yang sama
+func (s *ConfirmationService) Ask(key string, pending *service.PendingConfirmation) {
+	if pending == nil {
+		return
+	}
+	if pending.ExpiresAt.IsZero() {
+		pending.ExpiresAt = time.Now().Add(defaultConfirmationTTL)
+	}
+
+	s.mutex.Lock()
+	previous := s.pending[key]
+	s.pending[key] = pending
+	expired := s.removeExpiredLocked(time.Now())
+	s.mutex.Unlock()
+
+	// Konfirmasi lama yang tergantikan diperlakukan seperti kedaluwarsa
+	if previous != nil {
+		expired = append(expired, previous)
+	}
+	runExpired(expired)
+}
+
+// Resolve menjalankan konfirmasi jika jawaban berupa "ya" atau "tidak"
+func (s *ConfirmationService) Resolve(key string, answer string) (string, bool) {
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	isConfirm := confirmAnswers[answer]
+	isCancel := cancelAnswers[answer]
+	if !isConfirm && !isCancel {
+		return "", false
+	}
+
+	s.mutex.Lock()
+	expired := s.removeExpiredLocked(time.Now())
+	pending, exists := s.pending[key]
+	if exists {
+		delete(s.pending, key)
+	}
+	s.mutex.Unlock()
+
+	runExpired(expired)
+
+	if !exists {
+		return "", false
+	}
+
+	if isConfirm {
+		s.log.Info("Konfirmasi %s dijawab: ya", key)
+		return pending.OnConfirm(), true
+	}
+
+	s.log.Info("Konfirmasi %s dijawab: tidak", key)
+	if pending.OnCancel != nil {
+		return pending.OnCancel(), true
+	}
+	return "Dibatalkan.", true
+}
+
+// HasPending memeriksa apakah ada konfirmasi yang menunggu jawaban
+func (s *ConfirmationService) HasPending(key string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	pending, exists := s.pending[key]
+	return exists && time.Now().Before(pending.ExpiresAt)
+}
+
+// removeExpiredLocked menghapus konfirmasi kedaluwarsa; mutex harus sudah dikunci
+func (s *ConfirmationService) removeExpiredLocked(now time.Time) []*service.PendingConfirmation {
+	var expired []*service.PendingConfirmation
+	for key, pending := range s.pending {
+		if now.After(pending.ExpiresAt) {
+			expired = append(expired, pending)
+			delete(s.pending, key)
+		}
+	}
+	return expired
+}
+
+// runExpired menjalankan callback kedaluwarsa di luar lock
+func runExpired(expired []*service.PendingConfirmation) {
+	for _, pending := range expired {
+		if pending.OnExpire != nil {
+			pending.OnExpire()
+		}
+	}
+}
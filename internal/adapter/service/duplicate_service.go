@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+)
+
+// SetDuplicateWindowDays mengatur rentang hari untuk mendeteksi transaksi ganda
+func (s *FinanceService) SetDuplicateWindowDays(days int) {
+	if days < 0 {
+		days = 0
+	}
+	s.log.Info("Rentang deteksi transaksi ganda diatur ke %d hari", days)
+	s.duplicateWindowDays = days
+}
+
+// FindDuplicateRecords mencari record tersimpan yang kemungkinan sama dengan record baru
+func (s *FinanceService) FindDuplicateRecords(ctx context.Context, record *finance.FinanceRecord) ([]*finance.FinanceRecord, error) {
+	if record == nil {
+		return nil, nil
+	}
+
+	records, err := s.sheetsRepo.GetAllRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil riwayat transaksi: %v", err)
+	}
+
+	var duplicates []*finance.FinanceRecord
+	for _, existing := range records {
+		if record.IsPossibleDuplicateOf(existing, s.duplicateWindowDays) {
+			duplicates = append(duplicates, existing)
+		}
+	}
+
+	if len(duplicates) > 0 {
+		s.log.Info("Ditemukan %d kemungkinan transaksi ganda untuk '%s'", len(duplicates), record.Description)
+	}
+
+	return duplicates, nil
+}
@@ -6,14 +6,17 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/gwenziro/botopia/internal/domain/audit"
+	"github.com/gwenziro/botopia/internal/domain/errors"
 	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/money"
 )
 
 // AddExpense menambahkan record pengeluaran baru
 func (s *FinanceService) AddExpense(
 	ctx context.Context,
 	description string,
-	amount float64,
+	amount money.Money,
 	category string,
 	paymentMethod string,
 	storageMedia string,
@@ -39,14 +42,14 @@ func (s *FinanceService) AddExpenseWithDate(
 	ctx context.Context,
 	date time.Time,
 	description string,
-	amount float64,
+	amount money.Money,
 	category string,
 	paymentMethod string,
 	storageMedia string,
 	notes string,
 	proofURL string,
 ) (*finance.FinanceRecord, error) {
-	s.log.Info("Menambahkan pengeluaran baru dengan tanggal kustom: %s - %s (%.2f)",
+	s.log.Info("Menambahkan pengeluaran baru dengan tanggal kustom: %s - %s (%s)",
 		date.Format("2006-01-02"), description, amount)
 
 	// Handle blank notes
@@ -54,19 +57,6 @@ func (s *FinanceService) AddExpenseWithDate(
 		notes = "-"
 	}
 
-	// Validasi kategori, metode pembayaran, dan sumber dana terhadap konfigurasi
-	if s.config != nil {
-		if !contains(s.config.ExpenseCategories, category) {
-			return nil, fmt.Errorf("kategori pengeluaran '%s' tidak valid", category)
-		}
-		if !contains(s.config.PaymentMethods, paymentMethod) {
-			return nil, fmt.Errorf("metode pembayaran '%s' tidak valid", paymentMethod)
-		}
-		if !contains(s.config.StorageMedias, storageMedia) {
-			return nil, fmt.Errorf("sumber dana '%s' tidak valid", storageMedia)
-		}
-	}
-
 	// Buat record untuk pengeluaran
 	record := &finance.FinanceRecord{
 		Date:          date,
@@ -78,6 +68,28 @@ func (s *FinanceService) AddExpenseWithDate(
 		Notes:         notes,
 		ProofURL:      proofURL,
 		Type:          finance.TypeExpense,
+		Member:        recordMember(ctx),
+	}
+
+	// Tag #nama pada deskripsi dan catatan disimpan terpisah dari kategori
+	record.RefreshTags()
+
+	// Lengkapi field kosong berdasarkan aturan kategori
+	if err := s.ApplyCategoryRules(ctx, record); err != nil {
+		s.log.Warn("Gagal menerapkan aturan kategori: %v", err)
+	}
+
+	// Validasi kategori, metode pembayaran, dan sumber dana terhadap konfigurasi
+	if config := s.cachedConfiguration(ctx); config != nil {
+		if !contains(config.ExpenseCategories, record.Category) {
+			return nil, errors.NewValidationError("kategori", fmt.Sprintf("kategori pengeluaran '%s' tidak valid", record.Category))
+		}
+		if !contains(config.PaymentMethods, record.PaymentMethod) {
+			return nil, errors.NewValidationError("metode", fmt.Sprintf("metode pembayaran '%s' tidak valid", record.PaymentMethod))
+		}
+		if !contains(config.StorageMedias, record.StorageMedia) {
+			return nil, errors.NewValidationError("media", fmt.Sprintf("sumber dana '%s' tidak valid", record.StorageMedia))
+		}
 	}
 
 	// Validasi record
@@ -85,6 +97,11 @@ func (s *FinanceService) AddExpenseWithDate(
 		return nil, err
 	}
 
+	// Tolak transaksi mundur ke periode yang sudah tutup buku
+	if err := s.checkPeriod(ctx, record.Date, "ditambah", record.Description); err != nil {
+		return nil, err
+	}
+
 	// Tambahkan ke Google Sheets
 	err := s.sheetsRepo.AddExpenseRecord(ctx, record)
 	if err != nil {
@@ -93,6 +110,8 @@ func (s *FinanceService) AddExpenseWithDate(
 	}
 
 	s.log.Info("Pengeluaran berhasil dicatat dengan kode: %s", record.UniqueCode)
+	s.recordAudit(ctx, audit.ActionAddExpense, record.UniqueCode, nil, record)
+	s.journalNewRecord(ctx, record)
 	return record, nil
 }
 
@@ -101,31 +120,28 @@ func (s *FinanceService) ValidateAddExpenseParams(
 	ctx context.Context,
 	category, paymentMethod, storageMedia string,
 ) error {
-	// Load config if needed
-	var err error
-	if s.config == nil {
-		s.config, err = s.GetConfiguration(ctx)
-		if err != nil {
-			return fmt.Errorf("gagal memuat konfigurasi: %v", err)
-		}
+	// Konfigurasi dimuat sesuai ledger di context
+	config, err := s.GetConfiguration(ctx)
+	if err != nil {
+		return fmt.Errorf("gagal memuat konfigurasi: %v", err)
 	}
 
 	// Validasi kategori
-	if !contains(s.config.ExpenseCategories, category) {
-		return fmt.Errorf("kategori '%s' tidak valid. Kategori yang tersedia: %v",
-			category, s.config.ExpenseCategories)
+	if !contains(config.ExpenseCategories, category) {
+		return errors.NewValidationError("kategori", fmt.Sprintf("kategori '%s' tidak valid. Kategori yang tersedia: %v",
+			category, config.ExpenseCategories))
 	}
 
 	// Validasi metode pembayaran
-	if !contains(s.config.PaymentMethods, paymentMethod) {
-		return fmt.Errorf("metode pembayaran '%s' tidak valid. Metode yang tersedia: %v",
-			paymentMethod, s.config.PaymentMethods)
+	if !contains(config.PaymentMethods, paymentMethod) {
+		return errors.NewValidationError("metode", fmt.Sprintf("metode pembayaran '%s' tidak valid. Metode yang tersedia: %v",
+			paymentMethod, config.PaymentMethods))
 	}
 
 	// Validasi sumber dana
-	if !contains(s.config.StorageMedias, storageMedia) {
-		return fmt.Errorf("sumber dana '%s' tidak valid. Sumber dana yang tersedia: %v",
-			storageMedia, s.config.StorageMedias)
+	if !contains(config.StorageMedias, storageMedia) {
+		return errors.NewValidationError("media", fmt.Sprintf("sumber dana '%s' tidak valid. Sumber dana yang tersedia: %v",
+			storageMedia, config.StorageMedias))
 	}
 
 	return nil
@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/gwenziro/botopia/internal/domain/audit"
+	"github.com/gwenziro/botopia/internal/domain/errors"
 	"github.com/gwenziro/botopia/internal/domain/finance"
 	"github.com/gwenziro/botopia/internal/domain/repository"
 	"github.com/gwenziro/botopia/internal/domain/service"
@@ -15,23 +18,52 @@ import (
 
 // FinanceService implementasi layanan keuangan
 type FinanceService struct {
-	sheetsRepo repository.FinanceRepository
-	driveRepo  repository.DriveRepository
-	config     *finance.Configuration
-	configErr  error
-	log        *logger.Logger
+	sheetsRepo  repository.FinanceRepository
+	proofStore  repository.ProofStorageRepository
+	ruleService service.CategoryRuleService
+	log         *logger.Logger
+
+	// configs cache konfigurasi per ledger, key ID ledger
+	configs     map[string]*finance.Configuration
+	configMutex sync.RWMutex
+
+	// duplicateWindowDays adalah rentang hari untuk mendeteksi transaksi ganda
+	duplicateWindowDays int
+
+	// proofProcessor menormalkan file bukti sebelum diunggah (opsional)
+	proofProcessor service.ProofProcessor
+
+	// authorRepo menyimpan pembuat transaksi untuk pengingat (opsional)
+	authorRepo repository.RecordAuthorRepository
+
+	// auditService mencatat setiap perubahan data keuangan (opsional)
+	auditService service.AuditService
+
+	// periodClosings menolak perubahan pada periode yang sudah tutup buku (opsional)
+	periodClosings service.PeriodClosingService
+
+	// journalRepo menyimpan aksi pengguna beserta kebalikannya untuk !batal (opsional)
+	journalRepo repository.ActionJournalRepository
+
+	// reimbursements menautkan pemasukan penggantian ke klaim reimbursement (opsional)
+	reimbursements service.ReimbursementService
 }
 
 // NewFinanceService membuat instance layanan keuangan baru
 func NewFinanceService(
 	sheetsRepo repository.FinanceRepository,
-	driveRepo repository.DriveRepository,
+	proofStore repository.ProofStorageRepository,
+	ruleService service.CategoryRuleService,
 	log *logger.Logger,
 ) *FinanceService {
 	s := &FinanceService{
-		sheetsRepo: sheetsRepo,
-		driveRepo:  driveRepo,
-		log:        log,
+		sheetsRepo:  sheetsRepo,
+		proofStore:  proofStore,
+		ruleService: ruleService,
+		log:         log,
+		configs:     make(map[string]*finance.Configuration),
+
+		duplicateWindowDays: finance.DefaultDuplicateWindowDays,
 	}
 
 	// Muat konfigurasi di background
@@ -53,18 +85,16 @@ func (s *FinanceService) prefetchConfiguration() {
 	// Cek apakah repository terkonfigurasi
 	if !s.sheetsRepo.IsConfigured() {
 		s.log.Warn("Repository Google Sheets tidak terkonfigurasi dengan benar")
-		s.configErr = fmt.Errorf("repository tidak terkonfigurasi dengan benar")
 		return
 	}
 
 	config, err := s.sheetsRepo.GetConfiguration(ctx)
 	if err != nil {
 		s.log.Warn("Gagal memuat konfigurasi awal: %v", err)
-		s.configErr = err
 		return
 	}
 
-	s.config = config
+	s.setCachedConfiguration(ctx, config)
 	s.log.Info("Konfigurasi keuangan berhasil dimuat:")
 	s.log.Info("- %d kategori pemasukan", len(config.IncomeCategories))
 	s.log.Info("- %d kategori pengeluaran", len(config.ExpenseCategories))
@@ -75,6 +105,116 @@ func (s *FinanceService) prefetchConfiguration() {
 	s.log.Info("Spreadsheet URL: %s", s.GetSpreadsheetURL())
 }
 
+// SetProofProcessor mengatur pemroses file bukti sebelum diunggah
+func (s *FinanceService) SetProofProcessor(processor service.ProofProcessor) {
+	s.proofProcessor = processor
+}
+
+// SetAuthorRepository mengatur penyimpanan pembuat transaksi
+func (s *FinanceService) SetAuthorRepository(authorRepo repository.RecordAuthorRepository) {
+	s.authorRepo = authorRepo
+}
+
+// SetAuditService mengatur layanan audit log untuk setiap perubahan
+func (s *FinanceService) SetAuditService(auditService service.AuditService) {
+	s.auditService = auditService
+}
+
+// SetPeriodClosingService mengatur layanan tutup buku untuk mengunci periode tertutup
+func (s *FinanceService) SetPeriodClosingService(closings service.PeriodClosingService) {
+	s.periodClosings = closings
+}
+
+// checkPeriod memastikan transaksi bertanggal date boleh diubah jika tutup buku diaktifkan
+func (s *FinanceService) checkPeriod(ctx context.Context, date time.Time, operation, target string) error {
+	if s.periodClosings == nil {
+		return nil
+	}
+	return s.periodClosings.CheckWritable(ctx, date, operation, target)
+}
+
+// SetJournalRepository mengatur penyimpanan jurnal aksi untuk pembatalan
+func (s *FinanceService) SetJournalRepository(journalRepo repository.ActionJournalRepository) {
+	s.journalRepo = journalRepo
+}
+
+// SetReimbursementService mengatur layanan reimbursement untuk menautkan pemasukan penggantian ke klaim
+func (s *FinanceService) SetReimbursementService(reimbursements service.ReimbursementService) {
+	s.reimbursements = reimbursements
+}
+
+// linkReimbursement menautkan pemasukan baru ke klaim reimbursement yang cocok; kegagalan hanya dicatat di log
+func (s *FinanceService) linkReimbursement(ctx context.Context, income *finance.FinanceRecord) {
+	if s.reimbursements == nil {
+		return
+	}
+
+	claim, err := s.reimbursements.LinkIncome(ctx, income)
+	if err != nil {
+		s.log.Warn("Gagal menautkan pemasukan %s ke klaim reimbursement: %v", income.UniqueCode, err)
+		return
+	}
+	if claim != nil {
+		s.log.Info("Pemasukan %s melunasi klaim reimbursement %s", income.UniqueCode, claim.Code)
+	}
+}
+
+// recordAudit mencatat perubahan ke audit log; kegagalan hanya dicatat di log aplikasi
+func (s *FinanceService) recordAudit(ctx context.Context, action audit.Action, target string, before, after interface{}) {
+	if s.auditService == nil {
+		return
+	}
+
+	if err := s.auditService.Record(ctx, action, target, before, after); err != nil {
+		s.log.Error("Gagal mencatat audit %s untuk %s: %v", action, target, err)
+	}
+}
+
+// proofSnapshot merangkum status bukti record untuk audit log
+type proofSnapshot struct {
+	ProofURL    string   `json:"proof_url"`
+	Attachments []string `json:"attachments"`
+}
+
+// snapshotProof mengambil status bukti record saat ini
+func snapshotProof(record *finance.FinanceRecord) *proofSnapshot {
+	snapshot := &proofSnapshot{ProofURL: record.ProofURL, Attachments: []string{}}
+	for _, attachment := range record.Attachments {
+		snapshot.Attachments = append(snapshot.Attachments, attachment.URL)
+	}
+	return snapshot
+}
+
+// recordMember menentukan anggota pencatat transaksi baru; hanya pengguna WhatsApp yang diatribusikan
+func recordMember(ctx context.Context) string {
+	if member, ok := finance.MemberFromContext(ctx); ok {
+		return member
+	}
+
+	actor := audit.ActorFromContext(ctx)
+	if actor.Channel != audit.ChannelWhatsApp {
+		return ""
+	}
+	if actor.Name != "" {
+		return actor.Name
+	}
+	return actor.Phone
+}
+
+// SaveRecordAuthor mencatat pengguna yang membuat transaksi
+func (s *FinanceService) SaveRecordAuthor(ctx context.Context, author *finance.RecordAuthor) error {
+	if s.authorRepo == nil || author == nil || author.RecordCode == "" {
+		return nil
+	}
+
+	if author.CreatedAt.IsZero() {
+		author.CreatedAt = time.Now()
+	}
+	author.Ledger = finance.LedgerFromContext(ctx)
+
+	return s.authorRepo.Save(ctx, author)
+}
+
 // GetRecentRecords mendapatkan record keuangan terbaru
 func (s *FinanceService) GetRecentRecords(ctx context.Context, limit int) ([]*finance.FinanceRecord, error) {
 	s.log.Info("Mengambil %d record keuangan terbaru", limit)
@@ -88,9 +228,9 @@ func (s *FinanceService) GetSpreadsheetURL() string {
 
 // GetConfiguration mendapatkan konfigurasi keuangan
 func (s *FinanceService) GetConfiguration(ctx context.Context) (*finance.Configuration, error) {
-	// Gunakan cache jika tersedia
-	if s.config != nil && s.configErr == nil {
-		return s.config, nil
+	// Gunakan cache ledger jika tersedia
+	if config := s.cachedConfiguration(ctx); config != nil {
+		return config, nil
 	}
 
 	// Ambil ulang jika tidak tersedia atau ada error sebelumnya
@@ -100,16 +240,311 @@ func (s *FinanceService) GetConfiguration(ctx context.Context) (*finance.Configu
 	}
 
 	// Update cache
-	s.config = config
-	s.configErr = nil
+	s.setCachedConfiguration(ctx, config)
 
 	return config, nil
 }
 
-// UploadTransactionProof mengunggah bukti transaksi
+// cachedConfiguration mengembalikan konfigurasi ledger di context yang sudah dimuat (nil jika belum)
+func (s *FinanceService) cachedConfiguration(ctx context.Context) *finance.Configuration {
+	s.configMutex.RLock()
+	defer s.configMutex.RUnlock()
+
+	return s.configs[finance.LedgerFromContext(ctx)]
+}
+
+// setCachedConfiguration menyimpan konfigurasi ledger di context ke cache
+func (s *FinanceService) setCachedConfiguration(ctx context.Context, config *finance.Configuration) {
+	s.configMutex.Lock()
+	defer s.configMutex.Unlock()
+
+	s.configs[finance.LedgerFromContext(ctx)] = config
+}
+
+// ApplyCategoryRules melengkapi kategori, metode, dan sumber record berdasarkan aturan kategori
+func (s *FinanceService) ApplyCategoryRules(ctx context.Context, record *finance.FinanceRecord) error {
+	if s.ruleService == nil {
+		return nil
+	}
+
+	matched, err := s.ruleService.ApplyRules(ctx, record)
+	if err != nil {
+		return err
+	}
+
+	if len(matched) > 0 {
+		s.log.Info("%d aturan kategori cocok untuk '%s'", len(matched), record.Description)
+	}
+
+	return nil
+}
+
+// UploadTransactionProof menambahkan bukti transaksi ke daftar lampiran record
 func (s *FinanceService) UploadTransactionProof(ctx context.Context, transactionCode string, filePath string) (*finance.FinanceRecord, error) {
 	s.log.Info("Mengunggah bukti transaksi untuk kode: %s, file: %s", transactionCode, filePath)
 
+	record, err := s.prepareProofUpload(ctx, transactionCode, filePath)
+	if err != nil {
+		return nil, err
+	}
+	before := snapshotProof(record)
+	beforeURL, beforeAttachments := record.ProofURL, record.Attachments
+
+	// Simpan bukti lama (sebelum ada sheet Lampiran) sebagai lampiran pertama
+	if len(record.Attachments) == 0 && record.ProofURL != "" && record.ProofURL != "-" {
+		legacy := &finance.Attachment{
+			RecordCode: record.UniqueCode,
+			FileName:   "bukti-awal",
+			MimeType:   finance.MimeTypeFromPath(record.ProofURL),
+			URL:        record.ProofURL,
+		}
+		if err := s.sheetsRepo.AddAttachment(ctx, legacy); err != nil {
+			return nil, fmt.Errorf("gagal menyimpan bukti sebelumnya: %v", err)
+		}
+		record.Attachments = append(record.Attachments, legacy)
+	}
+
+	attachment, err := s.uploadAttachment(ctx, record, filePath)
+	if err != nil {
+		return nil, err
+	}
+	record.Attachments = append(record.Attachments, attachment)
+
+	// Bukti pertama juga dicatat di kolom bukti sheet transaksi
+	if record.ProofURL == "" || record.ProofURL == "-" {
+		if _, err := s.updateRecordProof(ctx, record, attachment.URL); err != nil {
+			return nil, fmt.Errorf("gagal memperbarui record: %v", err)
+		}
+	}
+
+	// Hapus file temporary
+	os.Remove(filePath)
+
+	s.recordAudit(ctx, audit.ActionUploadProof, record.UniqueCode, before, snapshotProof(record))
+	s.journalProofChange(ctx, record.UniqueCode, beforeURL, beforeAttachments, "unggah bukti")
+	return record, nil
+}
+
+// ReplaceTransactionProof mengganti seluruh bukti transaksi dengan file baru
+func (s *FinanceService) ReplaceTransactionProof(ctx context.Context, transactionCode string, filePath string) (*finance.FinanceRecord, error) {
+	s.log.Info("Mengganti bukti transaksi untuk kode: %s, file: %s", transactionCode, filePath)
+
+	record, err := s.prepareProofUpload(ctx, transactionCode, filePath)
+	if err != nil {
+		return nil, err
+	}
+	before := snapshotProof(record)
+	beforeURL, beforeAttachments := record.ProofURL, record.Attachments
+
+	if err := s.sheetsRepo.DeleteAttachments(ctx, record.UniqueCode); err != nil {
+		return nil, fmt.Errorf("gagal menghapus bukti lama: %v", err)
+	}
+
+	attachment, err := s.uploadAttachment(ctx, record, filePath)
+	if err != nil {
+		return nil, err
+	}
+	record.Attachments = []*finance.Attachment{attachment}
+
+	if _, err := s.updateRecordProof(ctx, record, attachment.URL); err != nil {
+		return nil, fmt.Errorf("gagal memperbarui record: %v", err)
+	}
+
+	// Hapus file temporary
+	os.Remove(filePath)
+
+	s.recordAudit(ctx, audit.ActionReplaceProof, record.UniqueCode, before, snapshotProof(record))
+	s.journalProofChange(ctx, record.UniqueCode, beforeURL, beforeAttachments, "ganti bukti")
+	return record, nil
+}
+
+// DeleteRecord menghapus transaksi beserta seluruh lampirannya.
+// File bukti di penyimpanan tidak dihapus karena dapat ditautkan oleh transaksi lain.
+func (s *FinanceService) DeleteRecord(ctx context.Context, code string) error {
+	record, err := s.GetRecordByCode(ctx, code)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkPeriod(ctx, record.Date, "dihapus", code); err != nil {
+		return err
+	}
+
+	// Pengeluaran yang sudah diajukan dalam klaim harus tetap ada sebagai dasar penggantian
+	if s.reimbursements != nil && record.Type == finance.TypeExpense {
+		claim, err := s.reimbursements.FindClaimByRecord(ctx, record.UniqueCode)
+		if err != nil {
+			return err
+		}
+		if claim != nil {
+			return fmt.Errorf("transaksi %s sudah diajukan dalam klaim reimbursement %s", code, claim.Code)
+		}
+	}
+
+	if err := s.sheetsRepo.DeleteAttachments(ctx, code); err != nil {
+		return fmt.Errorf("gagal menghapus lampiran: %v", err)
+	}
+
+	if err := s.sheetsRepo.DeleteRecord(ctx, code); err != nil {
+		return fmt.Errorf("gagal menghapus transaksi: %v", err)
+	}
+
+	s.log.Info("Transaksi %s dihapus", code)
+	s.recordAudit(ctx, audit.ActionDeleteRecord, code, record, nil)
+	return nil
+}
+
+// ListRecords mendapatkan record yang memenuhi filter, diurutkan dari yang terbaru
+func (s *FinanceService) ListRecords(ctx context.Context, filter *finance.RecordFilter) ([]*finance.FinanceRecord, error) {
+	// Hanya spreadsheet yang mencakup rentang tanggal filter yang dibaca
+	var from, to time.Time
+	if filter != nil {
+		from, to = filter.From, filter.To
+	}
+
+	records, err := s.sheetsRepo.GetRecordsBetween(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return finance.FilterRecords(records, filter), nil
+}
+
+// UpdateRecord memperbarui data transaksi. Kode, jenis, dan bukti transaksi tidak dapat diubah.
+func (s *FinanceService) UpdateRecord(ctx context.Context, record *finance.FinanceRecord) (*finance.FinanceRecord, error) {
+	existing, err := s.GetRecordByCode(ctx, record.UniqueCode)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *existing
+	updated.Date = record.Date
+	updated.Description = record.Description
+	updated.Amount = record.Amount
+	updated.Category = record.Category
+	updated.PaymentMethod = record.PaymentMethod
+	updated.StorageMedia = record.StorageMedia
+	updated.Notes = record.Notes
+	if updated.Type == finance.TypeIncome {
+		updated.PaymentMethod = ""
+	}
+	if updated.Notes == "" {
+		updated.Notes = "-"
+	}
+	updated.RefreshTags()
+
+	// Periode asal dan periode tujuan sama-sama tidak boleh sudah tutup buku
+	if err := s.checkPeriod(ctx, existing.Date, "diubah", existing.UniqueCode); err != nil {
+		return nil, err
+	}
+	if finance.PeriodOf(updated.Date) != finance.PeriodOf(existing.Date) {
+		if err := s.checkPeriod(ctx, updated.Date, "diubah", existing.UniqueCode); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.validateRecordOptions(ctx, &updated); err != nil {
+		return nil, err
+	}
+	if err := updated.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.sheetsRepo.UpdateRecord(ctx, &updated); err != nil {
+		return nil, fmt.Errorf("gagal memperbarui transaksi: %v", err)
+	}
+
+	s.log.Info("Transaksi %s diperbarui", updated.UniqueCode)
+	s.recordAudit(ctx, audit.ActionUpdateRecord, updated.UniqueCode, existing, &updated)
+	return &updated, nil
+}
+
+// validateRecordOptions memeriksa kategori, metode pembayaran, dan media record terhadap konfigurasi
+func (s *FinanceService) validateRecordOptions(ctx context.Context, record *finance.FinanceRecord) error {
+	config, err := s.GetConfiguration(ctx)
+	if err != nil {
+		return fmt.Errorf("gagal memuat konfigurasi: %v", err)
+	}
+
+	if record.Type == finance.TypeIncome {
+		if !contains(config.IncomeCategories, record.Category) {
+			return errors.NewValidationError("kategori", fmt.Sprintf("kategori pemasukan '%s' tidak valid", record.Category))
+		}
+	} else {
+		if !contains(config.ExpenseCategories, record.Category) {
+			return errors.NewValidationError("kategori", fmt.Sprintf("kategori pengeluaran '%s' tidak valid", record.Category))
+		}
+		if !contains(config.PaymentMethods, record.PaymentMethod) {
+			return errors.NewValidationError("metode", fmt.Sprintf("metode pembayaran '%s' tidak valid", record.PaymentMethod))
+		}
+	}
+	if !contains(config.StorageMedias, record.StorageMedia) {
+		return errors.NewValidationError("media", fmt.Sprintf("media penyimpanan '%s' tidak valid", record.StorageMedia))
+	}
+
+	return nil
+}
+
+// RestoreTransactionProof mengembalikan bukti transaksi ke URL dan daftar lampiran sebelumnya
+func (s *FinanceService) RestoreTransactionProof(ctx context.Context, code string, proofURL string, attachments []*finance.Attachment) (*finance.FinanceRecord, error) {
+	record, err := s.GetRecordByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkPeriod(ctx, record.Date, "diubah", code); err != nil {
+		return nil, err
+	}
+	before := snapshotProof(record)
+
+	if err := s.sheetsRepo.DeleteAttachments(ctx, code); err != nil {
+		return nil, fmt.Errorf("gagal menghapus lampiran: %v", err)
+	}
+
+	for _, attachment := range attachments {
+		if err := s.sheetsRepo.AddAttachment(ctx, attachment); err != nil {
+			return nil, fmt.Errorf("gagal mengembalikan lampiran: %v", err)
+		}
+	}
+	record.Attachments = attachments
+
+	if _, err := s.updateRecordProof(ctx, record, proofURL); err != nil {
+		return nil, fmt.Errorf("gagal memperbarui record: %v", err)
+	}
+
+	s.log.Info("Bukti transaksi %s dikembalikan ke %d lampiran", code, len(attachments))
+	s.recordAudit(ctx, audit.ActionRestoreProof, code, before, snapshotProof(record))
+	return record, nil
+}
+
+// GetRecordByCode mendapatkan record beserta seluruh lampirannya
+func (s *FinanceService) GetRecordByCode(ctx context.Context, code string) (*finance.FinanceRecord, error) {
+	record, err := s.findRecordByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, errors.NewRecordNotFoundError(code)
+	}
+
+	if err := s.loadAttachments(ctx, record); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// DownloadAttachment mengambil isi file lampiran dari penyimpanan bukti yang dikonfigurasi.
+// URL lain di sheet tidak diunduh langsung agar bot tidak dapat diarahkan ke alamat internal.
+func (s *FinanceService) DownloadAttachment(ctx context.Context, attachment *finance.Attachment) ([]byte, error) {
+	data, err := s.proofStore.DownloadProof(ctx, attachment.URL)
+	if err != nil {
+		return nil, fmt.Errorf("bukti tidak dapat diambil dari penyimpanan %s: %v", s.proofStore.GetName(), err)
+	}
+	return data, nil
+}
+
+// prepareProofUpload memvalidasi file, memastikan periode record belum tutup buku, dan mengambil record tujuan beserta lampirannya
+func (s *FinanceService) prepareProofUpload(ctx context.Context, transactionCode string, filePath string) (*finance.FinanceRecord, error) {
 	// Validasi file
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
 		return nil, fmt.Errorf("file bukti transaksi tidak ditemukan")
@@ -118,35 +553,113 @@ func (s *FinanceService) UploadTransactionProof(ctx context.Context, transaction
 	// Cari record berdasarkan kode
 	record, err := s.findRecordByCode(ctx, transactionCode)
 	if err != nil {
-		return nil, fmt.Errorf("gagal mencari transaksi: %v", err)
+		return nil, fmt.Errorf("gagal mencari transaksi: %w", err)
 	}
 
 	// Validasi record
 	if record == nil {
-		return nil, fmt.Errorf("transaksi dengan kode %s tidak ditemukan", transactionCode)
+		return nil, errors.NewRecordNotFoundError(transactionCode)
+	}
+
+	// Bukti termasuk data transaksi, sehingga periode yang sudah tutup buku tidak boleh diubah
+	if err := s.checkPeriod(ctx, record.Date, "diubah", record.UniqueCode); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.sheetsRepo.GetAttachments(ctx, record.UniqueCode)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil lampiran: %v", err)
 	}
+	record.Attachments = attachments
+
+	return record, nil
+}
 
-	// Cek apakah sudah ada bukti
-	if record.ProofURL != "" && record.ProofURL != "-" {
-		return nil, fmt.Errorf("transaksi dengan kode %s sudah memiliki bukti", transactionCode)
+// uploadAttachment memproses file, mengunggahnya ke penyimpanan bukti, dan mencatatnya sebagai lampiran.
+// File yang sama persis dengan bukti transaksi lain ditautkan tanpa diunggah ulang.
+func (s *FinanceService) uploadAttachment(ctx context.Context, record *finance.FinanceRecord, filePath string) (*finance.Attachment, error) {
+	processed := &finance.ProcessedProof{Path: filePath}
+	if s.proofProcessor != nil {
+		result, err := s.proofProcessor.Process(ctx, filePath)
+		if err != nil {
+			return nil, fmt.Errorf("gagal memproses bukti: %v", err)
+		}
+		processed = result
+
+		// Hapus file hasil normalisasi setelah selesai
+		if processed.Path != filePath {
+			defer os.Remove(processed.Path)
+		}
 	}
 
-	// Unggah ke Google Drive
-	fileURL, err := s.driveRepo.UploadImage(ctx, filePath, transactionCode)
+	// Cek apakah file yang sama sudah pernah diunggah
+	existing, err := s.sheetsRepo.FindAttachmentByHash(ctx, processed.Hash)
+	if err != nil {
+		s.log.Warn("Gagal memeriksa bukti ganda: %v", err)
+	}
+	if existing != nil {
+		if existing.RecordCode == record.UniqueCode {
+			return nil, fmt.Errorf("bukti yang sama sudah terlampir pada transaksi %s", record.UniqueCode)
+		}
+
+		linkedFrom := existing.RecordCode
+		if existing.LinkedFrom != "" {
+			linkedFrom = existing.LinkedFrom
+		}
+
+		attachment := &finance.Attachment{
+			RecordCode: record.UniqueCode,
+			FileName:   existing.FileName,
+			MimeType:   existing.MimeType,
+			URL:        existing.URL,
+			UploadedAt: time.Now(),
+			Hash:       existing.Hash,
+			LinkedFrom: linkedFrom,
+		}
+		if err := s.sheetsRepo.AddAttachment(ctx, attachment); err != nil {
+			return nil, fmt.Errorf("gagal mencatat lampiran: %v", err)
+		}
+
+		s.log.Info("Bukti untuk %s sama dengan bukti %s, ditautkan tanpa unggah ulang", record.UniqueCode, linkedFrom)
+		return attachment, nil
+	}
+
+	if !s.proofStore.IsConfigured() {
+		return nil, fmt.Errorf("penyimpanan bukti '%s' belum dikonfigurasi", s.proofStore.GetName())
+	}
+
+	fileURL, err := s.proofStore.UploadProof(ctx, processed.Path, record.UniqueCode)
 	if err != nil {
 		return nil, fmt.Errorf("gagal mengunggah bukti: %v", err)
 	}
 
-	// Update record dengan URL bukti
-	updatedRecord, err := s.updateRecordProof(ctx, record, fileURL)
+	attachment := finance.NewAttachment(record.UniqueCode, processed.Path, fileURL)
+	attachment.Hash = processed.Hash
+	if err := s.sheetsRepo.AddAttachment(ctx, attachment); err != nil {
+		return nil, fmt.Errorf("gagal mencatat lampiran: %v", err)
+	}
+
+	return attachment, nil
+}
+
+// loadAttachments memuat lampiran record; bukti lama tanpa lampiran tetap ditampilkan
+func (s *FinanceService) loadAttachments(ctx context.Context, record *finance.FinanceRecord) error {
+	attachments, err := s.sheetsRepo.GetAttachments(ctx, record.UniqueCode)
 	if err != nil {
-		return nil, fmt.Errorf("gagal memperbarui record: %v", err)
+		return fmt.Errorf("gagal mengambil lampiran: %v", err)
 	}
 
-	// Hapus file temporary
-	os.Remove(filePath)
+	if len(attachments) == 0 && record.ProofURL != "" && record.ProofURL != "-" {
+		attachments = []*finance.Attachment{{
+			RecordCode: record.UniqueCode,
+			FileName:   "bukti-awal",
+			MimeType:   finance.MimeTypeFromPath(record.ProofURL),
+			URL:        record.ProofURL,
+		}}
+	}
 
-	return updatedRecord, nil
+	record.Attachments = attachments
+	return nil
 }
 
 // findRecordByCode mencari record berdasarkan kode unik
@@ -156,7 +669,7 @@ func (s *FinanceService) findRecordByCode(ctx context.Context, code string) (*fi
 	isIncome := strings.HasPrefix(code, "m_")
 
 	if !isExpense && !isIncome {
-		return nil, fmt.Errorf("format kode tidak valid")
+		return nil, errors.NewValidationError("kode", "format kode tidak valid")
 	}
 
 	// Cari di sheet yang sesuai
@@ -202,8 +715,10 @@ func (s *FinanceService) UpdateConfiguration(ctx context.Context, config *financ
 
 	// Dalam implementasi sebenarnya, kita perlu menyimpan konfigurasi ke penyimpanan data
 	// Untuk sekarang, kita hanya update cache lokal
-	s.config = config
-	s.configErr = nil
+	previous := s.cachedConfiguration(ctx)
+	s.recordAudit(ctx, audit.ActionUpdateConfiguration, "konfigurasi", previous, config)
+	s.journalConfigurationChange(ctx, previous)
+	s.setCachedConfiguration(ctx, config)
 
 	s.log.Info("Konfigurasi keuangan berhasil diperbarui")
 	s.log.Info("- %d kategori pemasukan", len(config.IncomeCategories))
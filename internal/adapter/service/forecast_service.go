@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/repository"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+// ForecastService implementasi proyeksi arus kas dari record di spreadsheet
+type ForecastService struct {
+	financeRepo    repository.FinanceRepository
+	financeService service.FinanceService
+	log            *logger.Logger
+}
+
+// NewForecastService membuat instance layanan proyeksi arus kas baru
+func NewForecastService(
+	financeRepo repository.FinanceRepository,
+	financeService service.FinanceService,
+	log *logger.Logger,
+) *ForecastService {
+	return &ForecastService{
+		financeRepo:    financeRepo,
+		financeService: financeService,
+		log:            log,
+	}
+}
+
+// Memastikan ForecastService mengimplementasikan interface service.ForecastService
+var _ service.ForecastService = (*ForecastService)(nil)
+
+// GetForecast memproyeksikan saldo hingga akhir bulan berjalan ditambah months bulan berikutnya
+func (s *ForecastService) GetForecast(ctx context.Context, months int) (*finance.CashFlowForecast, error) {
+	records, err := s.financeRepo.GetAllRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil record keuangan: %v", err)
+	}
+
+	// Media tanpa transaksi tetap ditampilkan agar daftar sesuai konfigurasi
+	var storageMedias []string
+	if config, err := s.financeService.GetConfiguration(ctx); err == nil && config != nil {
+		storageMedias = config.StorageMedias
+	}
+
+	forecast := finance.BuildCashFlowForecast(records, storageMedias, time.Now(), months)
+	s.log.Info("Proyeksi arus kas dibuat untuk %d media penyimpanan, %d transaksi rutin terdeteksi",
+		len(forecast.Media), len(forecast.Recurring))
+
+	return forecast, nil
+}
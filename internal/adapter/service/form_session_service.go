@@ -0,0 +1,384 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/message"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+// defaultFormSessionTTL adalah batas waktu tidak aktif default sebuah sesi formulir
+const defaultFormSessionTTL = 10 * time.Minute
+
+var (
+	formCancelAnswers = map[string]bool{"batal": true, "cancel": true}
+	formBackAnswers   = map[string]bool{"kembali": true, "back": true}
+	formSkipAnswers   = map[string]bool{"-": true, "lewati": true, "skip": true}
+)
+
+// activeFormSession menyimpan status sesi formulir yang sedang berjalan
+type activeFormSession struct {
+	form      *service.FormSession
+	index     int
+	expiresAt time.Time
+	mutex     sync.Mutex
+}
+
+// FormSessionService implementasi in-memory sesi formulir interaktif
+type FormSessionService struct {
+	sessions map[string]*activeFormSession
+	prefix   string
+	ttl      time.Duration
+	mutex    sync.Mutex
+	log      *logger.Logger
+}
+
+// NewFormSessionService membuat instance layanan sesi formulir baru
+func NewFormSessionService(commandPrefix string, ttl time.Duration, log *logger.Logger) *FormSessionService {
+	if ttl <= 0 {
+		ttl = defaultFormSessionTTL
+	}
+	if commandPrefix == "" {
+		commandPrefix = "!"
+	}
+
+	return &FormSessionService{
+		sessions: make(map[string]*activeFormSession),
+		prefix:   commandPrefix,
+		ttl:      ttl,
+		log:      log,
+	}
+}
+
+// Memastikan FormSessionService mengimplementasikan interface service.FormSessionService
+var _ service.FormSessionService = (*FormSessionService)(nil)
+
+// Start memulai sesi baru dan mengembalikan pertanyaan pertama
+func (s *FormSessionService) Start(key string, form *service.FormSession, msg *message.Message) string {
+	if form == nil || form.OnComplete == nil {
+		return "❌ Formulir tidak valid."
+	}
+	if form.Values == nil {
+		form.Values = make(map[string]string)
+	}
+
+	// Jawaban awal yang kosong atau tidak valid akan ditanyakan ulang
+	for _, field := range form.Fields {
+		value, answered := form.Values[field.Key]
+		if !answered || field.AcceptMedia {
+			continue
+		}
+		normalized, err := normalizeAnswer(field, strings.TrimSpace(value))
+		if err != nil {
+			delete(form.Values, field.Key)
+			continue
+		}
+		form.Values[field.Key] = normalized
+	}
+
+	active := &activeFormSession{form: form}
+	active.index = active.nextIndex(0)
+
+	// Semua pertanyaan sudah terjawab dari awal, langsung selesaikan
+	if active.index >= len(form.Fields) {
+		return form.OnComplete(copyValues(form.Values), msg)
+	}
+
+	now := time.Now()
+	active.expiresAt = now.Add(s.ttl)
+
+	s.mutex.Lock()
+	previous := s.sessions[key]
+	s.sessions[key] = active
+	expired := s.removeExpiredLocked(now)
+	s.mutex.Unlock()
+
+	// Sesi lama yang tergantikan diperlakukan seperti kedaluwarsa
+	if previous != nil {
+		expired = append(expired, previous)
+	}
+	expireFormSessions(expired)
+
+	s.log.Info("Sesi formulir %q dimulai untuk %s", form.Title, key)
+	return active.prompt("")
+}
+
+// Handle memproses jawaban untuk sesi yang aktif
+func (s *FormSessionService) Handle(key string, msg *message.Message) (string, bool) {
+	if msg == nil {
+		return "", false
+	}
+
+	// Command tetap dijalankan seperti biasa tanpa mengakhiri sesi
+	text := strings.TrimSpace(msg.Text)
+	if strings.HasPrefix(text, s.prefix) {
+		return "", false
+	}
+
+	now := time.Now()
+
+	s.mutex.Lock()
+	active, exists := s.sessions[key]
+	var timedOut *activeFormSession
+	if exists && now.After(active.expiresAt) {
+		delete(s.sessions, key)
+		timedOut = active
+	}
+	expired := s.removeExpiredLocked(now)
+	if exists && timedOut == nil {
+		active.expiresAt = now.Add(s.ttl)
+	}
+	s.mutex.Unlock()
+
+	expireFormSessions(expired)
+
+	if timedOut != nil {
+		expireFormSessions([]*activeFormSession{timedOut})
+		s.log.Info("Sesi formulir %q untuk %s kedaluwarsa", timedOut.form.Title, key)
+		return fmt.Sprintf("⌛ Sesi formulir %s berakhir karena tidak ada aktivitas selama %d menit. Silakan mulai lagi.",
+			timedOut.form.Title, int(s.ttl.Minutes())), true
+	}
+	if !exists {
+		return "", false
+	}
+
+	active.mutex.Lock()
+	answer := strings.ToLower(text)
+
+	// Batalkan sesi
+	if formCancelAnswers[answer] {
+		active.mutex.Unlock()
+		s.remove(key, active)
+		active.removeMedia()
+		s.log.Info("Sesi formulir %q dibatalkan oleh %s", active.form.Title, key)
+		if active.form.OnCancel != nil {
+			return active.form.OnCancel(), true
+		}
+		return "❌ Formulir dibatalkan.", true
+	}
+
+	// Kembali ke pertanyaan sebelumnya
+	if formBackAnswers[answer] {
+		defer active.mutex.Unlock()
+		if active.index == 0 {
+			return active.prompt("Ini sudah pertanyaan pertama."), true
+		}
+		active.index--
+		return active.prompt(""), true
+	}
+
+	field := active.form.Fields[active.index]
+	value, err := answerValue(field, text, msg)
+	if err != nil {
+		defer active.mutex.Unlock()
+		return active.prompt("❌ " + err.Error()), true
+	}
+
+	// Jawaban media yang diganti tidak lagi dibutuhkan
+	if previous := active.form.Values[field.Key]; field.AcceptMedia && previous != "" && previous != value {
+		os.Remove(previous)
+	}
+	active.form.Values[field.Key] = value
+	active.index = active.nextIndex(active.index + 1)
+
+	if active.index < len(active.form.Fields) {
+		defer active.mutex.Unlock()
+		return active.prompt(""), true
+	}
+
+	// Semua pertanyaan terjawab
+	values := copyValues(active.form.Values)
+	active.mutex.Unlock()
+	s.remove(key, active)
+
+	s.log.Info("Sesi formulir %q untuk %s selesai", active.form.Title, key)
+	return active.form.OnComplete(values, msg), true
+}
+
+// Cancel menghentikan sesi yang aktif tanpa menjalankan OnCancel
+func (s *FormSessionService) Cancel(key string) bool {
+	s.mutex.Lock()
+	active, exists := s.sessions[key]
+	if exists {
+		delete(s.sessions, key)
+	}
+	s.mutex.Unlock()
+
+	if exists {
+		active.removeMedia()
+	}
+	return exists
+}
+
+// HasActive memeriksa apakah ada sesi yang sedang berjalan
+func (s *FormSessionService) HasActive(key string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	active, exists := s.sessions[key]
+	return exists && time.Now().Before(active.expiresAt)
+}
+
+// remove menghapus sesi dari daftar jika masih sesi yang sama
+func (s *FormSessionService) remove(key string, active *activeFormSession) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.sessions[key] == active {
+		delete(s.sessions, key)
+	}
+}
+
+// removeExpiredLocked menghapus sesi kedaluwarsa; mutex harus sudah dikunci
+func (s *FormSessionService) removeExpiredLocked(now time.Time) []*activeFormSession {
+	var expired []*activeFormSession
+	for key, active := range s.sessions {
+		if now.After(active.expiresAt) {
+			expired = append(expired, active)
+			delete(s.sessions, key)
+		}
+	}
+	return expired
+}
+
+// expireFormSessions membersihkan media dan menjalankan callback kedaluwarsa di luar lock
+func expireFormSessions(expired []*activeFormSession) {
+	for _, active := range expired {
+		active.removeMedia()
+		if active.form.OnExpire != nil {
+			active.form.OnExpire()
+		}
+	}
+}
+
+// nextIndex mencari pertanyaan berikutnya yang belum memiliki jawaban mulai dari indeks from
+func (a *activeFormSession) nextIndex(from int) int {
+	for i := from; i < len(a.form.Fields); i++ {
+		if _, answered := a.form.Values[a.form.Fields[i].Key]; !answered {
+			return i
+		}
+	}
+	return len(a.form.Fields)
+}
+
+// prompt memformat pertanyaan yang sedang aktif beserta pesan tambahan di atasnya
+func (a *activeFormSession) prompt(notice string) string {
+	field := a.form.Fields[a.index]
+
+	var sb strings.Builder
+	if notice != "" {
+		sb.WriteString(notice + "\n\n")
+	}
+
+	sb.WriteString(fmt.Sprintf("📝 %s (%d/%d)\n", a.form.Title, a.index+1, len(a.form.Fields)))
+	sb.WriteString("────────────────────────\n")
+	sb.WriteString(field.Prompt + "\n")
+
+	for i, choice := range field.Choices {
+		sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, choice))
+	}
+
+	if field.Hint != "" {
+		sb.WriteString("Contoh: " + field.Hint + "\n")
+	}
+
+	if current, answered := a.form.Values[field.Key]; answered && current != "" && !field.AcceptMedia {
+		sb.WriteString("Jawaban saat ini: " + current + "\n")
+	}
+
+	sb.WriteString("────────────────────────\n")
+	if field.Optional {
+		sb.WriteString("Ketik *-* untuk melewati.\n")
+	}
+	sb.WriteString("Ketik *kembali* untuk pertanyaan sebelumnya atau *batal* untuk membatalkan.")
+
+	return sb.String()
+}
+
+// removeMedia menghapus file media yang sudah diunduh untuk sesi ini
+func (a *activeFormSession) removeMedia() {
+	for _, field := range a.form.Fields {
+		if !field.AcceptMedia {
+			continue
+		}
+		if path := a.form.Values[field.Key]; path != "" {
+			os.Remove(path)
+		}
+	}
+}
+
+// answerValue memvalidasi jawaban pengguna untuk sebuah pertanyaan
+func answerValue(field service.FormField, text string, msg *message.Message) (string, error) {
+	if field.AcceptMedia && msg.HasMedia() {
+		path, err := msg.DownloadMedia()
+		if err != nil {
+			return "", fmt.Errorf("gagal mengunduh media: %v", err)
+		}
+		return path, nil
+	}
+
+	if field.Optional && formSkipAnswers[strings.ToLower(text)] {
+		return "", nil
+	}
+
+	if field.AcceptMedia {
+		if field.Optional {
+			return "", fmt.Errorf("kirim foto atau ketik - untuk melewati")
+		}
+		return "", fmt.Errorf("mohon kirim foto atau dokumen")
+	}
+
+	return normalizeAnswer(field, text)
+}
+
+// normalizeAnswer memvalidasi jawaban teks terhadap pilihan dan validator field
+func normalizeAnswer(field service.FormField, text string) (string, error) {
+	if text == "" {
+		return "", fmt.Errorf("jawaban tidak boleh kosong")
+	}
+
+	if len(field.Choices) > 0 {
+		choice, ok := resolveChoice(field.Choices, text)
+		if !ok {
+			return "", fmt.Errorf("pilihan %q tidak tersedia, jawab dengan nomor atau nama pilihan", text)
+		}
+		text = choice
+	}
+
+	if field.Validate != nil {
+		return field.Validate(text)
+	}
+	return text, nil
+}
+
+// resolveChoice mencocokkan jawaban dengan nomor atau nama pilihan
+func resolveChoice(choices []string, answer string) (string, bool) {
+	if number, err := strconv.Atoi(answer); err == nil {
+		if number >= 1 && number <= len(choices) {
+			return choices[number-1], true
+		}
+		return "", false
+	}
+
+	for _, choice := range choices {
+		if strings.EqualFold(choice, answer) {
+			return choice, true
+		}
+	}
+	return "", false
+}
+
+// copyValues menyalin jawaban agar callback tidak berbagi map dengan sesi
+func copyValues(values map[string]string) map[string]string {
+	result := make(map[string]string, len(values))
+	for key, value := range values {
+		result[key] = value
+	}
+	return result
+}
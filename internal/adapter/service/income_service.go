@@ -6,14 +6,17 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/gwenziro/botopia/internal/domain/audit"
+	"github.com/gwenziro/botopia/internal/domain/errors"
 	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/money"
 )
 
 // AddIncome menambahkan record pemasukan baru
 func (s *FinanceService) AddIncome(
 	ctx context.Context,
 	description string,
-	amount float64,
+	amount money.Money,
 	category string,
 	storageMedia string,
 	notes string,
@@ -37,13 +40,13 @@ func (s *FinanceService) AddIncomeWithDate(
 	ctx context.Context,
 	date time.Time,
 	description string,
-	amount float64,
+	amount money.Money,
 	category string,
 	storageMedia string,
 	notes string,
 	proofURL string,
 ) (*finance.FinanceRecord, error) {
-	s.log.Info("Menambahkan pemasukan baru dengan tanggal kustom: %s - %s (%.2f)",
+	s.log.Info("Menambahkan pemasukan baru dengan tanggal kustom: %s - %s (%s)",
 		date.Format("2006-01-02"), description, amount)
 
 	// Handle blank notes
@@ -51,16 +54,6 @@ func (s *FinanceService) AddIncomeWithDate(
 		notes = "-"
 	}
 
-	// Validasi kategori dan media penyimpanan terhadap konfigurasi
-	if s.config != nil {
-		if !contains(s.config.IncomeCategories, category) {
-			return nil, fmt.Errorf("kategori pemasukan '%s' tidak valid", category)
-		}
-		if !contains(s.config.StorageMedias, storageMedia) {
-			return nil, fmt.Errorf("media penyimpanan '%s' tidak valid", storageMedia)
-		}
-	}
-
 	// Buat record untuk pemasukan
 	record := &finance.FinanceRecord{
 		Date:         date,
@@ -71,6 +64,25 @@ func (s *FinanceService) AddIncomeWithDate(
 		Notes:        notes,
 		ProofURL:     proofURL,
 		Type:         finance.TypeIncome,
+		Member:       recordMember(ctx),
+	}
+
+	// Tag #nama pada deskripsi dan catatan disimpan terpisah dari kategori
+	record.RefreshTags()
+
+	// Lengkapi field kosong berdasarkan aturan kategori
+	if err := s.ApplyCategoryRules(ctx, record); err != nil {
+		s.log.Warn("Gagal menerapkan aturan kategori: %v", err)
+	}
+
+	// Validasi kategori dan media penyimpanan terhadap konfigurasi
+	if config := s.cachedConfiguration(ctx); config != nil {
+		if !contains(config.IncomeCategories, record.Category) {
+			return nil, errors.NewValidationError("kategori", fmt.Sprintf("kategori pemasukan '%s' tidak valid", record.Category))
+		}
+		if !contains(config.StorageMedias, record.StorageMedia) {
+			return nil, errors.NewValidationError("media", fmt.Sprintf("media penyimpanan '%s' tidak valid", record.StorageMedia))
+		}
 	}
 
 	// Validasi record
@@ -78,6 +90,11 @@ func (s *FinanceService) AddIncomeWithDate(
 		return nil, err
 	}
 
+	// Tolak transaksi mundur ke periode yang sudah tutup buku
+	if err := s.checkPeriod(ctx, record.Date, "ditambah", record.Description); err != nil {
+		return nil, err
+	}
+
 	// Tambahkan ke Google Sheets
 	err := s.sheetsRepo.AddIncomeRecord(ctx, record)
 	if err != nil {
@@ -86,6 +103,9 @@ func (s *FinanceService) AddIncomeWithDate(
 	}
 
 	s.log.Info("Pemasukan berhasil dicatat dengan kode: %s", record.UniqueCode)
+	s.recordAudit(ctx, audit.ActionAddIncome, record.UniqueCode, nil, record)
+	s.journalNewRecord(ctx, record)
+	s.linkReimbursement(ctx, record)
 	return record, nil
 }
 
@@ -94,25 +114,22 @@ func (s *FinanceService) ValidateAddIncomeParams(
 	ctx context.Context,
 	category, storageMedia string,
 ) error {
-	// Load config if needed
-	var err error
-	if s.config == nil {
-		s.config, err = s.GetConfiguration(ctx)
-		if err != nil {
-			return fmt.Errorf("gagal memuat konfigurasi: %v", err)
-		}
+	// Konfigurasi dimuat sesuai ledger di context
+	config, err := s.GetConfiguration(ctx)
+	if err != nil {
+		return fmt.Errorf("gagal memuat konfigurasi: %v", err)
 	}
 
 	// Validasi kategori
-	if !contains(s.config.IncomeCategories, category) {
-		return fmt.Errorf("kategori '%s' tidak valid. Kategori yang tersedia: %v",
-			category, s.config.IncomeCategories)
+	if !contains(config.IncomeCategories, category) {
+		return errors.NewValidationError("kategori", fmt.Sprintf("kategori '%s' tidak valid. Kategori yang tersedia: %v",
+			category, config.IncomeCategories))
 	}
 
 	// Validasi media penyimpanan
-	if !contains(s.config.StorageMedias, storageMedia) {
-		return fmt.Errorf("media penyimpanan '%s' tidak valid. Media yang tersedia: %v",
-			storageMedia, s.config.StorageMedias)
+	if !contains(config.StorageMedias, storageMedia) {
+		return errors.NewValidationError("media", fmt.Sprintf("media penyimpanan '%s' tidak valid. Media yang tersedia: %v",
+			storageMedia, config.StorageMedias))
 	}
 
 	return nil
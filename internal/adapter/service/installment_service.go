@@ -0,0 +1,310 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/audit"
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/money"
+	"github.com/gwenziro/botopia/internal/domain/repository"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+	"github.com/gwenziro/botopia/internal/utils"
+)
+
+// installmentCheckInterval adalah jarak antar pengecekan cicilan yang jatuh tempo
+const installmentCheckInterval = time.Hour
+
+// InstallmentService implementasi rencana cicilan yang dicatat sebagai pengeluaran bulanan
+type InstallmentService struct {
+	installmentRepo repository.InstallmentRepository
+	financeService  service.FinanceService
+	auditService    service.AuditService
+	log             *logger.Logger
+
+	// mutex mencegah cicilan yang sama dicatat dua kali oleh job dan command secara bersamaan
+	mutex sync.Mutex
+
+	stop      chan struct{}
+	stopMutex sync.Mutex
+}
+
+// NewInstallmentService membuat instance layanan cicilan baru
+func NewInstallmentService(
+	installmentRepo repository.InstallmentRepository,
+	financeService service.FinanceService,
+	auditService service.AuditService,
+	log *logger.Logger,
+) *InstallmentService {
+	return &InstallmentService{
+		installmentRepo: installmentRepo,
+		financeService:  financeService,
+		auditService:    auditService,
+		log:             log,
+	}
+}
+
+// Memastikan InstallmentService mengimplementasikan interface service.InstallmentService
+var _ service.InstallmentService = (*InstallmentService)(nil)
+
+// Start menjalankan pencatatan cicilan jatuh tempo secara berkala di background
+func (s *InstallmentService) Start() {
+	s.stopMutex.Lock()
+	defer s.stopMutex.Unlock()
+
+	if s.stop != nil {
+		return
+	}
+	s.stop = make(chan struct{})
+
+	go s.run(s.stop)
+	s.log.Info("Pencatatan cicilan otomatis aktif, dicek setiap %v", installmentCheckInterval)
+}
+
+// Stop menghentikan pencatatan berkala
+func (s *InstallmentService) Stop() {
+	s.stopMutex.Lock()
+	defer s.stopMutex.Unlock()
+
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+}
+
+// run mencatat cicilan yang tertinggal saat aplikasi mulai, lalu mengecek secara berkala sampai dihentikan
+func (s *InstallmentService) run(stop <-chan struct{}) {
+	ticker := time.NewTicker(installmentCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		if recorded, err := s.ProcessDue(ctx); err != nil {
+			s.log.Warn("Gagal mencatat cicilan jatuh tempo: %v", err)
+		} else if recorded > 0 {
+			s.log.Info("%d cicilan jatuh tempo dicatat sebagai pengeluaran", recorded)
+		}
+		cancel()
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Create menyimpan rencana cicilan baru dan langsung mencatat cicilan yang sudah jatuh tempo
+func (s *InstallmentService) Create(ctx context.Context, plan *finance.InstallmentPlan) (*finance.InstallmentPlan, error) {
+	if err := plan.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.financeService.ValidateAddExpenseParams(ctx, plan.Category, plan.PaymentMethod, plan.StorageMedia); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	code, err := s.nextCode(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.Code = code
+	plan.Status = finance.InstallmentActive
+	plan.Payments = nil
+	plan.CreatedAt = time.Now()
+	plan.Ledger = finance.LedgerFromContext(ctx)
+	if plan.CreatedBy == "" {
+		plan.CreatedBy = audit.ActorFromContext(ctx).String()
+	}
+
+	if err := s.installmentRepo.Save(ctx, plan); err != nil {
+		return nil, fmt.Errorf("gagal menyimpan rencana cicilan: %v", err)
+	}
+	s.recordAudit(ctx, audit.ActionCreateInstallment, plan.Code, nil, plan)
+
+	// Cicilan yang dimulai di masa lalu langsung dicatat sampai bulan berjalan
+	if _, err := s.recordDue(ctx, plan, time.Now()); err != nil {
+		s.log.Warn("Gagal mencatat cicilan jatuh tempo %s: %v", plan.Code, err)
+	}
+
+	return plan, nil
+}
+
+// List mendapatkan seluruh rencana cicilan di ledger context, diurutkan dari yang paling lama
+func (s *InstallmentService) List(ctx context.Context) ([]*finance.InstallmentPlan, error) {
+	plans, err := s.installmentRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ledgerID := finance.LedgerFromContext(ctx)
+	owned := make([]*finance.InstallmentPlan, 0, len(plans))
+	for _, plan := range plans {
+		if plan.Ledger == ledgerID {
+			owned = append(owned, plan)
+		}
+	}
+	return owned, nil
+}
+
+// Get mendapatkan rencana cicilan berdasarkan kode
+func (s *InstallmentService) Get(ctx context.Context, code string) (*finance.InstallmentPlan, error) {
+	plan, err := s.installmentRepo.FindByCode(ctx, strings.ToLower(strings.TrimSpace(code)))
+	if err != nil {
+		return nil, err
+	}
+	// Cicilan ledger lain diperlakukan seperti tidak ada
+	if plan == nil || plan.Ledger != finance.LedgerFromContext(ctx) {
+		return nil, fmt.Errorf("cicilan %s tidak ditemukan", code)
+	}
+	return plan, nil
+}
+
+// PayOff melunasi sisa cicilan sekaligus; amount 0 berarti sebesar sisa tagihan
+func (s *InstallmentService) PayOff(ctx context.Context, code string, amount money.Money) (*finance.InstallmentPlan, *finance.FinanceRecord, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	plan, err := s.Get(ctx, code)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !plan.IsActive() {
+		return nil, nil, fmt.Errorf("cicilan %s sudah tidak berjalan", plan.Code)
+	}
+
+	// Cicilan yang sudah jatuh tempo dicatat terlebih dahulu agar sisa tagihan akurat
+	now := time.Now()
+	if _, err := s.recordDue(ctx, plan, now); err != nil {
+		return nil, nil, err
+	}
+	if !plan.IsActive() {
+		return nil, nil, fmt.Errorf("cicilan %s sudah selesai, tidak ada sisa tagihan", plan.Code)
+	}
+
+	outstanding := plan.Outstanding()
+	if !amount.IsPositive() {
+		amount = outstanding
+	}
+
+	before := *plan
+	notes := fmt.Sprintf("Pelunasan cicilan %s, sisa %d dari %d bulan (tagihan Rp %s)",
+		plan.Code, plan.RemainingTenor(), plan.Tenor, utils.FormatMoney(outstanding))
+
+	record, err := s.financeService.AddExpenseWithDate(
+		ctx, now, plan.Description, amount, plan.Category,
+		plan.PaymentMethod, plan.StorageMedia, notes, "",
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("gagal mencatat pelunasan: %v", err)
+	}
+
+	plan.Payments = append(plan.Payments, &finance.InstallmentPayment{
+		Sequence:   0,
+		DueDate:    now,
+		Amount:     amount,
+		RecordCode: record.UniqueCode,
+		RecordedAt: now,
+	})
+	plan.Status = finance.InstallmentPaidOff
+	plan.ClosedAt = now
+
+	if err := s.installmentRepo.Save(ctx, plan); err != nil {
+		return nil, nil, fmt.Errorf("pelunasan tercatat (%s) tapi gagal memperbarui cicilan: %v", record.UniqueCode, err)
+	}
+	s.recordAudit(ctx, audit.ActionPayOffInstallment, plan.Code, &before, plan)
+
+	return plan, record, nil
+}
+
+// ProcessDue mencatat seluruh cicilan yang sudah jatuh tempo dan mengembalikan jumlah pengeluaran tercatat
+func (s *InstallmentService) ProcessDue(ctx context.Context) (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	plans, err := s.installmentRepo.FindAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("gagal membaca rencana cicilan: %v", err)
+	}
+
+	now := time.Now()
+	recorded := 0
+	for _, plan := range plans {
+		count, err := s.recordDue(ctx, plan, now)
+		recorded += count
+		if err != nil {
+			// Cicilan lain tetap diproses, yang gagal dicoba lagi pada pengecekan berikutnya
+			s.log.Warn("Gagal mencatat cicilan %s: %v", plan.Code, err)
+		}
+	}
+
+	return recorded, nil
+}
+
+// recordDue mencatat cicilan jatuh tempo sebuah rencana secara berurutan, pemanggil harus memegang mutex
+func (s *InstallmentService) recordDue(ctx context.Context, plan *finance.InstallmentPlan, now time.Time) (int, error) {
+	ctx = finance.WithLedger(ctx, plan.Ledger)
+
+	recorded := 0
+	for _, sequence := range plan.DueSequences(now) {
+		dueDate := plan.DueDate(sequence)
+		amount := plan.InstallmentAmount(sequence)
+		notes := fmt.Sprintf("Cicilan %d/%d (%s)", sequence, plan.Tenor, plan.Code)
+
+		record, err := s.financeService.AddExpenseWithDate(
+			ctx, dueDate, plan.Description, amount, plan.Category,
+			plan.PaymentMethod, plan.StorageMedia, notes, "",
+		)
+		if err != nil {
+			return recorded, fmt.Errorf("cicilan ke-%d: %v", sequence, err)
+		}
+
+		plan.Payments = append(plan.Payments, &finance.InstallmentPayment{
+			Sequence:   sequence,
+			DueDate:    dueDate,
+			Amount:     amount,
+			RecordCode: record.UniqueCode,
+			RecordedAt: now,
+		})
+		if sequence == plan.Tenor {
+			plan.Status = finance.InstallmentCompleted
+			plan.ClosedAt = now
+		}
+
+		if err := s.installmentRepo.Save(ctx, plan); err != nil {
+			return recorded, fmt.Errorf("cicilan ke-%d tercatat (%s) tapi gagal memperbarui rencana: %v",
+				sequence, record.UniqueCode, err)
+		}
+		recorded++
+	}
+
+	return recorded, nil
+}
+
+// nextCode membuat kode cicilan berurutan (c_001, c_002, ...)
+func (s *InstallmentService) nextCode(ctx context.Context) (string, error) {
+	plans, err := s.installmentRepo.FindAll(ctx)
+	if err != nil {
+		return "", fmt.Errorf("gagal membaca rencana cicilan: %v", err)
+	}
+
+	return fmt.Sprintf("%s%03d", finance.InstallmentCodePrefix, len(plans)+1), nil
+}
+
+// recordAudit mencatat perubahan ke audit log; kegagalan hanya dicatat di log aplikasi
+func (s *InstallmentService) recordAudit(ctx context.Context, action audit.Action, target string, before, after interface{}) {
+	if s.auditService == nil {
+		return
+	}
+
+	if err := s.auditService.Record(ctx, action, target, before, after); err != nil {
+		s.log.Error("Gagal mencatat audit %s untuk %s: %v", action, target, err)
+	}
+}
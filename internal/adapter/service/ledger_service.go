@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/repository"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+// LedgerService implementasi pengelolaan ledger per kontak atau grup
+type LedgerService struct {
+	ledgerRepo  repository.LedgerRepository
+	contactRepo repository.ContactRepository
+	log         *logger.Logger
+}
+
+// NewLedgerService membuat instance layanan ledger baru
+func NewLedgerService(
+	ledgerRepo repository.LedgerRepository,
+	contactRepo repository.ContactRepository,
+	log *logger.Logger,
+) *LedgerService {
+	return &LedgerService{
+		ledgerRepo:  ledgerRepo,
+		contactRepo: contactRepo,
+		log:         log,
+	}
+}
+
+// Memastikan LedgerService mengimplementasikan interface service.LedgerService
+var _ service.LedgerService = (*LedgerService)(nil)
+
+// List mendapatkan seluruh ledger tambahan, diurutkan berdasarkan nama
+func (s *LedgerService) List(ctx context.Context) ([]*finance.Ledger, error) {
+	return s.ledgerRepo.FindAll(ctx)
+}
+
+// Get mendapatkan ledger berdasarkan ID
+func (s *LedgerService) Get(ctx context.Context, id string) (*finance.Ledger, error) {
+	ledger, err := s.ledgerRepo.FindByID(ctx, finance.NormalizeLedgerID(id))
+	if err != nil {
+		return nil, err
+	}
+	if ledger == nil {
+		return nil, fmt.Errorf("ledger %s tidak ditemukan", id)
+	}
+	return ledger, nil
+}
+
+// Save menyimpan ledger baru atau memperbarui nama dan spreadsheet ledger yang sudah ada
+func (s *LedgerService) Save(ctx context.Context, ledger *finance.Ledger) (*finance.Ledger, error) {
+	ledger.ID = finance.NormalizeLedgerID(ledger.ID)
+	ledger.Name = strings.TrimSpace(ledger.Name)
+	ledger.SpreadsheetID = strings.TrimSpace(ledger.SpreadsheetID)
+	ledger.DriveFolderID = strings.TrimSpace(ledger.DriveFolderID)
+	if err := ledger.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.ledgerRepo.FindByID(ctx, ledger.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	ledger.CreatedAt = now
+	if existing != nil {
+		ledger.CreatedAt = existing.CreatedAt
+	}
+	ledger.UpdatedAt = now
+
+	if err := s.ledgerRepo.Save(ctx, ledger); err != nil {
+		return nil, fmt.Errorf("gagal menyimpan ledger: %v", err)
+	}
+
+	return ledger, nil
+}
+
+// Delete menghapus ledger yang tidak lagi dipakai kontak mana pun
+func (s *LedgerService) Delete(ctx context.Context, id string) error {
+	ledger, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	contacts, err := s.contactRepo.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Kontak yang masih terhubung tidak dialihkan diam-diam ke ledger utama milik orang lain
+	var users []string
+	for _, c := range contacts {
+		if c.LedgerID == ledger.ID {
+			users = append(users, c.Phone)
+		}
+	}
+	if len(users) > 0 {
+		return fmt.Errorf("ledger %s masih dipakai %d kontak (%s), pindahkan kontak terlebih dahulu",
+			ledger.ID, len(users), strings.Join(users, ", "))
+	}
+
+	return s.ledgerRepo.Delete(ctx, ledger.ID)
+}
+
+// Assign mengarahkan kontak atau grup ke ledger; ID kosong berarti kembali ke ledger utama
+func (s *LedgerService) Assign(ctx context.Context, phone, ledgerID string) error {
+	ledgerID = finance.NormalizeLedgerID(ledgerID)
+	if ledgerID != finance.DefaultLedgerID {
+		if _, err := s.Get(ctx, ledgerID); err != nil {
+			return err
+		}
+	}
+
+	phone = normalizePhone(phone)
+	c, err := s.contactRepo.FindByPhone(ctx, phone)
+	if err != nil {
+		return err
+	}
+	if c == nil {
+		return fmt.Errorf("kontak dengan nomor %s tidak ditemukan", phone)
+	}
+
+	c.LedgerID = ledgerID
+	if err := s.contactRepo.Save(ctx, c); err != nil {
+		return err
+	}
+
+	s.log.Info("Kontak %s diarahkan ke ledger %s", phone, finance.LedgerLabel(ledgerID))
+	return nil
+}
+
+// Resolve menentukan ledger pesan: ledger grup jika pesan dari grup yang dipetakan, lalu ledger pengirim
+func (s *LedgerService) Resolve(ctx context.Context, chatID string, isGroup bool, senderPhone string) (string, error) {
+	// Grup didaftarkan sebagai kontak dengan ID grup (misalnya 120363012345678901@g.us) sebagai nomor
+	if isGroup && chatID != "" {
+		group, err := s.contactRepo.FindByPhone(ctx, normalizePhone(chatID))
+		if err != nil {
+			return "", err
+		}
+		if group != nil && group.LedgerID != finance.DefaultLedgerID {
+			return group.LedgerID, nil
+		}
+	}
+
+	if senderPhone == "" {
+		return finance.DefaultLedgerID, nil
+	}
+
+	sender, err := s.contactRepo.FindByPhone(ctx, normalizePhone(senderPhone))
+	if err != nil {
+		return "", err
+	}
+	if sender == nil {
+		return finance.DefaultLedgerID, nil
+	}
+	return sender.LedgerID, nil
+}
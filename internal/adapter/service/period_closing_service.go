@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/audit"
+	"github.com/gwenziro/botopia/internal/domain/errors"
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/repository"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+	"github.com/gwenziro/botopia/internal/utils"
+)
+
+// periodOverrideWindow lama izin admin mengubah periode tertutup setelah !tutupbuku izinkan
+const periodOverrideWindow = 10 * time.Minute
+
+// PeriodClosingService implementasi layanan tutup buku bulanan
+type PeriodClosingService struct {
+	closingRepo  repository.PeriodClosingRepository
+	financeRepo  repository.FinanceRepository
+	auditService service.AuditService
+	admins       []string
+	mutex        sync.Mutex
+	log          *logger.Logger
+
+	// overrides izin sementara per admin dan periode, berisi batas waktunya
+	overrides     map[string]time.Time
+	overridesLock sync.Mutex
+}
+
+// NewPeriodClosingService membuat instance layanan tutup buku baru
+func NewPeriodClosingService(
+	closingRepo repository.PeriodClosingRepository,
+	financeRepo repository.FinanceRepository,
+	auditService service.AuditService,
+	admins []string,
+	log *logger.Logger,
+) *PeriodClosingService {
+	normalized := make([]string, 0, len(admins))
+	for _, phone := range admins {
+		if phone = strings.TrimSpace(phone); phone != "" {
+			normalized = append(normalized, normalizePhone(phone))
+		}
+	}
+
+	return &PeriodClosingService{
+		closingRepo:  closingRepo,
+		financeRepo:  financeRepo,
+		auditService: auditService,
+		admins:       normalized,
+		log:          log,
+		overrides:    make(map[string]time.Time),
+	}
+}
+
+// Memastikan PeriodClosingService mengimplementasikan interface service.PeriodClosingService
+var _ service.PeriodClosingService = (*PeriodClosingService)(nil)
+
+// Close menutup buku periode dan menyimpan ringkasan angkanya
+func (s *PeriodClosingService) Close(ctx context.Context, period string) (*finance.PeriodClosing, error) {
+	start, err := finance.ParsePeriod(period)
+	if err != nil {
+		return nil, err
+	}
+
+	// Periode berjalan masih bisa bertambah transaksi, tutup buku hanya untuk bulan yang sudah lewat
+	if time.Now().Before(start.AddDate(0, 1, 0)) {
+		return nil, fmt.Errorf("periode %s belum berakhir", utils.FormatMonthID(start))
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	existing, err := s.closingRepo.FindByPeriod(ctx, period)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("periode %s sudah tutup buku pada %s", utils.FormatMonthID(start), utils.FormatDateID(existing.ClosedAt))
+	}
+
+	// Cukup baca spreadsheet yang mencakup bulan yang ditutup
+	records, err := s.financeRepo.GetRecordsBetween(ctx, start, start.AddDate(0, 1, -1))
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil data transaksi: %v", err)
+	}
+
+	closing := &finance.PeriodClosing{
+		Period:   period,
+		Snapshot: finance.BuildPeriodSnapshot(records, period),
+		ClosedAt: time.Now(),
+		ClosedBy: audit.ActorFromContext(ctx).String(),
+		Ledger:   finance.LedgerFromContext(ctx),
+	}
+
+	if err := s.closingRepo.Save(ctx, closing); err != nil {
+		return nil, fmt.Errorf("gagal menyimpan tutup buku: %v", err)
+	}
+
+	s.log.Info("Periode %s tutup buku oleh %s", period, closing.ClosedBy)
+	s.recordAudit(ctx, audit.ActionClosePeriod, period, nil, closing)
+	return closing, nil
+}
+
+// Reopen membuka kembali periode yang sudah tutup buku
+func (s *PeriodClosingService) Reopen(ctx context.Context, period string) error {
+	start, err := finance.ParsePeriod(period)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	closing, err := s.closingRepo.FindByPeriod(ctx, period)
+	if err != nil {
+		return err
+	}
+	if closing == nil {
+		return fmt.Errorf("periode %s belum tutup buku", utils.FormatMonthID(start))
+	}
+
+	if err := s.closingRepo.Delete(ctx, period); err != nil {
+		return fmt.Errorf("gagal membuka kembali periode: %v", err)
+	}
+
+	s.log.Info("Periode %s dibuka kembali oleh %s", period, audit.ActorFromContext(ctx))
+	s.recordAudit(ctx, audit.ActionReopenPeriod, period, closing, nil)
+	return nil
+}
+
+// GetClosing mendapatkan data tutup buku periode (nil jika periode masih terbuka)
+func (s *PeriodClosingService) GetClosing(ctx context.Context, period string) (*finance.PeriodClosing, error) {
+	return s.closingRepo.FindByPeriod(ctx, period)
+}
+
+// ListClosings mendapatkan seluruh periode tertutup
+func (s *PeriodClosingService) ListClosings(ctx context.Context) ([]*finance.PeriodClosing, error) {
+	return s.closingRepo.FindAll(ctx)
+}
+
+// CheckWritable memastikan transaksi bertanggal date boleh ditambah, diubah, atau dihapus
+func (s *PeriodClosingService) CheckWritable(ctx context.Context, date time.Time, operation, target string) error {
+	period := finance.PeriodOf(date)
+	closing, err := s.closingRepo.FindByPeriod(ctx, period)
+	if err != nil {
+		return fmt.Errorf("gagal memeriksa tutup buku: %v", err)
+	}
+	if closing == nil {
+		return nil
+	}
+
+	if !s.hasOverride(ctx, period) {
+		actor := audit.ActorFromContext(ctx)
+		if actor.Channel == audit.ChannelWhatsApp && s.IsAdmin(actor.Phone) {
+			return errors.NewPeriodClosedError(utils.FormatMonthID(date), operation,
+				fmt.Sprintf("Jika memang perlu, kirim !tutupbuku izinkan %s lalu ulangi perintah",
+					strings.ToLower(utils.FormatMonthID(date))))
+		}
+		return errors.NewPeriodClosedError(utils.FormatMonthID(date), operation, "")
+	}
+
+	s.log.Warn("Admin %s mengubah periode tertutup %s: %s %s", audit.ActorFromContext(ctx), period, target, operation)
+	s.recordAudit(ctx, audit.ActionPeriodOverride, target, nil, map[string]string{
+		"period":    period,
+		"operation": operation,
+	})
+	return nil
+}
+
+// IsAdmin memeriksa apakah nomor WhatsApp termasuk admin keuangan
+func (s *PeriodClosingService) IsAdmin(phone string) bool {
+	phone = normalizePhone(phone)
+	for _, admin := range s.admins {
+		if admin == phone {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowOverride memberi admin pengirim izin sementara mengubah transaksi pada periode tertutup
+func (s *PeriodClosingService) AllowOverride(ctx context.Context, period string) (time.Time, error) {
+	start, err := finance.ParsePeriod(period)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	actor := audit.ActorFromContext(ctx)
+	if actor.Channel != audit.ChannelWhatsApp || !s.IsAdmin(actor.Phone) {
+		return time.Time{}, fmt.Errorf("hanya admin keuangan yang dapat mengubah periode tertutup")
+	}
+
+	closing, err := s.closingRepo.FindByPeriod(ctx, period)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if closing == nil {
+		return time.Time{}, fmt.Errorf("periode %s belum tutup buku", utils.FormatMonthID(start))
+	}
+
+	expiresAt := time.Now().Add(periodOverrideWindow)
+	s.overridesLock.Lock()
+	s.overrides[s.overrideKey(ctx, actor.Phone, period)] = expiresAt
+	s.overridesLock.Unlock()
+
+	s.log.Warn("Admin %s diizinkan mengubah periode tertutup %s hingga %s", actor, period, expiresAt.Format("15:04"))
+	return expiresAt, nil
+}
+
+// hasOverride memeriksa izin mengubah periode tertutup: penanda eksplisit dari web,
+// atau izin sementara yang diminta admin WhatsApp dengan !tutupbuku izinkan
+func (s *PeriodClosingService) hasOverride(ctx context.Context, period string) bool {
+	if finance.HasPeriodOverride(ctx) {
+		return true
+	}
+
+	actor := audit.ActorFromContext(ctx)
+	if actor.Channel != audit.ChannelWhatsApp || actor.Phone == "" || !s.IsAdmin(actor.Phone) {
+		return false
+	}
+
+	key := s.overrideKey(ctx, actor.Phone, period)
+	s.overridesLock.Lock()
+	defer s.overridesLock.Unlock()
+
+	expiresAt, ok := s.overrides[key]
+	if ok && time.Now().After(expiresAt) {
+		delete(s.overrides, key)
+		return false
+	}
+	return ok
+}
+
+// overrideKey membuat kunci izin sementara per ledger, admin, dan periode
+func (s *PeriodClosingService) overrideKey(ctx context.Context, phone, period string) string {
+	return finance.LedgerScopedKey(finance.LedgerFromContext(ctx), normalizePhone(phone)+"/"+period)
+}
+
+// recordAudit mencatat perubahan ke audit log; kegagalan hanya dicatat di log aplikasi
+func (s *PeriodClosingService) recordAudit(ctx context.Context, action audit.Action, target string, before, after interface{}) {
+	if s.auditService == nil {
+		return
+	}
+
+	if err := s.auditService.Record(ctx, action, target, before, after); err != nil {
+		s.log.Error("Gagal mencatat audit %s untuk %s: %v", action, target, err)
+	}
+}
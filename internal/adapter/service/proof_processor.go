@@ -0,0 +1,116 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	// Dekoder format gambar yang didukung
+	_ "image/gif"
+	_ "image/png"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+const (
+	// DefaultProofMaxDimension adalah sisi terpanjang default gambar bukti dalam piksel
+	DefaultProofMaxDimension = 1600
+
+	// DefaultProofJPEGQuality adalah kualitas JPEG default untuk gambar bukti
+	DefaultProofJPEGQuality = 80
+)
+
+// ProofProcessor implementasi pemrosesan gambar bukti transaksi:
+// menghapus metadata EXIF, memutar sesuai orientasi, memperkecil, dan encode ulang ke JPEG
+type ProofProcessor struct {
+	maxDimension int
+	jpegQuality  int
+	log          *logger.Logger
+}
+
+// NewProofProcessor membuat instance pemroses bukti baru
+func NewProofProcessor(maxDimension, jpegQuality int, log *logger.Logger) *ProofProcessor {
+	if maxDimension <= 0 {
+		maxDimension = DefaultProofMaxDimension
+	}
+	if jpegQuality < 1 || jpegQuality > 100 {
+		jpegQuality = DefaultProofJPEGQuality
+	}
+
+	return &ProofProcessor{
+		maxDimension: maxDimension,
+		jpegQuality:  jpegQuality,
+		log:          log,
+	}
+}
+
+// Memastikan ProofProcessor mengimplementasikan interface service.ProofProcessor
+var _ service.ProofProcessor = (*ProofProcessor)(nil)
+
+// Process menormalkan gambar bukti dan menghitung hash kontennya.
+// File non-gambar (misalnya PDF) hanya dihitung hash-nya tanpa diubah.
+func (p *ProofProcessor) Process(_ context.Context, filePath string) (*finance.ProcessedProof, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca file bukti: %v", err)
+	}
+
+	sum := sha256.Sum256(data)
+	result := &finance.ProcessedProof{
+		Path:         filePath,
+		Hash:         hex.EncodeToString(sum[:]),
+		OriginalSize: int64(len(data)),
+		Size:         int64(len(data)),
+	}
+
+	contentType := http.DetectContentType(data)
+	if contentType != "image/jpeg" && contentType != "image/png" && contentType != "image/gif" {
+		return result, nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		p.log.Warn("Gagal membaca gambar bukti, diunggah apa adanya: %v", err)
+		return result, nil
+	}
+
+	// Perkecil terlebih dahulu agar rotasi bekerja pada gambar yang lebih kecil
+	img = downscaleImage(img, p.maxDimension)
+
+	if contentType == "image/jpeg" {
+		img = applyOrientation(img, readExifOrientation(data))
+	}
+
+	// Encode ulang ke JPEG; metadata EXIF (termasuk lokasi) tidak ikut tersalin
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: p.jpegQuality}); err != nil {
+		return nil, fmt.Errorf("gagal mengencode gambar bukti: %v", err)
+	}
+
+	outputPath := strings.TrimSuffix(filePath, filepath.Ext(filePath)) + "_normalized.jpg"
+	if err := os.WriteFile(outputPath, buf.Bytes(), 0644); err != nil {
+		return nil, fmt.Errorf("gagal menyimpan gambar bukti: %v", err)
+	}
+
+	bounds := img.Bounds()
+	result.Path = outputPath
+	result.Size = int64(buf.Len())
+	result.Width = bounds.Dx()
+	result.Height = bounds.Dy()
+	result.Normalized = true
+
+	p.log.Info("Gambar bukti dinormalisasi: %dx%d, %d KB -> %d KB",
+		result.Width, result.Height, result.OriginalSize/1024, result.Size/1024)
+
+	return result, nil
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/repository"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+	"github.com/gwenziro/botopia/internal/utils"
+)
+
+// proofReminderCheckInterval adalah jarak antar pengecekan transaksi tanpa bukti.
+// Frekuensi pengingat per transaksi tetap dibatasi oleh ProofReminderPolicy.Interval.
+const proofReminderCheckInterval = time.Hour
+
+// ProofReminderService implementasi pengingat bukti transaksi melalui WhatsApp
+type ProofReminderService struct {
+	financeRepo    repository.FinanceRepository
+	authorRepo     repository.RecordAuthorRepository
+	connectionRepo repository.ConnectionRepository
+	policy         *finance.ProofReminderPolicy
+	log            *logger.Logger
+
+	stop  chan struct{}
+	mutex sync.Mutex
+}
+
+// NewProofReminderService membuat instance layanan pengingat bukti baru
+func NewProofReminderService(
+	financeRepo repository.FinanceRepository,
+	authorRepo repository.RecordAuthorRepository,
+	connectionRepo repository.ConnectionRepository,
+	policy *finance.ProofReminderPolicy,
+	log *logger.Logger,
+) *ProofReminderService {
+	return &ProofReminderService{
+		financeRepo:    financeRepo,
+		authorRepo:     authorRepo,
+		connectionRepo: connectionRepo,
+		policy:         policy,
+		log:            log,
+	}
+}
+
+// Memastikan ProofReminderService mengimplementasikan interface service.ProofReminderService
+var _ service.ProofReminderService = (*ProofReminderService)(nil)
+
+// Start menjalankan pengecekan pengingat secara berkala di background
+func (s *ProofReminderService) Start() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.stop != nil {
+		return
+	}
+	s.stop = make(chan struct{})
+
+	go s.run(s.stop)
+	s.log.Info("Pengingat bukti transaksi aktif: umur minimal %d hari, diulang setiap %v",
+		s.policy.MinAgeDays, s.policy.Interval)
+}
+
+// Stop menghentikan pengecekan berkala
+func (s *ProofReminderService) Stop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+}
+
+// run menjalankan loop pengecekan sampai dihentikan
+func (s *ProofReminderService) run(stop <-chan struct{}) {
+	ticker := time.NewTicker(proofReminderCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			if sent, err := s.SendReminders(ctx); err != nil {
+				s.log.Warn("Gagal mengirim pengingat bukti: %v", err)
+			} else if sent > 0 {
+				s.log.Info("%d pengingat bukti transaksi terkirim", sent)
+			}
+			cancel()
+		}
+	}
+}
+
+// SendReminders mengirim ringkasan pengingat ke setiap pembuat transaksi
+func (s *ProofReminderService) SendReminders(ctx context.Context) (int, error) {
+	if !s.connectionRepo.IsConnected() {
+		return 0, nil
+	}
+
+	authors, err := s.authorRepo.FindAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("gagal membaca pembuat transaksi: %v", err)
+	}
+	if len(authors) == 0 {
+		return 0, nil
+	}
+
+	// Kode transaksi hanya unik di dalam satu ledger, jadi pembuat dikelompokkan per ledger
+	authorsByLedger := make(map[string]map[string]*finance.RecordAuthor)
+	var ledgers []string
+	for _, author := range authors {
+		if _, exists := authorsByLedger[author.Ledger]; !exists {
+			authorsByLedger[author.Ledger] = make(map[string]*finance.RecordAuthor)
+			ledgers = append(ledgers, author.Ledger)
+		}
+		authorsByLedger[author.Ledger][author.RecordCode] = author
+	}
+
+	// Kelompokkan transaksi tanpa bukti per pembuat
+	now := time.Now()
+	digests := make(map[string][]*finance.FinanceRecord)
+	recipients := make(map[string][]*finance.RecordAuthor)
+	var order []string
+
+	for _, ledgerID := range ledgers {
+		records, err := s.financeRepo.GetAllRecords(finance.WithLedger(ctx, ledgerID))
+		if err != nil {
+			// Ledger yang bermasalah tidak menghalangi pengingat ledger lain
+			s.log.Warn("Gagal membaca transaksi ledger %s: %v", finance.LedgerLabel(ledgerID), err)
+			continue
+		}
+
+		authorByCode := authorsByLedger[ledgerID]
+		for _, record := range records {
+			author := authorByCode[record.UniqueCode]
+			if author == nil || !s.policy.NeedsReminder(record, now) {
+				continue
+			}
+			if !author.RemindedAt.IsZero() && now.Sub(author.RemindedAt) < s.policy.Interval {
+				continue
+			}
+
+			if _, exists := digests[author.UserID]; !exists {
+				order = append(order, author.UserID)
+			}
+			digests[author.UserID] = append(digests[author.UserID], record)
+			recipients[author.UserID] = append(recipients[author.UserID], author)
+		}
+	}
+
+	sent := 0
+	for _, userID := range order {
+		entries := recipients[userID]
+		if err := s.connectionRepo.SendMessage(ctx, entries[0].PrivateChatID(), formatProofReminder(digests[userID])); err != nil {
+			s.log.Warn("Gagal mengirim pengingat bukti ke %s: %v", entries[0].Phone, err)
+			continue
+		}
+		sent++
+
+		for _, author := range entries {
+			author.RemindedAt = now
+			if err := s.authorRepo.Save(ctx, author); err != nil {
+				s.log.Warn("Gagal menyimpan waktu pengingat %s: %v", author.RecordCode, err)
+			}
+		}
+	}
+
+	return sent, nil
+}
+
+// formatProofReminder memformat ringkasan transaksi yang belum memiliki bukti.
+// Pesan ini dapat langsung dibalas dengan foto; baris "n. kode" dibaca kembali oleh ProofReminderCodes.
+func formatProofReminder(records []*finance.FinanceRecord) string {
+	lines := make([]string, 0, len(records))
+	for i, record := range records {
+		lines = append(lines, fmt.Sprintf("%d. %s\n   📅 %s | 📖 %s | 💰 Rp %s",
+			i+1, record.UniqueCode, utils.FormatDateShort(record.Date), record.Description, utils.FormatMoney(record.Amount)))
+	}
+
+	howTo := "📸 Balas (reply) pesan ini dengan foto bukti untuk melampirkannya."
+	if len(records) > 1 {
+		howTo = "📸 Balas (reply) pesan ini dengan foto bukti dan caption nomor urutnya.\nContoh: caption 1 untuk " + records[0].UniqueCode
+	}
+
+	return fmt.Sprintf(`────────────────────────
+🧾 %s 🧾
+────────────────────────
+Hai 👋, ada %d transaksi yang belum memiliki bukti:
+
+%s
+────────────────────────
+%s
+Atau kirim foto dengan caption !unggah <kode_transaksi>
+────────────────────────`,
+		finance.ProofReminderTitle, len(records), strings.Join(lines, "\n"), howTo)
+}
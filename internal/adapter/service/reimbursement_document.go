@@ -0,0 +1,85 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/money"
+	"github.com/gwenziro/botopia/internal/utils"
+)
+
+// claimDocumentTemplate dokumen ringkasan klaim yang dapat dibuka di browser dan dicetak ke PDF
+var claimDocumentTemplate = template.Must(template.New("claim").Funcs(template.FuncMap{
+	"money":     func(amount money.Money) string { return "Rp " + utils.FormatMoney(amount) },
+	"date":      utils.FormatDateShort,
+	"dateLong":  utils.FormatDateID,
+	"increment": func(i int) int { return i + 1 },
+}).Parse(`<!DOCTYPE html>
+<html lang="id">
+<head>
+<meta charset="utf-8">
+<title>{{.Claim.Title}} ({{.Claim.Code}})</title>
+<style>
+  body { font-family: Arial, sans-serif; color: #222; margin: 32px; }
+  h1 { font-size: 20px; margin-bottom: 4px; }
+  .meta { color: #555; margin-bottom: 24px; }
+  table { width: 100%; border-collapse: collapse; font-size: 13px; }
+  th, td { border: 1px solid #ccc; padding: 6px 8px; text-align: left; vertical-align: top; }
+  th { background: #f3f3f3; }
+  td.amount, th.amount { text-align: right; white-space: nowrap; }
+  tfoot td { font-weight: bold; }
+  .status { display: inline-block; padding: 2px 8px; border-radius: 4px; background: #eee; }
+  .paid { background: #d7f5dd; }
+  .muted { color: #888; }
+</style>
+</head>
+<body>
+<h1>{{.Claim.Title}}</h1>
+<div class="meta">
+  Kode klaim: <strong>{{.Claim.Code}}</strong><br>
+  Diajukan: {{dateLong .Claim.SubmittedAt}}{{if .Claim.SubmittedBy}} oleh {{.Claim.SubmittedBy}}{{end}}<br>
+  Status: <span class="status{{if .Claim.IsPaid}} paid{{end}}">{{.Claim.StatusLabel}}</span>
+  {{if .Claim.IsPaid}}<br>Dibayar: {{dateLong .Claim.PaidAt}}, {{money .Claim.PaidAmount}} (pemasukan {{.Claim.IncomeCode}}){{end}}
+</div>
+<table>
+  <thead>
+    <tr><th>No</th><th>Tanggal</th><th>Kode</th><th>Deskripsi</th><th>Kategori</th><th class="amount">Nominal</th><th>Bukti</th></tr>
+  </thead>
+  <tbody>
+  {{range $i, $item := .Claim.Items}}
+    <tr>
+      <td>{{increment $i}}</td>
+      <td>{{date $item.Date}}</td>
+      <td>{{$item.RecordCode}}</td>
+      <td>{{$item.Description}}</td>
+      <td>{{$item.Category}}</td>
+      <td class="amount">{{money $item.Amount}}</td>
+      <td>{{range $j, $url := $item.ProofURLs}}<a href="{{$url}}">Bukti {{increment $j}}</a><br>{{else}}<span class="muted">Tidak ada</span>{{end}}</td>
+    </tr>
+  {{end}}
+  </tbody>
+  <tfoot>
+    <tr><td colspan="5">Total ({{len .Claim.Items}} pengeluaran)</td><td class="amount">{{money .Claim.Total}}</td><td></td></tr>
+    {{if .Claim.IsPaid}}<tr><td colspan="5">Selisih penggantian</td><td class="amount">{{money .Claim.Difference}}</td><td></td></tr>{{end}}
+  </tfoot>
+</table>
+<p class="muted">Dibuat {{dateLong .GeneratedAt}} oleh Botopia.</p>
+</body>
+</html>
+`))
+
+// renderClaimDocument membuat dokumen HTML ringkasan klaim beserta tautan bukti setiap pengeluaran
+func renderClaimDocument(claim *finance.ReimbursementClaim) ([]byte, error) {
+	var buf bytes.Buffer
+	err := claimDocumentTemplate.Execute(&buf, struct {
+		Claim       *finance.ReimbursementClaim
+		GeneratedAt time.Time
+	}{claim, time.Now()})
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat dokumen klaim: %v", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,413 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/audit"
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/repository"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+	"github.com/gwenziro/botopia/internal/utils"
+)
+
+// claimCodePattern mengenali kode klaim (r_001) yang disebut di deskripsi atau catatan pemasukan
+var claimCodePattern = regexp.MustCompile(`(?i)\b` + finance.ClaimCodePrefix + `\d{3,}\b`)
+
+// ReimbursementService implementasi pengelolaan klaim reimbursement
+type ReimbursementService struct {
+	reimbursementRepo repository.ReimbursementRepository
+	financeService    service.FinanceService
+	auditService      service.AuditService
+	log               *logger.Logger
+
+	// mutex mencegah pengeluaran yang sama masuk ke dua klaim yang dibuat bersamaan
+	mutex sync.Mutex
+}
+
+// NewReimbursementService membuat instance layanan reimbursement baru
+func NewReimbursementService(
+	reimbursementRepo repository.ReimbursementRepository,
+	financeService service.FinanceService,
+	auditService service.AuditService,
+	log *logger.Logger,
+) *ReimbursementService {
+	return &ReimbursementService{
+		reimbursementRepo: reimbursementRepo,
+		financeService:    financeService,
+		auditService:      auditService,
+		log:               log,
+	}
+}
+
+// Memastikan ReimbursementService mengimplementasikan interface service.ReimbursementService
+var _ service.ReimbursementService = (*ReimbursementService)(nil)
+
+// Flag menandai pengeluaran sebagai reimbursable dengan menambahkan tag #reimburse
+func (s *ReimbursementService) Flag(ctx context.Context, code string) (*finance.FinanceRecord, error) {
+	record, err := s.financeService.GetRecordByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if record.Type != finance.TypeExpense {
+		return nil, fmt.Errorf("hanya pengeluaran yang dapat diklaim, %s adalah pemasukan", record.UniqueCode)
+	}
+	if record.IsReimbursable() {
+		return record, nil
+	}
+
+	// Tag disimpan di catatan agar tetap terbaca sebagai tag seperti yang ditulis pengguna
+	notes := strings.TrimSpace(record.Notes)
+	if notes == "" || notes == "-" {
+		record.Notes = "#" + finance.ReimbursableTag
+	} else {
+		record.Notes = notes + " #" + finance.ReimbursableTag
+	}
+
+	return s.financeService.UpdateRecord(ctx, record)
+}
+
+// Unflag menghapus tanda reimbursable dari pengeluaran yang belum masuk klaim
+func (s *ReimbursementService) Unflag(ctx context.Context, code string) (*finance.FinanceRecord, error) {
+	record, err := s.financeService.GetRecordByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if !record.IsReimbursable() {
+		return nil, fmt.Errorf("transaksi %s tidak ditandai reimburse", record.UniqueCode)
+	}
+
+	claim, err := s.FindClaimByRecord(ctx, record.UniqueCode)
+	if err != nil {
+		return nil, err
+	}
+	if claim != nil {
+		return nil, fmt.Errorf("transaksi %s sudah masuk klaim %s", record.UniqueCode, claim.Code)
+	}
+
+	record.Description = finance.RemoveTag(record.Description, finance.ReimbursableTag)
+	record.Notes = finance.RemoveTag(record.Notes, finance.ReimbursableTag)
+
+	return s.financeService.UpdateRecord(ctx, record)
+}
+
+// ListPending mendapatkan pengeluaran reimbursable yang belum masuk klaim, diurutkan dari yang terbaru
+func (s *ReimbursementService) ListPending(ctx context.Context) ([]*finance.FinanceRecord, error) {
+	records, err := s.financeService.ListRecords(ctx, &finance.RecordFilter{
+		Type: finance.TypeExpense,
+		Tag:  finance.ReimbursableTag,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca transaksi: %v", err)
+	}
+
+	claimed, err := s.claimedRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make([]*finance.FinanceRecord, 0, len(records))
+	for _, record := range records {
+		if claimed[record.UniqueCode] == "" {
+			pending = append(pending, record)
+		}
+	}
+
+	return pending, nil
+}
+
+// CreateClaim mengelompokkan pengeluaran reimbursable menjadi klaim baru; tanpa kode berarti seluruh yang belum diklaim
+func (s *ReimbursementService) CreateClaim(ctx context.Context, title string, codes []string) (*finance.ReimbursementClaim, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if len(codes) == 0 {
+		pending, err := s.ListPending(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(pending) == 0 {
+			return nil, fmt.Errorf("tidak ada pengeluaran #%s yang belum diklaim", finance.ReimbursableTag)
+		}
+		// Item klaim diurutkan dari transaksi paling lama
+		for i := len(pending) - 1; i >= 0; i-- {
+			codes = append(codes, pending[i].UniqueCode)
+		}
+	}
+
+	claimed, err := s.claimedRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claim := &finance.ReimbursementClaim{
+		Title:       strings.TrimSpace(title),
+		Status:      finance.ClaimSubmitted,
+		SubmittedBy: audit.ActorFromContext(ctx).String(),
+		SubmittedAt: time.Now(),
+		Ledger:      finance.LedgerFromContext(ctx),
+	}
+
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		code = strings.ToLower(strings.TrimSpace(code))
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+
+		if other := claimed[code]; other != "" {
+			return nil, fmt.Errorf("transaksi %s sudah masuk klaim %s", code, other)
+		}
+
+		// GetRecordByCode memuat lampiran sehingga seluruh tautan bukti ikut tercatat di klaim
+		record, err := s.financeService.GetRecordByCode(ctx, code)
+		if err != nil {
+			return nil, err
+		}
+		if !record.IsReimbursable() {
+			return nil, fmt.Errorf("transaksi %s belum ditandai #%s", code, finance.ReimbursableTag)
+		}
+
+		claim.Items = append(claim.Items, finance.NewClaimItem(record))
+	}
+
+	if claim.Title == "" {
+		claim.Title = fmt.Sprintf("Reimbursement %s", claimPeriodLabel(claim))
+	}
+	if err := claim.Validate(); err != nil {
+		return nil, err
+	}
+
+	code, err := s.nextCode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	claim.Code = code
+
+	if err := s.reimbursementRepo.Save(ctx, claim); err != nil {
+		return nil, fmt.Errorf("gagal menyimpan klaim: %v", err)
+	}
+
+	s.log.Info("Klaim %s dibuat dengan %d pengeluaran (Rp %s)", claim.Code, len(claim.Items), utils.FormatMoney(claim.Total()))
+	s.recordAudit(ctx, audit.ActionCreateClaim, claim.Code, nil, claim)
+	return claim, nil
+}
+
+// ListClaims mendapatkan seluruh klaim di ledger context, diurutkan dari yang paling lama
+func (s *ReimbursementService) ListClaims(ctx context.Context) ([]*finance.ReimbursementClaim, error) {
+	return s.ledgerClaims(ctx)
+}
+
+// GetClaim mendapatkan klaim berdasarkan kode
+func (s *ReimbursementService) GetClaim(ctx context.Context, code string) (*finance.ReimbursementClaim, error) {
+	claim, err := s.reimbursementRepo.FindByCode(ctx, strings.ToLower(strings.TrimSpace(code)))
+	if err != nil {
+		return nil, err
+	}
+	// Klaim ledger lain diperlakukan seperti tidak ada
+	if claim == nil || claim.Ledger != finance.LedgerFromContext(ctx) {
+		return nil, fmt.Errorf("klaim %s tidak ditemukan", code)
+	}
+	return claim, nil
+}
+
+// Document membuat dokumen ringkasan klaim (HTML) berisi daftar pengeluaran, total, dan tautan bukti
+func (s *ReimbursementService) Document(ctx context.Context, code string) ([]byte, error) {
+	claim, err := s.GetClaim(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	return renderClaimDocument(claim)
+}
+
+// MarkPaid menautkan pemasukan penggantian ke klaim dan menandai seluruh pengeluarannya lunas
+func (s *ReimbursementService) MarkPaid(ctx context.Context, claimCode, incomeCode string) (*finance.ReimbursementClaim, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	claim, err := s.GetClaim(ctx, claimCode)
+	if err != nil {
+		return nil, err
+	}
+
+	income, err := s.financeService.GetRecordByCode(ctx, incomeCode)
+	if err != nil {
+		return nil, err
+	}
+	if income.Type != finance.TypeIncome {
+		return nil, fmt.Errorf("transaksi %s bukan pemasukan", income.UniqueCode)
+	}
+
+	return s.settle(ctx, claim, income)
+}
+
+// LinkIncome mencari klaim yang dilunasi oleh pemasukan baru; nil jika tidak ada yang cocok.
+// Pemasukan yang menyebut kode klaim (r_001) langsung ditautkan ke klaim tersebut, sedangkan
+// pemasukan bertag #reimburse ditautkan ke satu-satunya klaim terbuka dengan total yang sama.
+func (s *ReimbursementService) LinkIncome(ctx context.Context, income *finance.FinanceRecord) (*finance.ReimbursementClaim, error) {
+	if income == nil || income.Type != finance.TypeIncome {
+		return nil, nil
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if code := claimCodePattern.FindString(income.Description + " " + income.Notes); code != "" {
+		claim, err := s.GetClaim(ctx, code)
+		if err != nil {
+			return nil, err
+		}
+		return s.settle(ctx, claim, income)
+	}
+
+	if !income.HasTag(finance.ReimbursableTag) {
+		return nil, nil
+	}
+
+	claims, err := s.ledgerClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var match *finance.ReimbursementClaim
+	for _, claim := range claims {
+		if claim.IsPaid() || !claim.Total().Equal(income.Amount) {
+			continue
+		}
+		if match != nil {
+			// Lebih dari satu klaim dengan total sama, pengguna perlu memilih dengan !klaim lunas
+			return nil, nil
+		}
+		match = claim
+	}
+	if match == nil {
+		return nil, nil
+	}
+
+	return s.settle(ctx, match, income)
+}
+
+// FindClaimByRecord mencari klaim yang memuat pengeluaran atau pemasukan penggantian dengan kode tertentu
+func (s *ReimbursementService) FindClaimByRecord(ctx context.Context, code string) (*finance.ReimbursementClaim, error) {
+	claims, err := s.ledgerClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	code = strings.ToLower(strings.TrimSpace(code))
+	for _, claim := range claims {
+		if claim.IncomeCode == code || claim.HasRecord(code) {
+			return claim, nil
+		}
+	}
+	return nil, nil
+}
+
+// settle menandai klaim lunas dengan pemasukan penggantian, pemanggil harus memegang mutex
+func (s *ReimbursementService) settle(ctx context.Context, claim *finance.ReimbursementClaim, income *finance.FinanceRecord) (*finance.ReimbursementClaim, error) {
+	if claim.IsPaid() {
+		return nil, fmt.Errorf("klaim %s sudah lunas dengan pemasukan %s", claim.Code, claim.IncomeCode)
+	}
+
+	claims, err := s.ledgerClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range claims {
+		if other.IncomeCode == income.UniqueCode {
+			return nil, fmt.Errorf("pemasukan %s sudah ditautkan ke klaim %s", income.UniqueCode, other.Code)
+		}
+	}
+
+	before := *claim
+	claim.Status = finance.ClaimPaid
+	claim.IncomeCode = income.UniqueCode
+	claim.PaidAmount = income.Amount
+	claim.PaidAt = time.Now()
+
+	if err := s.reimbursementRepo.Save(ctx, claim); err != nil {
+		return nil, fmt.Errorf("gagal memperbarui klaim: %v", err)
+	}
+
+	s.log.Info("Klaim %s lunas dengan pemasukan %s", claim.Code, income.UniqueCode)
+	s.recordAudit(ctx, audit.ActionPayClaim, claim.Code, &before, claim)
+	return claim, nil
+}
+
+// claimedRecords memetakan kode pengeluaran ke kode klaim yang memuatnya
+func (s *ReimbursementService) claimedRecords(ctx context.Context) (map[string]string, error) {
+	claims, err := s.ledgerClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claimed := make(map[string]string)
+	for _, claim := range claims {
+		for _, item := range claim.Items {
+			claimed[item.RecordCode] = claim.Code
+		}
+	}
+	return claimed, nil
+}
+
+// ledgerClaims mendapatkan klaim milik ledger context; kode transaksi hanya unik di dalam satu ledger
+func (s *ReimbursementService) ledgerClaims(ctx context.Context) ([]*finance.ReimbursementClaim, error) {
+	claims, err := s.reimbursementRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca klaim: %v", err)
+	}
+
+	ledgerID := finance.LedgerFromContext(ctx)
+	owned := make([]*finance.ReimbursementClaim, 0, len(claims))
+	for _, claim := range claims {
+		if claim.Ledger == ledgerID {
+			owned = append(owned, claim)
+		}
+	}
+	return owned, nil
+}
+
+// nextCode membuat kode klaim berurutan (r_001, r_002, ...)
+func (s *ReimbursementService) nextCode(ctx context.Context) (string, error) {
+	claims, err := s.reimbursementRepo.FindAll(ctx)
+	if err != nil {
+		return "", fmt.Errorf("gagal membaca klaim: %v", err)
+	}
+
+	return fmt.Sprintf("%s%03d", finance.ClaimCodePrefix, len(claims)+1), nil
+}
+
+// claimPeriodLabel membuat label periode klaim dari tanggal pengeluaran pertama dan terakhir
+func claimPeriodLabel(claim *finance.ReimbursementClaim) string {
+	first, last := claim.Items[0].Date, claim.Items[0].Date
+	for _, item := range claim.Items[1:] {
+		if item.Date.Before(first) {
+			first = item.Date
+		}
+		if item.Date.After(last) {
+			last = item.Date
+		}
+	}
+
+	if finance.PeriodOf(first) == finance.PeriodOf(last) {
+		return utils.FormatMonthID(first)
+	}
+	return utils.FormatMonthID(first) + " - " + utils.FormatMonthID(last)
+}
+
+// recordAudit mencatat perubahan ke audit log; kegagalan hanya dicatat di log aplikasi
+func (s *ReimbursementService) recordAudit(ctx context.Context, action audit.Action, target string, before, after interface{}) {
+	if s.auditService == nil {
+		return
+	}
+
+	if err := s.auditService.Record(ctx, action, target, before, after); err != nil {
+		s.log.Error("Gagal mencatat audit %s untuk %s: %v", action, target, err)
+	}
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/audit"
+	"github.com/gwenziro/botopia/internal/domain/repository"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+// spreadsheetRolloverCheckInterval adalah jarak antar pengecekan pergantian periode spreadsheet
+const spreadsheetRolloverCheckInterval = 6 * time.Hour
+
+// SpreadsheetRolloverService implementasi pembuatan spreadsheet partisi baru di awal setiap periode
+type SpreadsheetRolloverService struct {
+	financeRepo  repository.FinanceRepository
+	auditService service.AuditService
+	log          *logger.Logger
+
+	stop  chan struct{}
+	mutex sync.Mutex
+}
+
+// NewSpreadsheetRolloverService membuat instance layanan rollover spreadsheet baru
+func NewSpreadsheetRolloverService(
+	financeRepo repository.FinanceRepository,
+	auditService service.AuditService,
+	log *logger.Logger,
+) *SpreadsheetRolloverService {
+	return &SpreadsheetRolloverService{
+		financeRepo:  financeRepo,
+		auditService: auditService,
+		log:          log,
+	}
+}
+
+// Memastikan SpreadsheetRolloverService mengimplementasikan interface service.SpreadsheetRolloverService
+var _ service.SpreadsheetRolloverService = (*SpreadsheetRolloverService)(nil)
+
+// Start menjalankan pengecekan pergantian periode secara berkala di background
+func (s *SpreadsheetRolloverService) Start() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.stop != nil {
+		return
+	}
+	s.stop = make(chan struct{})
+
+	go s.run(s.stop)
+	s.log.Info("Rollover spreadsheet otomatis aktif, dicek setiap %v", spreadsheetRolloverCheckInterval)
+}
+
+// Stop menghentikan pengecekan berkala
+func (s *SpreadsheetRolloverService) Stop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+}
+
+// run memeriksa periode berjalan saat aplikasi mulai, lalu secara berkala sampai dihentikan
+func (s *SpreadsheetRolloverService) run(stop <-chan struct{}) {
+	ticker := time.NewTicker(spreadsheetRolloverCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		if _, _, err := s.Rollover(ctx); err != nil {
+			// Dicoba lagi pada pengecekan berikutnya; transaksi tetap dapat dicatat karena partisi dibuat saat dibutuhkan
+			s.log.Warn("Gagal menyiapkan spreadsheet periode berjalan: %v", err)
+		}
+		cancel()
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Rollover memastikan spreadsheet untuk periode berjalan tersedia dan mencatat pembuatannya ke audit log
+func (s *SpreadsheetRolloverService) Rollover(ctx context.Context) (string, bool, error) {
+	if !s.financeRepo.IsConfigured() {
+		return "", false, nil
+	}
+
+	now := time.Now()
+	spreadsheetID, created, err := s.financeRepo.EnsurePartition(ctx, now)
+	if err != nil {
+		return "", false, err
+	}
+
+	if created {
+		s.log.Info("Spreadsheet baru untuk tahun %d siap digunakan: %s", now.Year(), spreadsheetID)
+		s.recordAudit(ctx, now.Year(), spreadsheetID)
+	}
+
+	return spreadsheetID, created, nil
+}
+
+// recordAudit mencatat pembuatan spreadsheet ke audit log; kegagalan hanya dicatat di log aplikasi
+func (s *SpreadsheetRolloverService) recordAudit(ctx context.Context, year int, spreadsheetID string) {
+	if s.auditService == nil {
+		return
+	}
+
+	after := map[string]interface{}{"year": year, "spreadsheet_id": spreadsheetID}
+	if err := s.auditService.Record(ctx, audit.ActionRolloverSpreadsheet, spreadsheetID, nil, after); err != nil {
+		s.log.Error("Gagal mencatat audit %s untuk %s: %v", audit.ActionRolloverSpreadsheet, spreadsheetID, err)
+	}
+}
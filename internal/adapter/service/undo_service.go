@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/audit"
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/repository"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+	"github.com/gwenziro/botopia/internal/utils"
+)
+
+// undoContextKey menandai context yang sedang menjalankan pembatalan agar tidak dicatat ulang di jurnal
+type undoContextKey struct{}
+
+// UndoService implementasi layanan pembatalan aksi terakhir
+type UndoService struct {
+	journalRepo    repository.ActionJournalRepository
+	financeService service.FinanceService
+	categoryRules  service.CategoryRuleService
+	window         time.Duration
+	log            *logger.Logger
+}
+
+// NewUndoService membuat instance layanan pembatalan baru
+func NewUndoService(
+	journalRepo repository.ActionJournalRepository,
+	financeService service.FinanceService,
+	categoryRules service.CategoryRuleService,
+	window time.Duration,
+	log *logger.Logger,
+) *UndoService {
+	return &UndoService{
+		journalRepo:    journalRepo,
+		financeService: financeService,
+		categoryRules:  categoryRules,
+		window:         window,
+		log:            log,
+	}
+}
+
+// Memastikan UndoService mengimplementasikan interface service.UndoService
+var _ service.UndoService = (*UndoService)(nil)
+
+// GetWindow mengembalikan batas waktu sebuah aksi masih dapat dibatalkan
+func (s *UndoService) GetWindow() time.Duration {
+	return s.window
+}
+
+// Undo membatalkan aksi terakhir milik nomor pengirim (atau actor dashboard) yang masih dalam batas waktu
+func (s *UndoService) Undo(ctx context.Context, phone string) (*finance.JournalEntry, error) {
+	entry, err := s.journalRepo.FindLatestByPhone(ctx, phone)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca jurnal aksi: %v", err)
+	}
+
+	now := time.Now()
+	if entry == nil || !entry.IsUndoable(now, s.window) {
+		return nil, service.ErrNothingToUndo
+	}
+
+	// Pembatalan dijalankan di ledger tempat aksi dilakukan, bukan ledger chat saat ini
+	ctx = context.WithValue(finance.WithLedger(ctx, entry.Ledger), undoContextKey{}, true)
+
+	switch entry.Kind {
+	case finance.UndoDeleteRecord:
+		err = s.financeService.DeleteRecord(ctx, entry.RecordCode)
+	case finance.UndoRestoreProof:
+		_, err = s.financeService.RestoreTransactionProof(ctx, entry.RecordCode, entry.ProofURL, entry.Attachments)
+	case finance.UndoRestoreConfiguration:
+		err = s.financeService.UpdateConfiguration(ctx, entry.Configuration)
+	case finance.UndoDeleteCategoryRule:
+		err = s.categoryRules.DeleteRule(ctx, entry.RuleID)
+	case finance.UndoRestoreCategoryRule:
+		_, err = s.categoryRules.RestoreRule(ctx, entry.CategoryRule)
+	default:
+		err = fmt.Errorf("jenis aksi %s tidak dikenali", entry.Kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entry.UndoneAt = &now
+	if err := s.journalRepo.Save(ctx, entry); err != nil {
+		s.log.Error("Gagal menandai aksi %s sebagai dibatalkan: %v", entry.ID, err)
+	}
+
+	s.log.Info("Aksi %s (%s) milik %s dibatalkan", entry.ID, entry.Description, phone)
+	return entry, nil
+}
+
+// journalNewRecord mencatat transaksi baru agar dapat dibatalkan dengan menghapusnya
+func (s *FinanceService) journalNewRecord(ctx context.Context, record *finance.FinanceRecord) {
+	label := "pengeluaran"
+	if record.Type == finance.TypeIncome {
+		label = "pemasukan"
+	}
+
+	s.journal(ctx, &finance.JournalEntry{
+		Kind:       finance.UndoDeleteRecord,
+		RecordCode: record.UniqueCode,
+		Description: fmt.Sprintf("%s %s (%s, Rp %s)",
+			label, record.UniqueCode, record.Description, utils.FormatMoney(record.Amount)),
+	})
+}
+
+// journalProofChange mencatat kondisi bukti sebelum diubah agar dapat dikembalikan
+func (s *FinanceService) journalProofChange(ctx context.Context, code, proofURL string, attachments []*finance.Attachment, label string) {
+	// Bukti yang diunggah bersamaan dengan transaksi baru ikut terhapus saat transaksi dibatalkan
+	if phone := journalOwner(ctx); phone != "" && s.journalRepo != nil {
+		latest, err := s.journalRepo.FindLatestByPhone(ctx, phone)
+		if err == nil && latest != nil && latest.Kind == finance.UndoDeleteRecord && latest.RecordCode == code {
+			return
+		}
+	}
+
+	s.journal(ctx, &finance.JournalEntry{
+		Kind:        finance.UndoRestoreProof,
+		RecordCode:  code,
+		ProofURL:    proofURL,
+		Attachments: append([]*finance.Attachment(nil), attachments...),
+		Description: fmt.Sprintf("%s untuk %s", label, code),
+	})
+}
+
+// journalConfigurationChange mencatat konfigurasi sebelum diubah agar dapat dikembalikan
+func (s *FinanceService) journalConfigurationChange(ctx context.Context, previous *finance.Configuration) {
+	if previous == nil {
+		return
+	}
+
+	s.journal(ctx, &finance.JournalEntry{
+		Kind:          finance.UndoRestoreConfiguration,
+		Configuration: previous,
+		Description:   "perubahan konfigurasi keuangan",
+	})
+}
+
+// journal menyimpan entri jurnal aksi pengguna; kegagalan hanya dicatat di log
+func (s *FinanceService) journal(ctx context.Context, entry *finance.JournalEntry) {
+	saveJournalEntry(ctx, s.journalRepo, s.log, entry)
+}
+
+// saveJournalEntry menyimpan entri jurnal untuk aksi pengguna WhatsApp atau dashboard
+func saveJournalEntry(ctx context.Context, journalRepo repository.ActionJournalRepository, log *logger.Logger, entry *finance.JournalEntry) {
+	if journalRepo == nil {
+		return
+	}
+
+	// Aksi yang merupakan bagian dari pembatalan tidak dicatat ulang
+	if undoing, _ := ctx.Value(undoContextKey{}).(bool); undoing {
+		return
+	}
+
+	phone := journalOwner(ctx)
+	if phone == "" {
+		return
+	}
+
+	now := time.Now()
+	entry.ID = strconv.FormatInt(now.UnixNano(), 36)
+	entry.Phone = phone
+	entry.ChatID = audit.ActorFromContext(ctx).ChatID
+	entry.Ledger = finance.LedgerFromContext(ctx)
+	entry.CreatedAt = now
+
+	if err := journalRepo.Save(ctx, entry); err != nil {
+		log.Error("Gagal mencatat jurnal aksi %s: %v", entry.Kind, err)
+	}
+}
+
+// journalOwner menentukan pemilik jurnal dari actor pada context: nomor pengirim WhatsApp,
+// atau actor dashboard seperti "web:admin". Aksi sistem tidak dicatat.
+func journalOwner(ctx context.Context) string {
+	actor := audit.ActorFromContext(ctx)
+	switch {
+	case actor.Channel == audit.ChannelWhatsApp:
+		return actor.Phone
+	case actor.Channel == audit.ChannelWeb && actor.Name != "":
+		return actor.String()
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/audit"
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/money"
+	"github.com/gwenziro/botopia/internal/domain/repository"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/infrastructure/logger"
+)
+
+// ZakatService implementasi laporan zakat dan pajak tahunan
+type ZakatService struct {
+	settingsRepo repository.ZakatSettingsRepository
+	financeRepo  repository.FinanceRepository
+	auditService service.AuditService
+	mutex        sync.Mutex
+	log          *logger.Logger
+}
+
+// NewZakatService membuat instance layanan zakat baru
+func NewZakatService(
+	settingsRepo repository.ZakatSettingsRepository,
+	financeRepo repository.FinanceRepository,
+	auditService service.AuditService,
+	log *logger.Logger,
+) *ZakatService {
+	return &ZakatService{
+		settingsRepo: settingsRepo,
+		financeRepo:  financeRepo,
+		auditService: auditService,
+		log:          log,
+	}
+}
+
+// Memastikan ZakatService mengimplementasikan interface service.ZakatService
+var _ service.ZakatService = (*ZakatService)(nil)
+
+// GetSettings mendapatkan pengaturan zakat ledger context, atau pengaturan bawaan jika belum diatur
+func (s *ZakatService) GetSettings(ctx context.Context) (*finance.ZakatSettings, error) {
+	settings, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		settings = finance.DefaultZakatSettings()
+		settings.Ledger = finance.LedgerFromContext(ctx)
+	}
+	return settings, nil
+}
+
+// SaveSettings menyimpan pengaturan zakat ledger context
+func (s *ZakatService) SaveSettings(ctx context.Context, settings *finance.ZakatSettings) (*finance.ZakatSettings, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	before, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	settings.ExcludedIncomeCategories = cleanNames(settings.ExcludedIncomeCategories)
+	settings.ExcludedStorageMedias = cleanNames(settings.ExcludedStorageMedias)
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+
+	return settings, s.save(ctx, before, settings)
+}
+
+// SetGoldPrice memperbarui harga emas per gram tanpa mengubah aturan lain
+func (s *ZakatService) SetGoldPrice(ctx context.Context, price money.Money) (*finance.ZakatSettings, error) {
+	if !price.IsPositive() {
+		return nil, fmt.Errorf("harga emas harus lebih dari 0")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	before, err := s.settingsRepo.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := finance.DefaultZakatSettings()
+	if before != nil {
+		updated := *before
+		settings = &updated
+	}
+	settings.GoldPricePerGram = price
+
+	return settings, s.save(ctx, before, settings)
+}
+
+// save menyimpan pengaturan ke ledger context dan mencatat audit, pemanggil harus memegang lock
+func (s *ZakatService) save(ctx context.Context, before, settings *finance.ZakatSettings) error {
+	settings.Ledger = finance.LedgerFromContext(ctx)
+	settings.UpdatedAt = time.Now()
+	settings.UpdatedBy = audit.ActorFromContext(ctx).String()
+
+	if err := s.settingsRepo.Save(ctx, settings); err != nil {
+		return fmt.Errorf("gagal menyimpan pengaturan zakat: %v", err)
+	}
+
+	s.log.Info("Pengaturan zakat ledger %s diperbarui oleh %s", finance.LedgerLabel(settings.Ledger), settings.UpdatedBy)
+	s.recordAudit(ctx, audit.ActionUpdateZakatSettings, finance.LedgerLabel(settings.Ledger), before, settings)
+	return nil
+}
+
+// Report menyusun laporan zakat dan pajak untuk satu tahun
+func (s *ZakatService) Report(ctx context.Context, year int) (*finance.ZakatReport, error) {
+	now := time.Now()
+	if year < 2000 || year > now.Year() {
+		return nil, fmt.Errorf("tahun %d tidak valid", year)
+	}
+
+	settings, err := s.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := s.financeRepo.GetAllRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil record keuangan: %v", err)
+	}
+
+	return finance.BuildZakatReport(records, year, settings, now), nil
+}
+
+// recordAudit mencatat perubahan ke audit log; kegagalan hanya dicatat di log aplikasi
+func (s *ZakatService) recordAudit(ctx context.Context, action audit.Action, target string, before, after interface{}) {
+	if s.auditService == nil {
+		return
+	}
+
+	if err := s.auditService.Record(ctx, action, target, before, after); err != nil {
+		s.log.Error("Gagal mencatat audit %s untuk %s: %v", action, target, err)
+	}
+}
+
+// cleanNames merapikan daftar nama dan membuang yang kosong atau ganda
+func cleanNames(names []string) []string {
+	cleaned := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		cleaned = append(cleaned, name)
+	}
+	return cleaned
+}
@@ -13,6 +13,17 @@ import (
 type CommandInitializer struct {
 	cmdRepo        repository.CommandRepository
 	financeService service.FinanceService
+	confirmations  service.ConfirmationService
+	connectionRepo repository.ConnectionRepository
+	approvals      service.ApprovalService
+	formSessions   service.FormSessionService
+	undo           service.UndoService
+	forecasts      service.ForecastService
+	closings       service.PeriodClosingService
+	installments   service.InstallmentService
+	reimbursements service.ReimbursementService
+	zakat          service.ZakatService
+	anomalies      service.AnomalyService
 	log            *logger.Logger
 }
 
@@ -20,10 +31,32 @@ type CommandInitializer struct {
 func NewCommandInitializer(
 	cmdRepo repository.CommandRepository,
 	financeService service.FinanceService,
+	confirmations service.ConfirmationService,
+	connectionRepo repository.ConnectionRepository,
+	approvals service.ApprovalService,
+	formSessions service.FormSessionService,
+	undo service.UndoService,
+	forecasts service.ForecastService,
+	closings service.PeriodClosingService,
+	installments service.InstallmentService,
+	reimbursements service.ReimbursementService,
+	zakat service.ZakatService,
+	anomalies service.AnomalyService,
 ) *CommandInitializer {
 	return &CommandInitializer{
 		cmdRepo:        cmdRepo,
 		financeService: financeService,
+		confirmations:  confirmations,
+		connectionRepo: connectionRepo,
+		approvals:      approvals,
+		formSessions:   formSessions,
+		undo:           undo,
+		forecasts:      forecasts,
+		closings:       closings,
+		installments:   installments,
+		reimbursements: reimbursements,
+		zakat:          zakat,
+		anomalies:      anomalies,
 		log:            logger.New("CommandInitializer", logger.INFO, true),
 	}
 }
@@ -45,19 +78,94 @@ func (c *CommandInitializer) RegisterDefaultCommands() {
 	// 3. Finance commands
 	if c.financeService != nil {
 		// Pengeluaran command
-		expenseCmd := finance.NewAddExpenseCommand(c.financeService)
+		expenseCmd := finance.NewAddExpenseCommand(c.financeService, c.confirmations, c.approvals, c.formSessions, c.anomalies)
 		c.cmdRepo.Register(expenseCmd)
 		c.log.Info("Command '%s' terdaftar", expenseCmd.GetName())
 
 		// Pemasukan command
-		incomeCmd := finance.NewAddIncomeCommand(c.financeService)
+		incomeCmd := finance.NewAddIncomeCommand(c.financeService, c.confirmations, c.formSessions)
 		c.cmdRepo.Register(incomeCmd)
 		c.log.Info("Command '%s' terdaftar", incomeCmd.GetName())
 
 		// Upload bukti transaksi command
-		uploadCmd := finance.NewUploadProofCommand(c.financeService)
+		uploadCmd := finance.NewUploadProofCommand(c.financeService, c.formSessions)
 		c.cmdRepo.Register(uploadCmd)
 		c.log.Info("Command '%s' terdaftar", uploadCmd.GetName())
+
+		// Detail transaksi command
+		detailCmd := finance.NewDetailCommand(c.financeService)
+		c.cmdRepo.Register(detailCmd)
+		c.log.Info("Command '%s' terdaftar", detailCmd.GetName())
+
+		// Kirim bukti transaksi command
+		if c.connectionRepo != nil {
+			proofCmd := finance.NewProofCommand(c.financeService, c.connectionRepo)
+			c.cmdRepo.Register(proofCmd)
+			c.log.Info("Command '%s' terdaftar", proofCmd.GetName())
+		}
+
+		// Persetujuan pengeluaran besar
+		if c.approvals != nil {
+			approveCmd := finance.NewApproveCommand(c.approvals)
+			c.cmdRepo.Register(approveCmd)
+			c.log.Info("Command '%s' terdaftar", approveCmd.GetName())
+
+			rejectCmd := finance.NewRejectCommand(c.approvals)
+			c.cmdRepo.Register(rejectCmd)
+			c.log.Info("Command '%s' terdaftar", rejectCmd.GetName())
+		}
+
+		// Proyeksi arus kas
+		if c.forecasts != nil {
+			forecastCmd := finance.NewForecastCommand(c.forecasts)
+			c.cmdRepo.Register(forecastCmd)
+			c.log.Info("Command '%s' terdaftar", forecastCmd.GetName())
+		}
+
+		// Ringkasan bulanan
+		summaryCmd := finance.NewSummaryCommand(c.financeService, c.closings)
+		c.cmdRepo.Register(summaryCmd)
+		c.log.Info("Command '%s' terdaftar", summaryCmd.GetName())
+
+		// Rekap per anggota untuk ledger bersama
+		memberCmd := finance.NewMemberCommand(c.financeService)
+		c.cmdRepo.Register(memberCmd)
+		c.log.Info("Command '%s' terdaftar", memberCmd.GetName())
+
+		// Ringkasan zakat dan pajak tahunan
+		if c.zakat != nil {
+			zakatCmd := finance.NewZakatCommand(c.zakat)
+			c.cmdRepo.Register(zakatCmd)
+			c.log.Info("Command '%s' terdaftar", zakatCmd.GetName())
+		}
+
+		// Tutup buku bulanan
+		if c.closings != nil {
+			closePeriodCmd := finance.NewClosePeriodCommand(c.closings)
+			c.cmdRepo.Register(closePeriodCmd)
+			c.log.Info("Command '%s' terdaftar", closePeriodCmd.GetName())
+		}
+
+		// Cicilan dan kartu kredit
+		if c.installments != nil {
+			installmentCmd := finance.NewInstallmentCommand(c.installments, c.financeService, c.formSessions)
+			c.cmdRepo.Register(installmentCmd)
+			c.log.Info("Command '%s' terdaftar", installmentCmd.GetName())
+		}
+
+		// Klaim reimbursement pengeluaran kantor
+		if c.reimbursements != nil {
+			claimCmd := finance.NewClaimCommand(c.reimbursements, c.connectionRepo)
+			c.cmdRepo.Register(claimCmd)
+			c.log.Info("Command '%s' terdaftar", claimCmd.GetName())
+		}
+
+		// Pembatalan aksi terakhir
+		if c.undo != nil {
+			undoCmd := finance.NewUndoCommand(c.undo, c.formSessions)
+			c.cmdRepo.Register(undoCmd)
+			c.log.Info("Command '%s' terdaftar", undoCmd.GetName())
+		}
 	} else {
 		c.log.Warn("Finance service tidak tersedia, command finance tidak akan didaftarkan")
 	}
@@ -3,15 +3,19 @@ package di
 import (
 	"database/sql"
 	"fmt"
+	"path/filepath"
+	"time"
 
 	"github.com/gwenziro/botopia/internal/adapter/controller/web"
 	whatsappController "github.com/gwenziro/botopia/internal/adapter/controller/whatsapp"
 	"github.com/gwenziro/botopia/internal/adapter/repository/file"
 	googleRepo "github.com/gwenziro/botopia/internal/adapter/repository/google"
 	"github.com/gwenziro/botopia/internal/adapter/repository/memory"
+	s3Repo "github.com/gwenziro/botopia/internal/adapter/repository/s3"
 	whatsmeowRepo "github.com/gwenziro/botopia/internal/adapter/repository/whatsmeow"
 	adapterService "github.com/gwenziro/botopia/internal/adapter/service"
 	"github.com/gwenziro/botopia/internal/app/command"
+	"github.com/gwenziro/botopia/internal/domain/finance"
 	"github.com/gwenziro/botopia/internal/domain/repository"
 	"github.com/gwenziro/botopia/internal/domain/service"
 	"github.com/gwenziro/botopia/internal/infrastructure/config"
@@ -36,13 +40,23 @@ type Container struct {
 	db     *sql.DB
 
 	// Repositories
-	commandRepository    *memory.CommandRepository
-	connectionRepository *whatsmeowRepo.ConnectionRepository
-	statsRepository      *memory.StatsRepository
-	googleAPIRepository  *googleRepo.GoogleAPIRepository
-	sheetsRepository     *googleRepo.SheetsRepository
-	driveRepository      *googleRepo.DriveRepository
-	contactRepository    repository.ContactRepository // Ubah dari *memory.ContactRepository ke repository.ContactRepository
+	commandRepository      *memory.CommandRepository
+	connectionRepository   *whatsmeowRepo.ConnectionRepository
+	statsRepository        *memory.StatsRepository
+	googleAPIRepository    *googleRepo.GoogleAPIRepository
+	sheetsRepository       repository.FinanceRepository
+	proofStorage           repository.ProofStorageRepository
+	contactRepository      repository.ContactRepository // Ubah dari *memory.ContactRepository ke repository.ContactRepository
+	categoryRuleRepository repository.CategoryRuleRepository
+	recordAuthorRepository repository.RecordAuthorRepository
+	approvalRepository     repository.ApprovalRepository
+	auditRepository        repository.AuditRepository
+	journalRepository      repository.ActionJournalRepository
+	periodClosingRepo      repository.PeriodClosingRepository
+	installmentRepository  repository.InstallmentRepository
+	reimbursementRepo      repository.ReimbursementRepository
+	ledgerRepository       repository.LedgerRepository
+	zakatSettingsRepo      repository.ZakatSettingsRepository
 
 	// Use cases
 	executeCommandUseCase  *execute.ExecuteCommandUseCase
@@ -51,18 +65,50 @@ type Container struct {
 	getStatsUseCase        *stats.GetStatsUseCase
 
 	// Services
-	financeService service.FinanceService
-	contactService service.ContactService
+	financeService      service.FinanceService
+	contactService      service.ContactService
+	categoryRuleService service.CategoryRuleService
+	confirmationService service.ConfirmationService
+	formSessionService  service.FormSessionService
+
+	auditService service.AuditService
+	undoService  service.UndoService
+
+	forecastService      service.ForecastService
+	periodClosingService service.PeriodClosingService
+	installmentService   service.InstallmentService
+	reimbursementService service.ReimbursementService
+	ledgerService        service.LedgerService
+	zakatService         service.ZakatService
+
+	// anomalyService nil jika peringatan pengeluaran tidak biasa dinonaktifkan
+	anomalyService service.AnomalyService
+
+	// approvalService nil jika persetujuan pengeluaran tidak diaktifkan
+	approvalService service.ApprovalService
+
+	// proofReminderService nil jika pengingat bukti tidak diaktifkan
+	proofReminderService service.ProofReminderService
+
+	// spreadsheetRolloverService nil jika partisi spreadsheet dinonaktifkan
+	spreadsheetRolloverService service.SpreadsheetRolloverService
 
 	// Controllers
-	dashboardController  *web.DashboardController
-	qrController         *web.QRController
-	authController       *web.AuthController
-	messageController    *whatsappController.MessageController
-	configController     *web.ConfigController
-	dataMasterController *web.DataMasterController
-	contactController    *web.ContactController
-	commandsController   *web.CommandsController // Tambahkan controller baru
+	dashboardController    *web.DashboardController
+	qrController           *web.QRController
+	authController         *web.AuthController
+	messageController      *whatsappController.MessageController
+	configController       *web.ConfigController
+	dataMasterController   *web.DataMasterController
+	contactController      *web.ContactController
+	commandsController     *web.CommandsController // Tambahkan controller baru
+	categoryRuleController *web.CategoryRuleController
+	auditController        *web.AuditController
+	forecastController     *web.ForecastController
+	zakatController        *web.ZakatController
+	undoController         *web.UndoController
+	financeAPIController   *web.FinanceAPIController
+	proofController        *web.ProofController
 
 	// Command initializer
 	commandInitializer *command.CommandInitializer
@@ -132,22 +178,218 @@ func (c *Container) initRepositories() {
 	// Inisialisasi Google API Repository
 	c.googleAPIRepository = googleRepo.NewGoogleAPIRepository(c.config, c.log)
 
-	// Inisialisasi Google Sheets Repository
-	c.sheetsRepository = googleRepo.NewSheetsRepository(c.googleAPIRepository, c.config, c.log)
+	// Ledger tambahan per kontak atau grup, masing-masing dengan spreadsheet sendiri
+	c.ledgerRepository = file.NewLedgerRepository(c.config.DataDir, c.log)
 
-	// Inisialisasi Google Drive Repository
-	c.driveRepository = googleRepo.NewDriveRepository(c.googleAPIRepository, c.config, c.log)
+	// Inisialisasi Google Sheets Repository; operasi diarahkan ke spreadsheet ledger pengirim
+	c.sheetsRepository = googleRepo.NewLedgerRouter(
+		c.googleAPIRepository,
+		c.config,
+		c.ledgerRepository,
+		googleRepo.NewSheetsRepository(c.googleAPIRepository, c.config, c.log),
+		c.log,
+	)
+
+	// Inisialisasi penyimpanan bukti transaksi sesuai konfigurasi
+	c.initProofStorage()
 
 	// Gunakan file repository untuk kontak (persisten)
 	c.contactRepository = file.NewContactRepository(c.config.DataDir, c.log)
+
+	// Aturan kategori disimpan di file agar persisten
+	c.categoryRuleRepository = file.NewCategoryRuleRepository(c.config.DataDir, c.log)
+
+	// Pembuat transaksi disimpan di file untuk pengingat bukti
+	c.recordAuthorRepository = file.NewRecordAuthorRepository(c.config.DataDir, c.log)
+
+	// Pengajuan persetujuan pengeluaran besar disimpan di file
+	c.approvalRepository = file.NewApprovalRepository(c.config.DataDir, c.log)
+
+	// Audit log perubahan keuangan, append-only dan berantai hash
+	c.auditRepository = file.NewAuditRepository(c.config.DataDir, c.log)
+
+	// Jurnal aksi pengguna untuk pembatalan dengan !batal
+	c.journalRepository = file.NewActionJournalRepository(c.config.DataDir, c.log)
+
+	// Periode bulanan yang sudah tutup buku
+	c.periodClosingRepo = file.NewPeriodClosingRepository(c.config.DataDir, c.log)
+
+	// Rencana cicilan yang dicatat otomatis setiap bulan
+	c.installmentRepository = file.NewInstallmentRepository(c.config.DataDir, c.log)
+
+	// Klaim reimbursement pengeluaran kantor
+	c.reimbursementRepo = file.NewReimbursementRepository(c.config.DataDir, c.log)
+
+	// Pengaturan zakat dan harga emas per ledger
+	c.zakatSettingsRepo = file.NewZakatSettingsRepository(c.config.DataDir, c.log)
+}
+
+// initProofStorage memilih backend penyimpanan bukti transaksi
+func (c *Container) initProofStorage() {
+	switch c.config.ProofStorage.Backend {
+	case "local":
+		c.proofStorage = file.NewLocalProofStorage(c.config, c.log)
+	case "s3":
+		c.proofStorage = s3Repo.NewProofStorage(c.config.ProofStorage, c.log)
+	default:
+		c.proofStorage = googleRepo.NewDriveRepository(c.googleAPIRepository, c.config, c.log)
+	}
+
+	if !c.proofStorage.IsConfigured() {
+		c.log.Warn("Penyimpanan bukti '%s' belum dikonfigurasi dengan lengkap", c.proofStorage.GetName())
+	}
+	c.log.Info("Penyimpanan bukti transaksi menggunakan backend: %s", c.proofStorage.GetName())
 }
 
 // initServices menginisialisasi layanan
 func (c *Container) initServices() {
+	// Inisialisasi category rule service
+	categoryRuleService := adapterService.NewCategoryRuleService(
+		c.categoryRuleRepository,
+		c.sheetsRepository,
+		c.log,
+	)
+	categoryRuleService.SetJournalRepository(c.journalRepository)
+	c.categoryRuleService = categoryRuleService
+
+	// Audit log untuk setiap perubahan data keuangan
+	c.auditService = adapterService.NewAuditService(c.auditRepository, c.log)
+
 	// Inisialisasi finance service
-	c.financeService = adapterService.NewFinanceService(
+	financeService := adapterService.NewFinanceService(
+		c.sheetsRepository,
+		c.proofStorage,
+		c.categoryRuleService,
+		c.log,
+	)
+	financeService.SetDuplicateWindowDays(c.config.DuplicateWindowDays)
+	financeService.SetProofProcessor(adapterService.NewProofProcessor(
+		c.config.ProofStorage.MaxDimension,
+		c.config.ProofStorage.JPEGQuality,
+		c.log,
+	))
+	financeService.SetAuthorRepository(c.recordAuthorRepository)
+	financeService.SetAuditService(c.auditService)
+	financeService.SetJournalRepository(c.journalRepository)
+
+	// Tutup buku bulanan; tanpa admin khusus, approver pengeluaran dianggap admin keuangan
+	financeAdmins := c.config.PeriodClosing.Admins
+	if len(financeAdmins) == 0 {
+		financeAdmins = c.config.Approval.Approvers
+	}
+	c.periodClosingService = adapterService.NewPeriodClosingService(
+		c.periodClosingRepo,
 		c.sheetsRepository,
-		c.driveRepository,
+		c.auditService,
+		financeAdmins,
+		c.log,
+	)
+	financeService.SetPeriodClosingService(c.periodClosingService)
+	c.financeService = financeService
+
+	// Proyeksi arus kas per media penyimpanan
+	c.forecastService = adapterService.NewForecastService(c.sheetsRepository, c.financeService, c.log)
+
+	// Laporan zakat dan pajak tahunan
+	c.zakatService = adapterService.NewZakatService(c.zakatSettingsRepo, c.sheetsRepository, c.auditService, c.log)
+
+	// Peringatan pengeluaran tidak biasa; tanpa pemilik khusus, admin keuangan menerima pemberitahuan
+	if anomalyCfg := c.config.Anomaly; anomalyCfg.Enabled {
+		owners := anomalyCfg.Owners
+		if len(owners) == 0 {
+			owners = financeAdmins
+		}
+		c.anomalyService = adapterService.NewAnomalyService(
+			c.sheetsRepository,
+			c.connectionRepository,
+			&finance.AnomalyPolicy{
+				MedianMultiplier:  anomalyCfg.MedianMultiplier,
+				MinHistory:        anomalyCfg.MinHistory,
+				NewMerchantAmount: anomalyCfg.NewMerchantAmount,
+				RunRateMultiplier: anomalyCfg.RunRateMultiplier,
+				RunRateMonths:     anomalyCfg.RunRateMonths,
+			},
+			owners,
+			c.log,
+		)
+	}
+
+	// Cicilan dan kartu kredit yang dicatat sebagai pengeluaran bulanan
+	c.installmentService = adapterService.NewInstallmentService(
+		c.installmentRepository,
+		c.financeService,
+		c.auditService,
+		c.log,
+	)
+
+	// Klaim reimbursement; pemasukan penggantian ditautkan otomatis ke klaimnya
+	reimbursementService := adapterService.NewReimbursementService(
+		c.reimbursementRepo,
+		c.financeService,
+		c.auditService,
+		c.log,
+	)
+	financeService.SetReimbursementService(reimbursementService)
+	c.reimbursementService = reimbursementService
+
+	// Pembatalan aksi terakhir pengguna
+	c.undoService = adapterService.NewUndoService(
+		c.journalRepository,
+		c.financeService,
+		c.categoryRuleService,
+		time.Duration(c.config.UndoWindowMinutes)*time.Minute,
+		c.log,
+	)
+
+	// Persetujuan pengeluaran besar aktif jika threshold dan approver diisi
+	if approvalCfg := c.config.Approval; approvalCfg.Threshold.IsPositive() {
+		if len(approvalCfg.Approvers) == 0 {
+			c.log.Warn("Threshold persetujuan diisi tapi belum ada approver, persetujuan dinonaktifkan")
+		} else {
+			c.approvalService = adapterService.NewApprovalService(
+				c.approvalRepository,
+				c.financeService,
+				c.connectionRepository,
+				approvalCfg.Threshold,
+				approvalCfg.Approvers,
+				filepath.Join(c.config.DataDir, "approvals"),
+				c.log,
+			)
+		}
+	}
+
+	// Pengingat terjadwal untuk transaksi yang belum memiliki bukti
+	if reminderCfg := c.config.ProofReminder; reminderCfg.Enabled {
+		c.proofReminderService = adapterService.NewProofReminderService(
+			c.sheetsRepository,
+			c.recordAuthorRepository,
+			c.connectionRepository,
+			&finance.ProofReminderPolicy{
+				MinAgeDays: reminderCfg.MinAgeDays,
+				MinAmount:  reminderCfg.MinAmount,
+				Categories: reminderCfg.Categories,
+				Interval:   time.Duration(reminderCfg.IntervalHours) * time.Hour,
+			},
+			c.log,
+		)
+	}
+
+	// Spreadsheet baru setiap pergantian periode agar sheet transaksi tidak terus membesar
+	if c.config.GoogleSheets.RolloverYears > 0 {
+		c.spreadsheetRolloverService = adapterService.NewSpreadsheetRolloverService(
+			c.sheetsRepository,
+			c.auditService,
+			c.log,
+		)
+	}
+
+	// Konfirmasi "ya/tidak" untuk aksi yang perlu persetujuan pengguna
+	c.confirmationService = adapterService.NewConfirmationService(c.log)
+
+	// Sesi formulir interaktif yang menanyakan data satu per satu
+	c.formSessionService = adapterService.NewFormSessionService(
+		c.config.CommandPrefix,
+		time.Duration(c.config.FormSessionTimeoutMinutes)*time.Minute,
 		c.log,
 	)
 
@@ -157,12 +399,19 @@ func (c *Container) initServices() {
 		c.log,
 	)
 
+	// Pemetaan kontak dan grup ke ledger masing-masing
+	c.ledgerService = adapterService.NewLedgerService(
+		c.ledgerRepository,
+		c.contactRepository,
+		c.log,
+	)
+
 	c.log.Info("Services berhasil diinisialisasi")
 }
 
 // initCommandInitializer menginisialisasi command initializer
 func (c *Container) initCommandInitializer() {
-	c.commandInitializer = command.NewCommandInitializer(c.commandRepository, c.financeService)
+	c.commandInitializer = command.NewCommandInitializer(c.commandRepository, c.financeService, c.confirmationService, c.connectionRepository, c.approvalService, c.formSessionService, c.undoService, c.forecastService, c.periodClosingService, c.installmentService, c.reimbursementService, c.zakatService, c.anomalyService)
 	c.commandInitializer.RegisterDefaultCommands()
 	c.log.Info("Command default berhasil didaftarkan. Total: %d command",
 		c.commandInitializer.GetCommandCount())
@@ -204,7 +453,7 @@ func (c *Container) initControllers() {
 	)
 
 	// Tambahkan controller kontak
-	c.contactController = web.NewContactController(c.contactService)
+	c.contactController = web.NewContactController(c.contactService, c.ledgerService)
 
 	// Perbarui message controller dengan contactService
 	c.messageController = whatsappController.NewMessageController(
@@ -212,7 +461,10 @@ func (c *Container) initControllers() {
 		c.connectionRepository,
 		c.statsRepository,
 		c.contactService,
+		c.confirmationService,
+		c.formSessionService,
 	)
+	c.messageController.SetLedgerService(c.ledgerService)
 
 	c.configController = web.NewConfigController(c.config)
 
@@ -222,6 +474,19 @@ func (c *Container) initControllers() {
 	// Tambahkan commands controller
 	c.commandsController = web.NewCommandsController(c.commandRepository)
 
+	// Controller file bukti untuk penyimpanan lokal
+	if localStorage, ok := c.proofStorage.(*file.LocalProofStorage); ok {
+		c.proofController = web.NewProofController(localStorage)
+	}
+
+	// Controller aturan kategori
+	c.categoryRuleController = web.NewCategoryRuleController(c.categoryRuleService, c.financeService, c.config)
+	c.auditController = web.NewAuditController(c.auditService)
+	c.forecastController = web.NewForecastController(c.forecastService)
+	c.zakatController = web.NewZakatController(c.zakatService, c.config)
+	c.undoController = web.NewUndoController(c.undoService, c.config)
+	c.financeAPIController = web.NewFinanceAPIController(c.financeService, c.approvalService, c.config)
+
 	c.log.Info("Controllers berhasil diinisialisasi")
 }
 
@@ -255,9 +520,9 @@ func (c *Container) GetGoogleAPIRepository() repository.GoogleAPIRepository {
 	return c.googleAPIRepository
 }
 
-// GetDriveRepository mengembalikan repository drive
-func (c *Container) GetDriveRepository() repository.DriveRepository {
-	return c.driveRepository
+// GetProofStorage mengembalikan penyimpanan bukti transaksi
+func (c *Container) GetProofStorage() repository.ProofStorageRepository {
+	return c.proofStorage
 }
 
 // GetConnectWhatsAppUseCase mengembalikan use case koneksi WhatsApp
@@ -310,6 +575,61 @@ func (c *Container) GetCommandsController() *web.CommandsController {
 	return c.commandsController
 }
 
+// GetCategoryRuleService mengembalikan service aturan kategori
+func (c *Container) GetCategoryRuleService() service.CategoryRuleService {
+	return c.categoryRuleService
+}
+
+// GetCategoryRuleController mengembalikan controller aturan kategori
+func (c *Container) GetCategoryRuleController() *web.CategoryRuleController {
+	return c.categoryRuleController
+}
+
+// GetAuditController mengembalikan controller audit log
+func (c *Container) GetAuditController() *web.AuditController {
+	return c.auditController
+}
+
+// GetForecastController mengembalikan controller proyeksi arus kas
+func (c *Container) GetForecastController() *web.ForecastController {
+	return c.forecastController
+}
+
+// GetZakatController mengembalikan controller laporan zakat dan pajak tahunan
+func (c *Container) GetZakatController() *web.ZakatController {
+	return c.zakatController
+}
+
+// GetUndoController mengembalikan controller pembatalan aksi terakhir dari dashboard
+func (c *Container) GetUndoController() *web.UndoController {
+	return c.undoController
+}
+
+// GetProofReminderService mengembalikan layanan pengingat bukti (nil jika tidak diaktifkan)
+func (c *Container) GetProofReminderService() service.ProofReminderService {
+	return c.proofReminderService
+}
+
+// GetSpreadsheetRolloverService mengembalikan layanan rollover spreadsheet (nil jika dinonaktifkan)
+func (c *Container) GetSpreadsheetRolloverService() service.SpreadsheetRolloverService {
+	return c.spreadsheetRolloverService
+}
+
+// GetInstallmentService mengembalikan layanan cicilan
+func (c *Container) GetInstallmentService() service.InstallmentService {
+	return c.installmentService
+}
+
+// GetFinanceAPIController mengembalikan controller REST API keuangan
+func (c *Container) GetFinanceAPIController() *web.FinanceAPIController {
+	return c.financeAPIController
+}
+
+// GetProofController mengembalikan controller file bukti (nil jika tidak memakai penyimpanan lokal)
+func (c *Container) GetProofController() *web.ProofController {
+	return c.proofController
+}
+
 // GetConfig mengembalikan konfigurasi
 func (c *Container) GetConfig() *config.Config {
 	return c.config
@@ -0,0 +1,54 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	// ChannelWhatsApp perubahan dari pesan WhatsApp
+	ChannelWhatsApp = "whatsapp"
+
+	// ChannelWeb perubahan dari antarmuka web
+	ChannelWeb = "web"
+
+	// ChannelSystem perubahan dari proses internal (misalnya job terjadwal)
+	ChannelSystem = "system"
+)
+
+// Actor pihak yang melakukan perubahan
+type Actor struct {
+	Channel string `json:"channel"`
+	UserID  string `json:"user_id,omitempty"`
+	Phone   string `json:"phone,omitempty"`
+	ChatID  string `json:"chat_id,omitempty"`
+	Name    string `json:"name,omitempty"`
+}
+
+// String mengembalikan representasi singkat actor untuk tampilan dan pencarian
+func (a Actor) String() string {
+	switch {
+	case a.Phone != "":
+		return fmt.Sprintf("%s:%s", a.Channel, a.Phone)
+	case a.Name != "":
+		return fmt.Sprintf("%s:%s", a.Channel, a.Name)
+	default:
+		return a.Channel
+	}
+}
+
+// actorKey kunci context untuk actor
+type actorKey struct{}
+
+// WithActor menyimpan actor di context agar ikut tercatat pada perubahan berikutnya
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext mengambil actor dari context, default ke sistem
+func ActorFromContext(ctx context.Context) Actor {
+	if actor, ok := ctx.Value(actorKey{}).(Actor); ok {
+		return actor
+	}
+	return Actor{Channel: ChannelSystem}
+}
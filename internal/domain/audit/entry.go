@@ -0,0 +1,155 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// Action jenis perubahan data keuangan yang dicatat di audit log
+type Action string
+
+const (
+	// ActionAddIncome pencatatan pemasukan baru
+	ActionAddIncome Action = "add_income"
+
+	// ActionAddExpense pencatatan pengeluaran baru
+	ActionAddExpense Action = "add_expense"
+
+	// ActionUploadProof penambahan bukti transaksi
+	ActionUploadProof Action = "upload_proof"
+
+	// ActionReplaceProof penggantian seluruh bukti transaksi
+	ActionReplaceProof Action = "replace_proof"
+
+	// ActionUpdateConfiguration perubahan konfigurasi keuangan
+	ActionUpdateConfiguration Action = "update_configuration"
+
+	// ActionUpdateRecord perubahan data transaksi
+	ActionUpdateRecord Action = "update_record"
+
+	// ActionDeleteRecord penghapusan transaksi
+	ActionDeleteRecord Action = "delete_record"
+
+	// ActionRestoreProof pengembalian bukti transaksi melalui pembatalan aksi
+	ActionRestoreProof Action = "restore_proof"
+
+	// ActionClosePeriod tutup buku periode bulanan
+	ActionClosePeriod Action = "close_period"
+
+	// ActionReopenPeriod pembukaan kembali periode yang sudah tutup buku
+	ActionReopenPeriod Action = "reopen_period"
+
+	// ActionPeriodOverride perubahan transaksi pada periode tertutup oleh admin
+	ActionPeriodOverride Action = "period_override"
+
+	// ActionCreateInstallment pembuatan rencana cicilan
+	ActionCreateInstallment Action = "create_installment"
+
+	// ActionPayOffInstallment pelunasan cicilan sebelum tenor berakhir
+	ActionPayOffInstallment Action = "payoff_installment"
+
+	// ActionRolloverSpreadsheet pembuatan spreadsheet baru untuk periode (tahun) berikutnya
+	ActionRolloverSpreadsheet Action = "rollover_spreadsheet"
+
+	// ActionCreateClaim pengajuan klaim reimbursement
+	ActionCreateClaim Action = "create_claim"
+
+	// ActionPayClaim penggantian klaim reimbursement diterima sebagai pemasukan
+	ActionPayClaim Action = "pay_claim"
+
+	// ActionUpdateZakatSettings perubahan pengaturan zakat atau harga emas
+	ActionUpdateZakatSettings Action = "update_zakat_settings"
+)
+
+// GenesisHash adalah hash sebelumnya untuk entri pertama di rantai
+const GenesisHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+// Entry merepresentasikan satu catatan audit yang terhubung ke entri sebelumnya melalui hash
+type Entry struct {
+	Sequence  int64           `json:"sequence"`
+	Timestamp time.Time       `json:"timestamp"`
+	Action    Action          `json:"action"`
+	Target    string          `json:"target,omitempty"`
+	Actor     Actor           `json:"actor"`
+	Before    json.RawMessage `json:"before,omitempty"`
+	After     json.RawMessage `json:"after,omitempty"`
+	PrevHash  string          `json:"prev_hash"`
+	Hash      string          `json:"hash"`
+}
+
+// ComputeHash menghitung hash entri dari seluruh field kecuali Hash itu sendiri
+func (e *Entry) ComputeHash() string {
+	copied := *e
+	copied.Hash = ""
+
+	data, _ := json.Marshal(&copied)
+	sum := sha256.Sum256(append([]byte(e.PrevHash+"\n"), data...))
+	return hex.EncodeToString(sum[:])
+}
+
+// Filter kriteria pencarian entri audit
+type Filter struct {
+	Action Action
+	Target string
+	Actor  string
+	From   time.Time
+	To     time.Time
+}
+
+// Matches memeriksa apakah entri memenuhi filter
+func (f *Filter) Matches(e *Entry) bool {
+	if f.Action != "" && e.Action != f.Action {
+		return false
+	}
+	if f.Target != "" && !strings.Contains(strings.ToLower(e.Target), strings.ToLower(f.Target)) {
+		return false
+	}
+	if f.Actor != "" && !strings.Contains(strings.ToLower(e.Actor.String()), strings.ToLower(f.Actor)) {
+		return false
+	}
+	if !f.From.IsZero() && e.Timestamp.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && e.Timestamp.After(f.To) {
+		return false
+	}
+	return true
+}
+
+// VerifyResult hasil pemeriksaan keutuhan rantai audit
+type VerifyResult struct {
+	Valid    bool   `json:"valid"`
+	Total    int    `json:"total"`
+	BrokenAt int64  `json:"brokenAt,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// Verify memeriksa urutan, tautan hash, dan hash setiap entri
+func Verify(entries []*Entry) *VerifyResult {
+	result := &VerifyResult{Valid: true, Total: len(entries)}
+
+	prevHash := GenesisHash
+	for i, entry := range entries {
+		expectedSeq := int64(i + 1)
+		switch {
+		case entry.Sequence != expectedSeq:
+			result.Reason = "nomor urut tidak berurutan (ada entri yang hilang atau disisipkan)"
+		case entry.PrevHash != prevHash:
+			result.Reason = "hash sebelumnya tidak cocok (rantai terputus)"
+		case entry.ComputeHash() != entry.Hash:
+			result.Reason = "isi entri tidak cocok dengan hash (entri diubah)"
+		default:
+			prevHash = entry.Hash
+			continue
+		}
+
+		result.Valid = false
+		result.BrokenAt = expectedSeq
+		return result
+	}
+
+	return result
+}
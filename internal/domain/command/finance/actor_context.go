@@ -0,0 +1,40 @@
+package finance
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/audit"
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/message"
+)
+
+// actorContext membuat context dengan batas waktu yang membawa pengirim pesan sebagai actor audit log
+func actorContext(msg *message.Message, timeout time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(messageContext(msg), timeout)
+}
+
+// messageContext membuat context yang membawa pengirim pesan sebagai actor audit log
+// dan ledger pengirim agar transaksi tercatat di spreadsheet miliknya
+func messageContext(msg *message.Message) context.Context {
+	ctx := context.Background()
+	if msg == nil {
+		return ctx
+	}
+
+	ctx = finance.WithLedger(ctx, msg.LedgerID)
+	if msg.Sender != nil {
+		actor := audit.Actor{
+			Channel: audit.ChannelWhatsApp,
+			UserID:  msg.Sender.ID,
+			Phone:   msg.Sender.Phone,
+			Name:    msg.Sender.PushName,
+		}
+		if msg.Chat != nil {
+			actor.ChatID = msg.Chat.ID
+		}
+		ctx = audit.WithActor(ctx, actor)
+	}
+
+	return ctx
+}
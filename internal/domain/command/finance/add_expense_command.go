@@ -1,7 +1,6 @@
 package finance
 
 import (
-	"context"
 	"fmt"
 	"os"
 	"regexp"
@@ -20,29 +19,49 @@ import (
 type AddExpenseCommand struct {
 	common.BaseCommand
 	financeService service.FinanceService
+	confirmations  service.ConfirmationService
+	formSessions   service.FormSessionService
+	approvals      service.ApprovalService
+	anomalies      service.AnomalyService
 }
 
 // NewAddExpenseCommand membuat instance command baru
-func NewAddExpenseCommand(financeService service.FinanceService) *AddExpenseCommand {
+func NewAddExpenseCommand(
+	financeService service.FinanceService,
+	confirmations service.ConfirmationService,
+	approvals service.ApprovalService,
+	formSessions service.FormSessionService,
+	anomalies service.AnomalyService,
+) *AddExpenseCommand {
 	cmd := &AddExpenseCommand{
 		financeService: financeService,
+		confirmations:  confirmations,
+		approvals:      approvals,
+		formSessions:   formSessions,
+		anomalies:      anomalies,
 	}
 	cmd.Name = "keluar"
-	cmd.Description = "Mencatat pengeluaran baru. Kirim !keluar untuk mendapatkan form input data."
+	cmd.Description = "Mencatat pengeluaran baru. Kirim !keluar untuk mengisi data langkah demi langkah, !keluar template untuk formulir lengkap, atau !keluar diikuti satu transaksi per baris seperti \"kopi 15rb #Makanan @Gopay\" untuk mencatat beberapa pengeluaran sekaligus."
 	cmd.Category = "Keuangan"
-	cmd.Usage = "!keluar"
+	cmd.Usage = "!keluar [template] | !keluar <deskripsi> <nominal> #Kategori @Sumber (satu per baris)"
 	return cmd
 }
 
 // Execute menjalankan command
 func (c *AddExpenseCommand) Execute(args []string, msg *message.Message) (string, error) {
-	// Jika tidak ada argumen, kirimkan form template
+	// Jika tidak ada argumen, mulai formulir interaktif
 	if len(args) == 0 {
+		return c.startFormSession(msg, nil), nil
+	}
+
+	// Template formulir untuk diisi sekaligus dalam satu pesan
+	if strings.EqualFold(args[0], "template") {
 		return c.getFormTemplate(), nil
 	}
 
 	// Cek apakah pesan adalah form yang diisi
-	if isFilledForm, form := c.parseFormInput(msg.Text); isFilledForm {
+	isFilledForm, form := c.parseFormInput(msg.Text)
+	if isFilledForm {
 		// Periksa apakah ada media yang dilampirkan untuk upload bukti
 		var mediaPath string
 		var err error
@@ -53,20 +72,31 @@ func (c *AddExpenseCommand) Execute(args []string, msg *message.Message) (string
 			if err != nil {
 				return fmt.Sprintf("Gagal mengunduh media: %v", err), nil
 			}
-			// Pastikan file akan dihapus setelah selesai
-			defer func() {
-				if mediaPath != "" {
-					os.Remove(mediaPath)
-				}
-			}()
 		}
 
-		return c.processForm(form, mediaPath)
+		response, pending := c.processForm(form, mediaPath, msg)
+
+		// Hapus file media kecuali masih dibutuhkan oleh konfirmasi yang tertunda
+		if mediaPath != "" && !pending {
+			os.Remove(mediaPath)
+		}
+
+		return response, nil
+	}
+
+	// Form yang belum lengkap dilanjutkan dengan menanyakan field yang kosong
+	if len(form) > 0 {
+		return c.startFormSession(msg, form), nil
+	}
+
+	// Entri cepat satu transaksi per baris, misalnya "kopi 15rb #Makanan @Gopay"
+	if lines := quickEntryLines(msg.Text); len(lines) > 0 {
+		return c.processQuickEntries(lines, msg), nil
 	}
 
 	// Jika bukan form dan ada argument, tampilkan panduan
-	config, _ := c.financeService.GetConfiguration(context.Background())
-	helpMsg := "Untuk mencatat pengeluaran, kirim !keluar (tanpa parameter) untuk mendapatkan formulir."
+	config, _ := c.financeService.GetConfiguration(messageContext(msg))
+	helpMsg := "Untuk mencatat pengeluaran, kirim !keluar (tanpa parameter) untuk mengisi data langkah demi langkah, atau !keluar template untuk formulir lengkap."
 
 	if config != nil {
 		// Tambahkan informasi kategori yang tersedia
@@ -78,6 +108,55 @@ func (c *AddExpenseCommand) Execute(args []string, msg *message.Message) (string
 	return helpMsg, nil
 }
 
+// startFormSession memulai formulir interaktif pengeluaran dengan jawaban awal opsional.
+// Jika sesi tidak tersedia, template formulir dikembalikan seperti biasa.
+func (c *AddExpenseCommand) startFormSession(msg *message.Message, form map[string]string) string {
+	key, ok := formSessionKey(msg)
+	if c.formSessions == nil || !ok {
+		return c.getFormTemplate()
+	}
+
+	config, err := c.financeService.GetConfiguration(messageContext(msg))
+	if err != nil {
+		return fmt.Sprintf("Gagal memuat konfigurasi keuangan: %v", err)
+	}
+
+	values := prefilledValues(form)
+	if msg.HasMedia() && form != nil {
+		if mediaPath, err := msg.DownloadMedia(); err == nil {
+			values["Bukti"] = mediaPath
+		}
+	}
+
+	return c.formSessions.Start(key, &service.FormSession{
+		Title: "INPUT DATA PENGELUARAN",
+		Fields: []service.FormField{
+			dateFormField(),
+			{Key: "Deskripsi", Prompt: "Untuk apa pengeluarannya?", Hint: "Makan siang"},
+			amountFormField(),
+			{Key: "Kategori", Prompt: "Pilih kategori:", Choices: config.ExpenseCategories},
+			{Key: "Metode", Prompt: "Pilih metode pembayaran:", Choices: config.PaymentMethods},
+			{Key: "Sumber", Prompt: "Pilih sumber dana:", Choices: config.StorageMedias},
+			notesFormField(),
+			proofFormField(true),
+		},
+		Values: values,
+		OnComplete: func(values map[string]string, msg *message.Message) string {
+			mediaPath := values["Bukti"]
+			response, pending := c.processForm(values, mediaPath, msg)
+
+			// Hapus file media kecuali masih dibutuhkan oleh konfirmasi yang tertunda
+			if mediaPath != "" && !pending {
+				os.Remove(mediaPath)
+			}
+			return response
+		},
+		OnCancel: func() string {
+			return "❌ Pencatatan pengeluaran dibatalkan."
+		},
+	}, msg)
+}
+
 // getFormTemplate mengembalikan template form pengeluaran
 func (c *AddExpenseCommand) getFormTemplate() string {
 	return `!keluar
@@ -127,88 +206,145 @@ func (c *AddExpenseCommand) parseFormInput(text string) (bool, map[string]string
 		}
 	}
 
-	// Periksa apakah minimal field wajib terisi
-	requiredFields := []string{"Tanggal", "Deskripsi", "Nominal", "Kategori", "Metode", "Sumber"}
+	// Periksa apakah minimal field wajib terisi.
+	// Kategori, Metode, dan Sumber boleh kosong karena dapat dilengkapi oleh aturan kategori.
+	requiredFields := []string{"Tanggal", "Deskripsi", "Nominal"}
+	// Form yang belum lengkap tetap dikembalikan agar dapat dilanjutkan secara interaktif
 	for _, field := range requiredFields {
 		if form[field] == "" {
-			return false, nil
+			return false, form
 		}
 	}
 
 	return true, form
 }
 
-// processForm memproses form yang sudah diisi
-func (c *AddExpenseCommand) processForm(form map[string]string, mediaPath string) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// processForm memproses form yang sudah diisi.
+// Mengembalikan pending=true jika penyimpanan menunggu konfirmasi transaksi ganda.
+func (c *AddExpenseCommand) processForm(form map[string]string, mediaPath string, msg *message.Message) (string, bool) {
+	ctx, cancel := actorContext(msg, 10*time.Second)
 	defer cancel()
 
 	// Parse tanggal
 	date, err := utils.ParseDateWithFormats(form["Tanggal"])
 	if err != nil {
-		return fmt.Sprintf("Format tanggal tidak valid: %v. Gunakan format seperti '15 Mei 2025'", err), nil
+		return fmt.Sprintf("Format tanggal tidak valid: %v. Gunakan format seperti '15 Mei 2025'", err), false
 	}
 
 	// Parse nominal
 	amount, err := utils.ParseMoney(form["Nominal"])
 	if err != nil {
-		return fmt.Sprintf("Nominal tidak valid: %v. Gunakan angka saja, contoh: 50000", err), nil
+		return fmt.Sprintf("Nominal tidak valid: %v. Gunakan angka saja, contoh: 50000", err), false
 	}
 
-	description := form["Deskripsi"]
-	category := form["Kategori"]
-	paymentMethod := form["Metode"]
-	storageMedia := form["Sumber"]
-
 	// Tangani catatan kosong dengan satu strip
 	notes := form["Catatan"]
 	if notes == "" || strings.Contains(notes, "─") {
 		notes = "-"
 	}
 
+	draft := &finance.FinanceRecord{
+		Type:          finance.TypeExpense,
+		Date:          date,
+		Description:   form["Deskripsi"],
+		Amount:        amount,
+		Category:      form["Kategori"],
+		PaymentMethod: form["Metode"],
+		StorageMedia:  form["Sumber"],
+		Notes:         notes,
+	}
+
+	// Lengkapi field yang kosong menggunakan aturan kategori
+	_ = c.financeService.ApplyCategoryRules(ctx, draft)
+
 	// Validasi parameter terhadap konfigurasi
-	if err := c.financeService.ValidateAddExpenseParams(ctx, category, paymentMethod, storageMedia); err != nil {
-		return fmt.Sprintf("Validasi gagal: %v", err), nil
+	if err := c.financeService.ValidateAddExpenseParams(ctx, draft.Category, draft.PaymentMethod, draft.StorageMedia); err != nil {
+		return fmt.Sprintf("Validasi gagal: %v", err), false
 	}
 
-	var record *finance.FinanceRecord
+	// Minta konfirmasi jika ada transaksi serupa yang sudah tercatat
+	duplicates, err := c.financeService.FindDuplicateRecords(ctx, draft)
+	if err == nil && len(duplicates) > 0 && c.confirmations != nil && msg != nil && msg.Sender != nil && msg.Chat != nil {
+		c.confirmations.Ask(service.ConfirmationKey(msg.Chat.ID, msg.Sender.Phone), &service.PendingConfirmation{
+			OnConfirm: func() string {
+				response := c.saveRecord(draft, mediaPath, msg)
+				removeMedia(mediaPath)
+				return response
+			},
+			OnCancel: func() string {
+				removeMedia(mediaPath)
+				return "❌ Pencatatan pengeluaran dibatalkan."
+			},
+			OnExpire: func() {
+				removeMedia(mediaPath)
+			},
+		})
+		return formatDuplicateWarning(draft, duplicates), true
+	}
+
+	return c.saveRecord(draft, mediaPath, msg), false
+}
 
-	// Jika ada media path, unggah terlebih dahulu sebelum menyimpan record
-	if mediaPath != "" {
-		// Buat record dengan URL kosong terlebih dahulu
-		tmpRecord, err := c.financeService.AddExpenseWithDate(
-			ctx, date, description, amount, category,
-			paymentMethod, storageMedia, notes, "",
-		)
+// saveRecord menyimpan pengeluaran beserta bukti transaksi jika ada
+func (c *AddExpenseCommand) saveRecord(draft *finance.FinanceRecord, mediaPath string, msg *message.Message) string {
+	ctx, cancel := actorContext(msg, 10*time.Second)
+	defer cancel()
 
+	// Pengeluaran besar menunggu persetujuan approver sebelum dicatat
+	if c.approvals != nil && msg != nil && msg.Sender != nil && c.approvals.RequiresApproval(draft, msg.Sender.Phone) {
+		request, err := c.approvals.Submit(ctx, draft, mediaPath, newRecordAuthor("", msg))
 		if err != nil {
-			return fmt.Sprintf("Gagal mencatat pengeluaran: %v", err), nil
+			return fmt.Sprintf("Gagal mengajukan persetujuan pengeluaran: %v", err)
 		}
+		return formatPendingApproval(request, c.approvals.GetThreshold())
+	}
 
-		// Unggah bukti menggunakan kode transaksi yang dihasilkan
-		uploadCtx, uploadCancel := context.WithTimeout(context.Background(), 60*time.Second)
-		defer uploadCancel()
+	// Simpan record dengan URL bukti kosong terlebih dahulu
+	record, err := c.financeService.AddExpenseWithDate(
+		ctx, draft.Date, draft.Description, draft.Amount, draft.Category,
+		draft.PaymentMethod, draft.StorageMedia, draft.Notes, "",
+	)
+	if err != nil {
+		return fmt.Sprintf("Gagal mencatat pengeluaran: %v", err)
+	}
 
-		record, err = c.financeService.UploadTransactionProof(uploadCtx, tmpRecord.UniqueCode, mediaPath)
-		if err != nil {
-			// Transaksi sudah tersimpan tapi gagal upload bukti
-			proofStatus := fmt.Sprintf("\n\n⚠️ Gagal mengunggah bukti: %v", err)
-			return c.formatSuccessResponse(tmpRecord, false) + proofStatus, nil
-		}
-	} else {
-		// Tanpa media, langsung simpan record
-		record, err = c.financeService.AddExpenseWithDate(
-			ctx, date, description, amount, category,
-			paymentMethod, storageMedia, notes, "",
-		)
+	saveRecordAuthor(ctx, c.financeService, record.UniqueCode, msg)
+	alert := c.anomalyAlert(record, msg)
 
-		if err != nil {
-			return fmt.Sprintf("Gagal mencatat pengeluaran: %v", err), nil
-		}
+	if mediaPath == "" {
+		return c.formatSuccessResponse(record, false) + alert
+	}
+
+	// Unggah bukti menggunakan kode transaksi yang dihasilkan
+	uploadCtx, uploadCancel := actorContext(msg, 60*time.Second)
+	defer uploadCancel()
+
+	updated, err := c.financeService.UploadTransactionProof(uploadCtx, record.UniqueCode, mediaPath)
+	if err != nil {
+		// Transaksi sudah tersimpan tapi gagal upload bukti
+		proofStatus := fmt.Sprintf("\n\n⚠️ Gagal mengunggah bukti: %v", err)
+		return c.formatSuccessResponse(record, false) + proofStatus + alert
+	}
+
+	return c.formatSuccessResponse(updated, true) + alert
+}
+
+// anomalyAlert memeriksa pengeluaran yang baru tersimpan dan memformat peringatan jika tidak biasa.
+// Kegagalan pemeriksaan tidak menggagalkan pencatatan.
+func (c *AddExpenseCommand) anomalyAlert(record *finance.FinanceRecord, msg *message.Message) string {
+	if c.anomalies == nil {
+		return ""
+	}
+
+	ctx, cancel := actorContext(msg, 30*time.Second)
+	defer cancel()
+
+	anomalies, err := c.anomalies.Inspect(ctx, record)
+	if err != nil || len(anomalies) == 0 {
+		return ""
 	}
 
-	// Format response sukses
-	return c.formatSuccessResponse(record, mediaPath != ""), nil
+	return "\n\n" + formatAnomalyAlert(record, anomalies)
 }
 
 // formatSuccessResponse memformat pesan sukses
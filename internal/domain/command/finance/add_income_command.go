@@ -1,7 +1,6 @@
 package finance
 
 import (
-	"context"
 	"fmt"
 	"os"
 	"regexp"
@@ -20,29 +19,43 @@ import (
 type AddIncomeCommand struct {
 	common.BaseCommand
 	financeService service.FinanceService
+	confirmations  service.ConfirmationService
+	formSessions   service.FormSessionService
 }
 
 // NewAddIncomeCommand membuat instance command baru
-func NewAddIncomeCommand(financeService service.FinanceService) *AddIncomeCommand {
+func NewAddIncomeCommand(
+	financeService service.FinanceService,
+	confirmations service.ConfirmationService,
+	formSessions service.FormSessionService,
+) *AddIncomeCommand {
 	cmd := &AddIncomeCommand{
 		financeService: financeService,
+		confirmations:  confirmations,
+		formSessions:   formSessions,
 	}
 	cmd.Name = "masuk"
-	cmd.Description = "Mencatat pemasukan baru. Kirim !masuk untuk mendapatkan form input data."
+	cmd.Description = "Mencatat pemasukan baru. Kirim !masuk untuk mengisi data langkah demi langkah, atau !masuk template untuk formulir lengkap."
 	cmd.Category = "Keuangan"
-	cmd.Usage = "!masuk"
+	cmd.Usage = "!masuk [template]"
 	return cmd
 }
 
 // Execute menjalankan command
 func (c *AddIncomeCommand) Execute(args []string, msg *message.Message) (string, error) {
-	// Jika tidak ada argumen, kirimkan form template
+	// Jika tidak ada argumen, mulai formulir interaktif
 	if len(args) == 0 {
+		return c.startFormSession(msg, nil), nil
+	}
+
+	// Template formulir untuk diisi sekaligus dalam satu pesan
+	if strings.EqualFold(args[0], "template") {
 		return c.getFormTemplate(), nil
 	}
 
 	// Cek apakah pesan adalah form yang diisi
-	if isFilledForm, form := c.parseFormInput(msg.Text); isFilledForm {
+	isFilledForm, form := c.parseFormInput(msg.Text)
+	if isFilledForm {
 		// Periksa apakah ada media yang dilampirkan untuk upload bukti
 		var mediaPath string
 		var err error
@@ -53,20 +66,26 @@ func (c *AddIncomeCommand) Execute(args []string, msg *message.Message) (string,
 			if err != nil {
 				return fmt.Sprintf("Gagal mengunduh media: %v", err), nil
 			}
-			// Pastikan file akan dihapus setelah selesai
-			defer func() {
-				if mediaPath != "" {
-					os.Remove(mediaPath)
-				}
-			}()
 		}
 
-		return c.processForm(form, mediaPath)
+		response, pending := c.processForm(form, mediaPath, msg)
+
+		// Hapus file media kecuali masih dibutuhkan oleh konfirmasi yang tertunda
+		if mediaPath != "" && !pending {
+			os.Remove(mediaPath)
+		}
+
+		return response, nil
+	}
+
+	// Form yang belum lengkap dilanjutkan dengan menanyakan field yang kosong
+	if len(form) > 0 {
+		return c.startFormSession(msg, form), nil
 	}
 
 	// Jika bukan form dan ada argument, tampilkan panduan
-	config, _ := c.financeService.GetConfiguration(context.Background())
-	helpMsg := "Untuk mencatat pemasukan, kirim !masuk (tanpa parameter) untuk mendapatkan formulir."
+	config, _ := c.financeService.GetConfiguration(messageContext(msg))
+	helpMsg := "Untuk mencatat pemasukan, kirim !masuk (tanpa parameter) untuk mengisi data langkah demi langkah, atau !masuk template untuk formulir lengkap."
 
 	if config != nil {
 		// Tambahkan informasi kategori yang tersedia
@@ -77,6 +96,54 @@ func (c *AddIncomeCommand) Execute(args []string, msg *message.Message) (string,
 	return helpMsg, nil
 }
 
+// startFormSession memulai formulir interaktif pemasukan dengan jawaban awal opsional.
+// Jika sesi tidak tersedia, template formulir dikembalikan seperti biasa.
+func (c *AddIncomeCommand) startFormSession(msg *message.Message, form map[string]string) string {
+	key, ok := formSessionKey(msg)
+	if c.formSessions == nil || !ok {
+		return c.getFormTemplate()
+	}
+
+	config, err := c.financeService.GetConfiguration(messageContext(msg))
+	if err != nil {
+		return fmt.Sprintf("Gagal memuat konfigurasi keuangan: %v", err)
+	}
+
+	values := prefilledValues(form)
+	if msg.HasMedia() && form != nil {
+		if mediaPath, err := msg.DownloadMedia(); err == nil {
+			values["Bukti"] = mediaPath
+		}
+	}
+
+	return c.formSessions.Start(key, &service.FormSession{
+		Title: "INPUT DATA PEMASUKAN",
+		Fields: []service.FormField{
+			dateFormField(),
+			{Key: "Deskripsi", Prompt: "Dari mana pemasukannya?", Hint: "Gaji bulanan"},
+			amountFormField(),
+			{Key: "Kategori", Prompt: "Pilih kategori:", Choices: config.IncomeCategories},
+			{Key: "Media", Prompt: "Pilih media penyimpanan:", Choices: config.StorageMedias},
+			notesFormField(),
+			proofFormField(true),
+		},
+		Values: values,
+		OnComplete: func(values map[string]string, msg *message.Message) string {
+			mediaPath := values["Bukti"]
+			response, pending := c.processForm(values, mediaPath, msg)
+
+			// Hapus file media kecuali masih dibutuhkan oleh konfirmasi yang tertunda
+			if mediaPath != "" && !pending {
+				os.Remove(mediaPath)
+			}
+			return response
+		},
+		OnCancel: func() string {
+			return "❌ Pencatatan pemasukan dibatalkan."
+		},
+	}, msg)
+}
+
 // getFormTemplate mengembalikan template form pemasukan
 func (c *AddIncomeCommand) getFormTemplate() string {
 	return `!masuk
@@ -125,88 +192,116 @@ func (c *AddIncomeCommand) parseFormInput(text string) (bool, map[string]string)
 		}
 	}
 
-	// Periksa apakah minimal field wajib terisi
-	requiredFields := []string{"Tanggal", "Deskripsi", "Nominal", "Kategori", "Media"}
+	// Periksa apakah minimal field wajib terisi.
+	// Kategori dan Media boleh kosong karena dapat dilengkapi oleh aturan kategori.
+	requiredFields := []string{"Tanggal", "Deskripsi", "Nominal"}
+	// Form yang belum lengkap tetap dikembalikan agar dapat dilanjutkan secara interaktif
 	for _, field := range requiredFields {
 		if form[field] == "" {
-			return false, nil
+			return false, form
 		}
 	}
 
 	return true, form
 }
 
-// processForm memproses form yang sudah diisi
-func (c *AddIncomeCommand) processForm(form map[string]string, mediaPath string) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// processForm memproses form yang sudah diisi.
+// Mengembalikan pending=true jika penyimpanan menunggu konfirmasi transaksi ganda.
+func (c *AddIncomeCommand) processForm(form map[string]string, mediaPath string, msg *message.Message) (string, bool) {
+	ctx, cancel := actorContext(msg, 10*time.Second)
 	defer cancel()
 
 	// Parse tanggal
 	date, err := utils.ParseDateWithFormats(form["Tanggal"])
 	if err != nil {
-		return fmt.Sprintf("Format tanggal tidak valid: %v. Gunakan format seperti '15 Mei 2025'", err), nil
+		return fmt.Sprintf("Format tanggal tidak valid: %v. Gunakan format seperti '15 Mei 2025'", err), false
 	}
 
 	// Parse nominal
 	amount, err := utils.ParseMoney(form["Nominal"])
 	if err != nil {
-		return fmt.Sprintf("Nominal tidak valid: %v. Gunakan angka saja, contoh: 50000", err), nil
+		return fmt.Sprintf("Nominal tidak valid: %v. Gunakan angka saja, contoh: 50000", err), false
 	}
 
-	description := form["Deskripsi"]
-	category := form["Kategori"]
-	storageMedia := form["Media"]
-
 	// Tangani catatan kosong dengan satu strip
 	notes := form["Catatan"]
 	if notes == "" || strings.Contains(notes, "─") {
 		notes = "-"
 	}
 
+	draft := &finance.FinanceRecord{
+		Type:         finance.TypeIncome,
+		Date:         date,
+		Description:  form["Deskripsi"],
+		Amount:       amount,
+		Category:     form["Kategori"],
+		StorageMedia: form["Media"],
+		Notes:        notes,
+	}
+
+	// Lengkapi field yang kosong menggunakan aturan kategori
+	_ = c.financeService.ApplyCategoryRules(ctx, draft)
+
 	// Validasi parameter terhadap konfigurasi
-	if err := c.financeService.ValidateAddIncomeParams(ctx, category, storageMedia); err != nil {
-		return fmt.Sprintf("Validasi gagal: %v", err), nil
+	if err := c.financeService.ValidateAddIncomeParams(ctx, draft.Category, draft.StorageMedia); err != nil {
+		return fmt.Sprintf("Validasi gagal: %v", err), false
 	}
 
-	var proofURL string = ""
-	var record *finance.FinanceRecord
+	// Minta konfirmasi jika ada transaksi serupa yang sudah tercatat
+	duplicates, err := c.financeService.FindDuplicateRecords(ctx, draft)
+	if err == nil && len(duplicates) > 0 && c.confirmations != nil && msg != nil && msg.Sender != nil && msg.Chat != nil {
+		c.confirmations.Ask(service.ConfirmationKey(msg.Chat.ID, msg.Sender.Phone), &service.PendingConfirmation{
+			OnConfirm: func() string {
+				response := c.saveRecord(draft, mediaPath, msg)
+				removeMedia(mediaPath)
+				return response
+			},
+			OnCancel: func() string {
+				removeMedia(mediaPath)
+				return "❌ Pencatatan pemasukan dibatalkan."
+			},
+			OnExpire: func() {
+				removeMedia(mediaPath)
+			},
+		})
+		return formatDuplicateWarning(draft, duplicates), true
+	}
 
-	// Jika ada media path, unggah terlebih dahulu sebelum menyimpan record
-	if mediaPath != "" {
-		// Buat record dengan URL kosong terlebih dahulu
-		tmpRecord, err := c.financeService.AddIncomeWithDate(
-			ctx, date, description, amount, category,
-			storageMedia, notes, proofURL,
-		)
+	return c.saveRecord(draft, mediaPath, msg), false
+}
 
-		if err != nil {
-			return fmt.Sprintf("Gagal mencatat pemasukan: %v", err), nil
-		}
+// saveRecord menyimpan pemasukan beserta bukti transaksi jika ada
+func (c *AddIncomeCommand) saveRecord(draft *finance.FinanceRecord, mediaPath string, msg *message.Message) string {
+	ctx, cancel := actorContext(msg, 10*time.Second)
+	defer cancel()
 
-		// Unggah bukti menggunakan kode transaksi yang dihasilkan
-		uploadCtx, uploadCancel := context.WithTimeout(context.Background(), 60*time.Second)
-		defer uploadCancel()
+	// Simpan record dengan URL bukti kosong terlebih dahulu
+	record, err := c.financeService.AddIncomeWithDate(
+		ctx, draft.Date, draft.Description, draft.Amount, draft.Category,
+		draft.StorageMedia, draft.Notes, "",
+	)
+	if err != nil {
+		return fmt.Sprintf("Gagal mencatat pemasukan: %v", err)
+	}
 
-		record, err = c.financeService.UploadTransactionProof(uploadCtx, tmpRecord.UniqueCode, mediaPath)
-		if err != nil {
-			// Transaksi sudah tersimpan tapi gagal upload bukti
-			proofStatus := fmt.Sprintf("\n\n⚠️ Gagal mengunggah bukti: %v", err)
-			return c.formatSuccessResponse(tmpRecord, false) + proofStatus, nil
-		}
-	} else {
-		// Tanpa media, langsung simpan record
-		record, err = c.financeService.AddIncomeWithDate(
-			ctx, date, description, amount, category,
-			storageMedia, notes, "",
-		)
-
-		if err != nil {
-			return fmt.Sprintf("Gagal mencatat pemasukan: %v", err), nil
-		}
+	saveRecordAuthor(ctx, c.financeService, record.UniqueCode, msg)
+
+	if mediaPath == "" {
+		return c.formatSuccessResponse(record, false)
+	}
+
+	// Unggah bukti menggunakan kode transaksi yang dihasilkan
+	uploadCtx, uploadCancel := actorContext(msg, 60*time.Second)
+	defer uploadCancel()
+
+	updated, err := c.financeService.UploadTransactionProof(uploadCtx, record.UniqueCode, mediaPath)
+	if err != nil {
+		// Transaksi sudah tersimpan tapi gagal upload bukti
+		proofStatus := fmt.Sprintf("\n\n⚠️ Gagal mengunggah bukti: %v", err)
+		return c.formatSuccessResponse(record, false) + proofStatus
 	}
 
-	// Format response sukses dengan urutan parameter yang benar
-	return c.formatSuccessResponse(record, mediaPath != ""), nil
+	return c.formatSuccessResponse(updated, true)
 }
 
 // formatSuccessResponse memformat pesan sukses
@@ -0,0 +1,19 @@
+package finance
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+)
+
+// formatAnomalyAlert memformat peringatan pengeluaran tidak biasa yang ditambahkan ke balasan pencatatan
+func formatAnomalyAlert(record *finance.FinanceRecord, anomalies []*finance.SpendingAnomaly) string {
+	var sb strings.Builder
+	sb.WriteString("🚨 PENGELUARAN TIDAK BIASA\n")
+	for _, anomaly := range anomalies {
+		sb.WriteString(fmt.Sprintf("• %s\n", anomaly.Describe(record)))
+	}
+	sb.WriteString(fmt.Sprintf("Jika keliru, ketik !batal atau ubah transaksi %s.", record.UniqueCode))
+	return sb.String()
+}
@@ -0,0 +1,152 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/command/common"
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/message"
+	"github.com/gwenziro/botopia/internal/domain/money"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/utils"
+)
+
+// ApproveCommand implementasi command untuk menyetujui pengeluaran besar
+type ApproveCommand struct {
+	common.BaseCommand
+	approvals service.ApprovalService
+}
+
+// NewApproveCommand membuat instance command baru
+func NewApproveCommand(approvals service.ApprovalService) *ApproveCommand {
+	cmd := &ApproveCommand{
+		approvals: approvals,
+	}
+	cmd.Name = "setuju"
+	cmd.Description = "Menyetujui pengeluaran besar yang menunggu persetujuan. Kirim !setuju tanpa kode untuk melihat daftar pengajuan."
+	cmd.Category = "Keuangan"
+	cmd.Usage = "!setuju <kode_persetujuan>"
+	return cmd
+}
+
+// Execute menjalankan command
+func (c *ApproveCommand) Execute(args []string, msg *message.Message) (string, error) {
+	if !c.approvals.IsApprover(msg.Sender.Phone) {
+		return "❌ Hanya approver yang dapat menyetujui pengeluaran.", nil
+	}
+
+	ctx, cancel := actorContext(msg, 60*time.Second)
+	defer cancel()
+
+	// Tanpa kode, tampilkan daftar pengajuan yang menunggu
+	if len(args) == 0 {
+		return c.listPending(ctx)
+	}
+
+	code := strings.ToLower(strings.TrimSpace(args[0]))
+	request, err := c.approvals.Approve(ctx, code, msg.Sender.Phone)
+	if err != nil {
+		return fmt.Sprintf("❌ Gagal menyetujui pengajuan: %v", err), nil
+	}
+
+	return fmt.Sprintf(`✅ Pengajuan %s disetujui.
+📖 %s - Rp %s
+ℹ Kode Transaksi: %s`,
+		request.Code, request.Record.Description, utils.FormatMoney(request.Record.Amount), request.RecordCode), nil
+}
+
+// listPending memformat daftar pengajuan yang masih menunggu keputusan
+func (c *ApproveCommand) listPending(ctx context.Context) (string, error) {
+	pending, err := c.approvals.ListPending(ctx)
+	if err != nil {
+		return fmt.Sprintf("❌ Gagal mengambil daftar pengajuan: %v", err), nil
+	}
+	if len(pending) == 0 {
+		return "ℹ Tidak ada pengeluaran yang menunggu persetujuan.", nil
+	}
+
+	return fmt.Sprintf(`────────────────────────
+⏳ MENUNGGU PERSETUJUAN ⏳
+────────────────────────
+%s
+────────────────────────
+Balas !setuju <kode> atau !tolak <kode> <alasan>.
+────────────────────────`, formatApprovalList(pending)), nil
+}
+
+// RejectCommand implementasi command untuk menolak pengeluaran besar
+type RejectCommand struct {
+	common.BaseCommand
+	approvals service.ApprovalService
+}
+
+// NewRejectCommand membuat instance command baru
+func NewRejectCommand(approvals service.ApprovalService) *RejectCommand {
+	cmd := &RejectCommand{
+		approvals: approvals,
+	}
+	cmd.Name = "tolak"
+	cmd.Description = "Menolak pengeluaran besar yang menunggu persetujuan beserta alasannya."
+	cmd.Category = "Keuangan"
+	cmd.Usage = "!tolak <kode_persetujuan> <alasan>"
+	return cmd
+}
+
+// Execute menjalankan command
+func (c *RejectCommand) Execute(args []string, msg *message.Message) (string, error) {
+	if !c.approvals.IsApprover(msg.Sender.Phone) {
+		return "❌ Hanya approver yang dapat menolak pengeluaran.", nil
+	}
+
+	if len(args) < 2 {
+		return "❌ Mohon sertakan kode persetujuan dan alasan. Contoh: !tolak p_jan25_001 anggaran bulan ini habis", nil
+	}
+
+	ctx, cancel := actorContext(msg, 30*time.Second)
+	defer cancel()
+
+	code := strings.ToLower(strings.TrimSpace(args[0]))
+	reason := strings.Join(args[1:], " ")
+
+	request, err := c.approvals.Reject(ctx, code, msg.Sender.Phone, reason)
+	if err != nil {
+		return fmt.Sprintf("❌ Gagal menolak pengajuan: %v", err), nil
+	}
+
+	return fmt.Sprintf("❌ Pengajuan %s (%s) ditolak.\n📝 Alasan: %s", request.Code, request.Record.Description, reason), nil
+}
+
+// formatApprovalList memformat daftar pengajuan persetujuan
+func formatApprovalList(requests []*finance.ApprovalRequest) string {
+	lines := make([]string, 0, len(requests))
+	for i, request := range requests {
+		requester := "-"
+		if request.Requester != nil {
+			requester = request.Requester.Phone
+		}
+		lines = append(lines, fmt.Sprintf("%d. %s\n   📖 %s | 💰 Rp %s\n   👤 %s",
+			i+1, request.Code, request.Record.Description, utils.FormatMoney(request.Record.Amount), requester))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// formatPendingApproval memformat balasan untuk pengaju bahwa pengeluaran menunggu persetujuan
+func formatPendingApproval(request *finance.ApprovalRequest, threshold money.Money) string {
+	return fmt.Sprintf(`────────────────────────
+⏳ PENGELUARAN MENUNGGU PERSETUJUAN ⏳
+────────────────────────
+Pengeluaran "%s" sebesar Rp %s mencapai batas Rp %s,
+jadi belum dicatat sampai disetujui approver.
+
+📌 Kode Persetujuan: %s
+Kamu akan diberi tahu setelah pengajuan disetujui atau ditolak.
+────────────────────────`,
+		request.Record.Description,
+		utils.FormatMoney(request.Record.Amount),
+		utils.FormatMoney(threshold),
+		request.Code)
+}
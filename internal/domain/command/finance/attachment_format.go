@@ -0,0 +1,30 @@
+package finance
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+)
+
+// formatAttachmentList memformat daftar lampiran bukti transaksi
+func formatAttachmentList(attachments []*finance.Attachment) string {
+	if len(attachments) == 0 {
+		return "Belum ada lampiran"
+	}
+
+	lines := make([]string, 0, len(attachments))
+	for i, attachment := range attachments {
+		icon := "📄"
+		if attachment.IsImage() {
+			icon = "🖼"
+		}
+		line := fmt.Sprintf("%d. %s %s\n   🔗 %s", i+1, icon, attachment.FileName, attachment.URL)
+		if attachment.LinkedFrom != "" {
+			line += fmt.Sprintf("\n   ♻️ Sama dengan bukti %s", attachment.LinkedFrom)
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
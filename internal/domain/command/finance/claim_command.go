@@ -0,0 +1,263 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/command/common"
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/message"
+	"github.com/gwenziro/botopia/internal/domain/money"
+	"github.com/gwenziro/botopia/internal/domain/repository"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/utils"
+)
+
+// ClaimCommand implementasi command untuk mengelola klaim reimbursement pengeluaran kantor
+type ClaimCommand struct {
+	common.BaseCommand
+	reimbursements service.ReimbursementService
+	connectionRepo repository.ConnectionRepository
+}
+
+// NewClaimCommand membuat instance command baru; connectionRepo boleh nil jika dokumen tidak perlu dikirim
+func NewClaimCommand(reimbursements service.ReimbursementService, connectionRepo repository.ConnectionRepository) *ClaimCommand {
+	cmd := &ClaimCommand{
+		reimbursements: reimbursements,
+		connectionRepo: connectionRepo,
+	}
+	cmd.Name = "klaim"
+	cmd.Description = "Mengelola reimbursement kantor: tandai pengeluaran dengan #reimburse, kelompokkan menjadi klaim beserta dokumen ringkasannya, lalu tautkan pemasukan penggantian. Pemasukan yang menyebut kode klaim (r_001) otomatis melunasi klaim tersebut."
+	cmd.Category = "Keuangan"
+	cmd.Usage = "!klaim | !klaim tandai <kode...> | !klaim lepas <kode> | !klaim buat [kode...] [judul] | !klaim <r_xxx> | !klaim dokumen <r_xxx> | !klaim lunas <r_xxx> <kode_pemasukan> | !klaim semua"
+	return cmd
+}
+
+// Execute menjalankan command
+func (c *ClaimCommand) Execute(args []string, msg *message.Message) (string, error) {
+	ctx, cancel := actorContext(msg, 120*time.Second)
+	defer cancel()
+
+	if len(args) == 0 {
+		return c.overview(ctx, false), nil
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "semua", "riwayat":
+		return c.overview(ctx, true), nil
+
+	case "tandai":
+		if len(args) < 2 {
+			return "❌ Kode transaksi belum diisi. Contoh: !klaim tandai k_jun25_004 k_jun25_007", nil
+		}
+		return c.flag(ctx, args[1:]), nil
+
+	case "lepas", "hapus":
+		if len(args) < 2 {
+			return "❌ Kode transaksi belum diisi. Contoh: !klaim lepas k_jun25_004", nil
+		}
+		code := strings.ToLower(args[1])
+		if !transactionCodePattern.MatchString(code) {
+			return invalidCodeMessage(code), nil
+		}
+		record, err := c.reimbursements.Unflag(ctx, code)
+		if err != nil {
+			return fmt.Sprintf("❌ Gagal melepas tanda reimburse: %v", err), nil
+		}
+		return fmt.Sprintf("✅ Tanda #%s dilepas dari %s - %s.", finance.ReimbursableTag, record.UniqueCode, record.Description), nil
+
+	case "buat", "ajukan":
+		return c.createClaim(ctx, args[1:], msg), nil
+
+	case "dokumen":
+		if len(args) < 2 {
+			return "❌ Kode klaim belum diisi. Contoh: !klaim dokumen r_001", nil
+		}
+		claim, err := c.reimbursements.GetClaim(ctx, args[1])
+		if err != nil {
+			return fmt.Sprintf("❌ %v. Ketik !klaim semua untuk melihat daftar klaim.", err), nil
+		}
+		if err := c.sendDocument(ctx, claim, msg); err != nil {
+			return fmt.Sprintf("❌ Gagal mengirim dokumen klaim: %v", err), nil
+		}
+		return fmt.Sprintf("✅ Dokumen klaim %s terkirim.", claim.Code), nil
+
+	case "lunas", "bayar":
+		if len(args) < 3 {
+			return "❌ Kode klaim dan kode pemasukan penggantian belum diisi. Contoh: !klaim lunas r_001 m_jul25_002", nil
+		}
+		incomeCode := strings.ToLower(args[2])
+		if !transactionCodePattern.MatchString(incomeCode) {
+			return invalidCodeMessage(incomeCode), nil
+		}
+		claim, err := c.reimbursements.MarkPaid(ctx, args[1], incomeCode)
+		if err != nil {
+			return fmt.Sprintf("❌ Gagal menandai klaim lunas: %v", err), nil
+		}
+		return "✅ Klaim ditandai lunas, seluruh pengeluarannya sudah diganti.\n\n" + formatClaimDetail(claim), nil
+	}
+
+	claim, err := c.reimbursements.GetClaim(ctx, args[0])
+	if err != nil {
+		return fmt.Sprintf("❌ %v. Ketik !klaim untuk melihat pengeluaran dan klaim yang berjalan.", err), nil
+	}
+
+	return formatClaimDetail(claim), nil
+}
+
+// overview memformat pengeluaran yang belum diklaim dan daftar klaim; tanpa includePaid hanya klaim yang belum lunas
+func (c *ClaimCommand) overview(ctx context.Context, includePaid bool) string {
+	pending, err := c.reimbursements.ListPending(ctx)
+	if err != nil {
+		return fmt.Sprintf("❌ Gagal memuat pengeluaran reimburse: %v", err)
+	}
+	claims, err := c.reimbursements.ListClaims(ctx)
+	if err != nil {
+		return fmt.Sprintf("❌ Gagal memuat daftar klaim: %v", err)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("────────────────────────\n")
+	sb.WriteString("🧾 REIMBURSEMENT 🧾\n")
+	sb.WriteString("────────────────────────\n")
+
+	var pendingTotal money.Money
+	sb.WriteString(fmt.Sprintf("📌 Belum diklaim (%d):\n", len(pending)))
+	for _, record := range pending {
+		pendingTotal = pendingTotal.Add(record.Amount)
+		sb.WriteString(fmt.Sprintf("• %s - %s - Rp %s (%s)\n",
+			utils.FormatDateShort(record.Date), record.Description, utils.FormatMoney(record.Amount), record.UniqueCode))
+	}
+	if len(pending) == 0 {
+		sb.WriteString(fmt.Sprintf("Tidak ada. Tandai pengeluaran dengan #%s atau !klaim tandai <kode>.\n", finance.ReimbursableTag))
+	} else {
+		sb.WriteString(fmt.Sprintf("💰 Total: Rp %s\n", utils.FormatMoney(pendingTotal)))
+	}
+
+	var outstanding money.Money
+	shown := 0
+	sb.WriteString("\n📨 Klaim:\n")
+	for _, claim := range claims {
+		if !claim.IsPaid() {
+			outstanding = outstanding.Add(claim.Total())
+		} else if !includePaid {
+			continue
+		}
+		shown++
+
+		sb.WriteString(fmt.Sprintf("• %s - %s - Rp %s [%s]\n",
+			claim.Code, claim.Title, utils.FormatMoney(claim.Total()), claim.StatusLabel()))
+	}
+	if shown == 0 {
+		sb.WriteString("Tidak ada klaim yang menunggu penggantian.\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("⏳ Menunggu penggantian: Rp %s\n", utils.FormatMoney(outstanding)))
+	}
+
+	sb.WriteString("────────────────────────\n")
+	sb.WriteString("Ketik !klaim buat untuk mengajukan seluruh pengeluaran yang belum diklaim.")
+
+	return sb.String()
+}
+
+// flag menandai beberapa pengeluaran sebagai reimbursable
+func (c *ClaimCommand) flag(ctx context.Context, codes []string) string {
+	var lines []string
+	for _, code := range codes {
+		code = strings.ToLower(strings.TrimSpace(code))
+		if !transactionCodePattern.MatchString(code) {
+			lines = append(lines, fmt.Sprintf("❌ %s: format kode tidak valid", code))
+			continue
+		}
+
+		record, err := c.reimbursements.Flag(ctx, code)
+		if err != nil {
+			lines = append(lines, fmt.Sprintf("❌ %s: %v", code, err))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("✅ %s - %s - Rp %s", record.UniqueCode, record.Description, utils.FormatMoney(record.Amount)))
+	}
+
+	return fmt.Sprintf("🧾 Tandai #%s:\n%s\n\nKetik !klaim buat untuk mengajukan klaim.",
+		finance.ReimbursableTag, strings.Join(lines, "\n"))
+}
+
+// createClaim membuat klaim dari kode transaksi di awal argumen; sisa argumen menjadi judul klaim
+func (c *ClaimCommand) createClaim(ctx context.Context, args []string, msg *message.Message) string {
+	var codes []string
+	for len(args) > 0 && transactionCodePattern.MatchString(strings.ToLower(args[0])) {
+		codes = append(codes, strings.ToLower(args[0]))
+		args = args[1:]
+	}
+	title := strings.Join(args, " ")
+
+	claim, err := c.reimbursements.CreateClaim(ctx, title, codes)
+	if err != nil {
+		return fmt.Sprintf("❌ Gagal membuat klaim: %v", err)
+	}
+
+	result := "✅ Klaim berhasil dibuat.\n\n" + formatClaimDetail(claim)
+	if err := c.sendDocument(ctx, claim, msg); err != nil {
+		result += fmt.Sprintf("\n\n⚠️ Dokumen klaim gagal dikirim: %v\nCoba lagi dengan !klaim dokumen %s", err, claim.Code)
+	}
+	return result
+}
+
+// sendDocument mengirim dokumen ringkasan klaim ke chat sebagai file HTML
+func (c *ClaimCommand) sendDocument(ctx context.Context, claim *finance.ReimbursementClaim, msg *message.Message) error {
+	if c.connectionRepo == nil || msg == nil || msg.Chat == nil {
+		return fmt.Errorf("koneksi WhatsApp tidak tersedia")
+	}
+
+	data, err := c.reimbursements.Document(ctx, claim.Code)
+	if err != nil {
+		return err
+	}
+
+	return c.connectionRepo.SendMedia(ctx, msg.Chat.ID, &message.OutgoingMedia{
+		Data:     data,
+		MimeType: "text/html",
+		FileName: fmt.Sprintf("klaim_%s.html", claim.Code),
+		Caption:  fmt.Sprintf("🧾 %s (%s) - Rp %s", claim.Title, claim.Code, utils.FormatMoney(claim.Total())),
+	})
+}
+
+// formatClaimDetail memformat detail klaim beserta daftar pengeluarannya
+func formatClaimDetail(claim *finance.ReimbursementClaim) string {
+	var sb strings.Builder
+
+	sb.WriteString("────────────────────────\n")
+	sb.WriteString(fmt.Sprintf("🧾 KLAIM %s 🧾\n", strings.ToUpper(claim.Code)))
+	sb.WriteString("────────────────────────\n")
+	sb.WriteString(fmt.Sprintf("📖 %s\n", claim.Title))
+	sb.WriteString(fmt.Sprintf("📅 Diajukan: %s\n", utils.FormatDateID(claim.SubmittedAt)))
+	sb.WriteString(fmt.Sprintf("📌 Status: %s\n", claim.StatusLabel()))
+	sb.WriteString("────────────────────────\n")
+
+	for i, item := range claim.Items {
+		proof := ""
+		if len(item.ProofURLs) == 0 {
+			proof = " ⚠️ tanpa bukti"
+		}
+		sb.WriteString(fmt.Sprintf("%d. %s - %s - Rp %s (%s)%s\n",
+			i+1, utils.FormatDateShort(item.Date), item.Description, utils.FormatMoney(item.Amount), item.RecordCode, proof))
+	}
+
+	sb.WriteString("────────────────────────\n")
+	sb.WriteString(fmt.Sprintf("💰 Total: Rp %s\n", utils.FormatMoney(claim.Total())))
+
+	if claim.IsPaid() {
+		sb.WriteString(fmt.Sprintf("✅ Diganti %s: Rp %s (%s)\n",
+			utils.FormatDateID(claim.PaidAt), utils.FormatMoney(claim.PaidAmount), claim.IncomeCode))
+		if diff := claim.Difference(); !diff.IsZero() {
+			sb.WriteString(fmt.Sprintf("⚖ Selisih: Rp %s\n", utils.FormatMoney(diff)))
+		}
+	} else {
+		sb.WriteString(fmt.Sprintf("ℹ Sebut %s di deskripsi pemasukan penggantian agar klaim otomatis lunas.\n", claim.Code))
+	}
+	sb.WriteString("────────────────────────")
+
+	return sb.String()
+}
@@ -0,0 +1,123 @@
+package finance
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/command/common"
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/message"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/utils"
+)
+
+// ClosePeriodCommand implementasi command untuk tutup buku bulanan
+type ClosePeriodCommand struct {
+	common.BaseCommand
+	closings service.PeriodClosingService
+}
+
+// NewClosePeriodCommand membuat instance command baru
+func NewClosePeriodCommand(closings service.PeriodClosingService) *ClosePeriodCommand {
+	cmd := &ClosePeriodCommand{
+		closings: closings,
+	}
+	cmd.Name = "tutupbuku"
+	cmd.Description = "Tutup buku periode bulanan: menyimpan ringkasan angka dan mengunci transaksi pada bulan tersebut. Tanpa argumen menutup bulan lalu. Gunakan 'buka' untuk membuka kembali, 'izinkan' untuk sementara mengubah transaksi pada periode tertutup, dan 'daftar' untuk melihat periode tertutup."
+	cmd.Category = "Keuangan"
+	cmd.Usage = "!tutupbuku [bulan] [tahun] | !tutupbuku buka <bulan> [tahun] | !tutupbuku izinkan <bulan> [tahun] | !tutupbuku daftar"
+	return cmd
+}
+
+// Execute menjalankan command
+func (c *ClosePeriodCommand) Execute(args []string, msg *message.Message) (string, error) {
+	if !c.closings.IsAdmin(msg.Sender.Phone) {
+		return "❌ Hanya admin keuangan yang dapat melakukan tutup buku.", nil
+	}
+
+	ctx, cancel := actorContext(msg, 60*time.Second)
+	defer cancel()
+
+	now := time.Now()
+
+	if len(args) > 0 {
+		switch strings.ToLower(args[0]) {
+		case "daftar", "list":
+			closings, err := c.closings.ListClosings(ctx)
+			if err != nil {
+				return fmt.Sprintf("❌ Gagal memuat daftar tutup buku: %v", err), nil
+			}
+			return formatClosingList(closings), nil
+
+		case "buka", "open":
+			start, err := parsePeriodArgs(args[1:], now)
+			if err != nil {
+				return fmt.Sprintf("❌ %v. Contoh: !tutupbuku buka mei 2025", err), nil
+			}
+			if err := c.closings.Reopen(ctx, finance.PeriodOf(start)); err != nil {
+				return fmt.Sprintf("❌ Gagal membuka periode: %v", err), nil
+			}
+			return fmt.Sprintf("🔓 Periode %s dibuka kembali. Transaksi pada bulan ini dapat diubah lagi.",
+				utils.FormatMonthID(start)), nil
+
+		case "izinkan", "override":
+			start, err := parsePeriodArgs(args[1:], now)
+			if err != nil {
+				return fmt.Sprintf("❌ %v. Contoh: !tutupbuku izinkan mei 2025", err), nil
+			}
+			expiresAt, err := c.closings.AllowOverride(ctx, finance.PeriodOf(start))
+			if err != nil {
+				return fmt.Sprintf("❌ Gagal memberi izin: %v", err), nil
+			}
+			return fmt.Sprintf("⚠️ Kamu dapat menambah, mengubah, atau menghapus transaksi periode %s hingga pukul %s. "+
+				"Setiap perubahan dicatat di audit log.", utils.FormatMonthID(start), expiresAt.Format("15:04")), nil
+		}
+	}
+
+	// Tanpa argumen, tutup buku bulan lalu
+	start := time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, now.Location())
+	if len(args) > 0 {
+		parsed, err := parsePeriodArgs(args, now)
+		if err != nil {
+			return fmt.Sprintf("❌ %v. Contoh: !tutupbuku mei 2025", err), nil
+		}
+		start = parsed
+	}
+
+	closing, err := c.closings.Close(ctx, finance.PeriodOf(start))
+	if err != nil {
+		return fmt.Sprintf("❌ Gagal tutup buku: %v", err), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("────────────────────────\n")
+	sb.WriteString(fmt.Sprintf("🔒 TUTUP BUKU %s 🔒\n", strings.ToUpper(utils.FormatMonthID(start))))
+	sb.WriteString("────────────────────────\n")
+	sb.WriteString(formatPeriodSnapshot(closing.Snapshot))
+	sb.WriteString("────────────────────────\n")
+	sb.WriteString("Transaksi bertanggal di bulan ini tidak dapat lagi ditambah, diubah, atau dihapus kecuali admin mengirim !tutupbuku izinkan.")
+
+	return sb.String(), nil
+}
+
+// formatClosingList memformat daftar periode yang sudah tutup buku
+func formatClosingList(closings []*finance.PeriodClosing) string {
+	if len(closings) == 0 {
+		return "📭 Belum ada periode yang tutup buku."
+	}
+
+	var sb strings.Builder
+	sb.WriteString("🔒 Periode tutup buku:\n")
+	for _, closing := range closings {
+		start, err := finance.ParsePeriod(closing.Period)
+		if err != nil {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("\n• %s - selisih Rp %s\n  Ditutup %s oleh %s\n",
+			utils.FormatMonthID(start), utils.FormatMoney(closing.Snapshot.Net),
+			utils.FormatDateID(closing.ClosedAt), closing.ClosedBy))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,54 @@
+package finance
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/command/common"
+	"github.com/gwenziro/botopia/internal/domain/message"
+	"github.com/gwenziro/botopia/internal/domain/service"
+)
+
+// DetailCommand implementasi command untuk melihat detail transaksi
+type DetailCommand struct {
+	common.BaseCommand
+	financeService service.FinanceService
+}
+
+// NewDetailCommand membuat instance command baru
+func NewDetailCommand(financeService service.FinanceService) *DetailCommand {
+	cmd := &DetailCommand{
+		financeService: financeService,
+	}
+	cmd.Name = "detail"
+	cmd.Description = "Menampilkan detail lengkap transaksi berdasarkan kode transaksi."
+	cmd.Category = "Keuangan"
+	cmd.Usage = "!detail <kode_transaksi>"
+	return cmd
+}
+
+// Execute menjalankan command
+func (c *DetailCommand) Execute(args []string, msg *message.Message) (string, error) {
+	if len(args) == 0 {
+		return "❌ Mohon sertakan kode transaksi. Contoh: !detail k_jan25_001", nil
+	}
+
+	code := strings.ToLower(strings.TrimSpace(args[0]))
+	if !transactionCodePattern.MatchString(code) {
+		return invalidCodeMessage(code), nil
+	}
+
+	ctx, cancel := actorContext(msg, 30*time.Second)
+	defer cancel()
+
+	record, err := c.financeService.GetRecordByCode(ctx, code)
+	if err != nil {
+		if strings.Contains(err.Error(), "tidak ditemukan") {
+			return fmt.Sprintf("❌ Transaksi dengan kode %s tidak ditemukan.", code), nil
+		}
+		return fmt.Sprintf("❌ Gagal mengambil transaksi: %v", err), nil
+	}
+
+	return formatRecordDetail(record), nil
+}
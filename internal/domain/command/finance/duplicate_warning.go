@@ -0,0 +1,46 @@
+package finance
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gwenziro/botopia/internal/domain/dto"
+	"github.com/gwenziro/botopia/internal/domain/finance"
+)
+
+// formatDuplicateWarning memformat peringatan transaksi ganda beserta permintaan konfirmasi
+func formatDuplicateWarning(draft *finance.FinanceRecord, duplicates []*finance.FinanceRecord) string {
+	typeText := "pengeluaran"
+	if draft.Type == finance.TypeIncome {
+		typeText = "pemasukan"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("────────────────────────\n")
+	sb.WriteString("⚠️ KEMUNGKINAN DATA GANDA ⚠️\n")
+	sb.WriteString("────────────────────────\n")
+	sb.WriteString(fmt.Sprintf("Sudah ada %s serupa yang tercatat:\n\n", typeText))
+
+	for _, record := range duplicates {
+		recordDTO := dto.FromFinanceRecord(record)
+		sb.WriteString(fmt.Sprintf("• %s — %s\n  %s — Rp %s\n",
+			record.UniqueCode, recordDTO.DateFormatted, record.Description, recordDTO.AmountText))
+	}
+
+	draftDTO := dto.FromFinanceRecord(draft)
+	sb.WriteString("────────────────────────\n")
+	sb.WriteString(fmt.Sprintf("Data baru: %s — %s — Rp %s\n\n", draftDTO.DateFormatted, draft.Description, draftDTO.AmountText))
+	sb.WriteString(fmt.Sprintf("Tetap simpan %s ini?\n", typeText))
+	sb.WriteString("Balas *ya* untuk menyimpan atau *tidak* untuk membatalkan.\n")
+	sb.WriteString("────────────────────────")
+
+	return sb.String()
+}
+
+// removeMedia menghapus file media sementara jika ada
+func removeMedia(mediaPath string) {
+	if mediaPath != "" {
+		os.Remove(mediaPath)
+	}
+}
@@ -0,0 +1,133 @@
+package finance
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/command/common"
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/message"
+	"github.com/gwenziro/botopia/internal/domain/money"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/utils"
+)
+
+// ForecastCommand implementasi command untuk melihat proyeksi arus kas
+type ForecastCommand struct {
+	common.BaseCommand
+	forecasts service.ForecastService
+}
+
+// NewForecastCommand membuat instance command baru
+func NewForecastCommand(forecasts service.ForecastService) *ForecastCommand {
+	cmd := &ForecastCommand{
+		forecasts: forecasts,
+	}
+	cmd.Name = "proyeksi"
+	cmd.Description = "Memproyeksikan saldo setiap sumber dana hingga akhir bulan dan beberapa bulan ke depan, termasuk perkiraan tanggal saldo minus."
+	cmd.Category = "Keuangan"
+	cmd.Usage = "!proyeksi [jumlah_bulan|nama_bulan]"
+	return cmd
+}
+
+// Execute menjalankan command
+func (c *ForecastCommand) Execute(args []string, msg *message.Message) (string, error) {
+	months := finance.DefaultForecastMonths
+	if len(args) > 0 {
+		parsed, err := parseForecastMonths(args[0], time.Now())
+		if err != nil {
+			return fmt.Sprintf("❌ %v. Contoh: !proyeksi 3 atau !proyeksi agustus", err), nil
+		}
+		months = parsed
+	}
+
+	ctx, cancel := actorContext(msg, 60*time.Second)
+	defer cancel()
+
+	forecast, err := c.forecasts.GetForecast(ctx, months)
+	if err != nil {
+		return fmt.Sprintf("❌ Gagal membuat proyeksi: %v", err), nil
+	}
+
+	return formatForecast(forecast), nil
+}
+
+// parseForecastMonths menerima jumlah bulan (1-12) atau nama bulan tujuan proyeksi
+func parseForecastMonths(arg string, now time.Time) (int, error) {
+	arg = strings.ToLower(strings.TrimSpace(arg))
+
+	if months, err := strconv.Atoi(arg); err == nil {
+		if months < 0 || months > finance.MaxForecastMonths {
+			return 0, fmt.Errorf("jumlah bulan harus antara 0 dan %d", finance.MaxForecastMonths)
+		}
+		return months, nil
+	}
+
+	english, ok := utils.IndoMonthsMap[arg]
+	if !ok {
+		return 0, fmt.Errorf("bulan '%s' tidak dikenali", arg)
+	}
+
+	target, err := time.Parse("Jan", english[:3])
+	if err != nil {
+		return 0, fmt.Errorf("bulan '%s' tidak dikenali", arg)
+	}
+
+	// Bulan yang sudah lewat berarti bulan tersebut di tahun berikutnya
+	months := int(target.Month()) - int(now.Month())
+	if months < 0 {
+		months += 12
+	}
+	return months, nil
+}
+
+// formatForecast memformat hasil proyeksi arus kas untuk pesan WhatsApp
+func formatForecast(forecast *finance.CashFlowForecast) string {
+	var sb strings.Builder
+
+	sb.WriteString("────────────────────────\n")
+	sb.WriteString("📈 PROYEKSI ARUS KAS 📈\n")
+	sb.WriteString("────────────────────────\n")
+	sb.WriteString(fmt.Sprintf("Hingga %s\n", utils.FormatDateShort(forecast.Until)))
+
+	if len(forecast.Media) == 0 {
+		sb.WriteString("\nBelum ada data transaksi untuk diproyeksikan.\n")
+	}
+
+	for _, media := range forecast.Media {
+		sb.WriteString(fmt.Sprintf("\n🏦 %s\n", media.StorageMedia))
+		sb.WriteString(fmt.Sprintf("Saldo saat ini: Rp %s\n", utils.FormatMoney(media.CurrentBalance)))
+		for _, monthEnd := range media.MonthEnds {
+			sb.WriteString(fmt.Sprintf("Akhir %s %d: Rp %s\n",
+				utils.IndoMonths[monthEnd.Month.Month()-1], monthEnd.Month.Year(), utils.FormatMoney(monthEnd.Balance)))
+		}
+		if media.NegativeDate != nil {
+			sb.WriteString(fmt.Sprintf("⚠️ Diperkirakan minus pada %s\n", utils.FormatDateShort(*media.NegativeDate)))
+		}
+	}
+
+	if len(forecast.Recurring) > 0 {
+		sb.WriteString("\n🔁 TRANSAKSI RUTIN\n")
+		for _, item := range forecast.Recurring {
+			sign := "-"
+			if item.Type == finance.TypeIncome {
+				sign = "+"
+			}
+			sb.WriteString(fmt.Sprintf("• Tgl %d: %s %sRp %s (%s)\n",
+				item.DayOfMonth, item.Description, sign, utils.FormatMoney(item.Amount), item.StorageMedia))
+		}
+	}
+
+	var daily money.Money
+	for _, spend := range forecast.DailySpend {
+		daily = daily.Add(spend.DailyAmount)
+	}
+	sb.WriteString(fmt.Sprintf("\n📊 Rata-rata pengeluaran tidak rutin: Rp %s/hari (%d hari terakhir)\n",
+		utils.FormatMoney(daily), forecast.LookbackDays))
+	sb.WriteString("ℹ Pemasukan yang tidak rutin tidak ikut diproyeksikan.\n")
+	sb.WriteString("────────────────────────")
+
+	return sb.String()
+}
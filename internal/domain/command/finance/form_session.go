@@ -0,0 +1,79 @@
+package finance
+
+import (
+	"fmt"
+
+	"github.com/gwenziro/botopia/internal/domain/message"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/utils"
+)
+
+// formSessionKey membuat key sesi formulir untuk pengirim pesan
+func formSessionKey(msg *message.Message) (string, bool) {
+	if msg == nil || msg.Sender == nil || msg.Chat == nil {
+		return "", false
+	}
+	return service.FormSessionKey(msg.Chat.ID, msg.Sender.Phone), true
+}
+
+// prefilledValues mengambil field formulir yang sudah terisi sebagai jawaban awal sesi
+func prefilledValues(form map[string]string) map[string]string {
+	values := make(map[string]string)
+	for key, value := range form {
+		if value != "" {
+			values[key] = value
+		}
+	}
+	return values
+}
+
+// dateFormField pertanyaan tanggal transaksi, dinormalisasi ke format DD/MM/YYYY
+func dateFormField() service.FormField {
+	return service.FormField{
+		Key:    "Tanggal",
+		Prompt: "Tanggal transaksi?",
+		Hint:   "hari ini, 15 Mei 2025, atau 15/05/2025",
+		Validate: func(answer string) (string, error) {
+			date, err := utils.ParseDateWithFormats(answer)
+			if err != nil {
+				return "", fmt.Errorf("format tanggal tidak valid, gunakan format seperti '15 Mei 2025'")
+			}
+			return utils.FormatDateShort(date), nil
+		},
+	}
+}
+
+// amountFormField pertanyaan nominal transaksi yang harus lebih dari nol
+func amountFormField() service.FormField {
+	return service.FormField{
+		Key:    "Nominal",
+		Prompt: "Berapa nominalnya?",
+		Hint:   "50000",
+		Validate: func(answer string) (string, error) {
+			amount, err := utils.ParseMoney(answer)
+			if err != nil || !amount.IsPositive() {
+				return "", fmt.Errorf("nominal tidak valid, gunakan angka saja, contoh: 50000")
+			}
+			return amount.Decimal(), nil
+		},
+	}
+}
+
+// notesFormField pertanyaan catatan tambahan yang boleh dilewati
+func notesFormField() service.FormField {
+	return service.FormField{
+		Key:      "Catatan",
+		Prompt:   "Catatan tambahan?",
+		Optional: true,
+	}
+}
+
+// proofFormField pertanyaan foto bukti transaksi
+func proofFormField(optional bool) service.FormField {
+	return service.FormField{
+		Key:         "Bukti",
+		Prompt:      "Kirim foto bukti transaksi.",
+		Optional:    optional,
+		AcceptMedia: true,
+	}
+}
@@ -0,0 +1,321 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/command/common"
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/message"
+	"github.com/gwenziro/botopia/internal/domain/money"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/utils"
+)
+
+// InstallmentCommand implementasi command untuk mengelola cicilan dan kartu kredit
+type InstallmentCommand struct {
+	common.BaseCommand
+	installments   service.InstallmentService
+	financeService service.FinanceService
+	formSessions   service.FormSessionService
+}
+
+// NewInstallmentCommand membuat instance command baru
+func NewInstallmentCommand(
+	installments service.InstallmentService,
+	financeService service.FinanceService,
+	formSessions service.FormSessionService,
+) *InstallmentCommand {
+	cmd := &InstallmentCommand{
+		installments:   installments,
+		financeService: financeService,
+		formSessions:   formSessions,
+	}
+	cmd.Name = "cicilan"
+	cmd.Description = "Mengelola cicilan (misalnya kartu kredit) yang dicatat otomatis sebagai pengeluaran setiap bulan. Tanpa argumen menampilkan sisa tenor dan sisa tagihan cicilan yang berjalan."
+	cmd.Category = "Keuangan"
+	cmd.Usage = "!cicilan [kode] | !cicilan tambah | !cicilan lunas <kode> [nominal] | !cicilan semua"
+	return cmd
+}
+
+// Execute menjalankan command
+func (c *InstallmentCommand) Execute(args []string, msg *message.Message) (string, error) {
+	ctx, cancel := actorContext(msg, 60*time.Second)
+	defer cancel()
+
+	if len(args) == 0 {
+		return c.listPlans(ctx, false), nil
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "tambah", "baru":
+		return c.startCreateSession(msg), nil
+
+	case "semua", "riwayat":
+		return c.listPlans(ctx, true), nil
+
+	case "lunas", "pelunasan":
+		if len(args) < 2 {
+			return "❌ Kode cicilan belum diisi. Contoh: !cicilan lunas c_001", nil
+		}
+
+		var amount money.Money
+		if len(args) > 2 {
+			parsed, err := utils.ParseMoney(strings.Join(args[2:], ""))
+			if err != nil || !parsed.IsPositive() {
+				return "❌ Nominal pelunasan tidak valid. Contoh: !cicilan lunas c_001 2500000", nil
+			}
+			amount = parsed
+		}
+
+		plan, record, err := c.installments.PayOff(ctx, args[1], amount)
+		if err != nil {
+			return fmt.Sprintf("❌ Gagal melunasi cicilan: %v", err), nil
+		}
+
+		return fmt.Sprintf(`✅ Cicilan %s dilunasi.
+📖 %s
+💰 Pelunasan: Rp %s
+📊 Total dibayar: Rp %s dari Rp %s
+ℹ Kode Transaksi: %s`,
+			plan.Code, plan.Description, utils.FormatMoney(record.Amount),
+			utils.FormatMoney(plan.PaidAmount()), utils.FormatMoney(plan.Total()), record.UniqueCode), nil
+	}
+
+	plan, err := c.installments.Get(ctx, args[0])
+	if err != nil {
+		return fmt.Sprintf("❌ %v. Ketik !cicilan untuk melihat daftar cicilan.", err), nil
+	}
+
+	return formatInstallmentDetail(plan), nil
+}
+
+// listPlans memformat daftar cicilan; tanpa includeClosed hanya cicilan yang masih berjalan
+func (c *InstallmentCommand) listPlans(ctx context.Context, includeClosed bool) string {
+	plans, err := c.installments.List(ctx)
+	if err != nil {
+		return fmt.Sprintf("❌ Gagal memuat daftar cicilan: %v", err)
+	}
+
+	var sb strings.Builder
+	var totalOutstanding money.Money
+	shown := 0
+
+	for _, plan := range plans {
+		if !includeClosed && !plan.IsActive() {
+			continue
+		}
+		shown++
+		totalOutstanding = totalOutstanding.Add(plan.Outstanding())
+
+		sb.WriteString(fmt.Sprintf("\n%d. %s - %s\n", shown, plan.Code, plan.Description))
+		sb.WriteString(fmt.Sprintf("   💳 Rp %s/bulan via %s\n", utils.FormatMoney(plan.MonthlyAmount()), plan.PaymentMethod))
+		switch plan.Status {
+		case finance.InstallmentActive:
+			sb.WriteString(fmt.Sprintf("   ⏳ Sisa %d dari %d bulan | Sisa tagihan Rp %s\n",
+				plan.RemainingTenor(), plan.Tenor, utils.FormatMoney(plan.Outstanding())))
+			if next, ok := plan.NextDueDate(); ok {
+				sb.WriteString(fmt.Sprintf("   📅 Jatuh tempo berikutnya: %s\n", utils.FormatDateID(next)))
+			}
+		case finance.InstallmentPaidOff:
+			sb.WriteString(fmt.Sprintf("   ✅ Dilunasi %s\n", utils.FormatDateID(plan.ClosedAt)))
+		default:
+			sb.WriteString(fmt.Sprintf("   ✅ Selesai %s\n", utils.FormatDateID(plan.ClosedAt)))
+		}
+	}
+
+	if shown == 0 {
+		return "📭 Tidak ada cicilan yang sedang berjalan.\nKetik !cicilan tambah untuk mencatat cicilan baru."
+	}
+
+	return fmt.Sprintf(`────────────────────────
+💳 DAFTAR CICILAN 💳
+────────────────────────%s
+────────────────────────
+💰 Total sisa tagihan: Rp %s
+────────────────────────
+Ketik !cicilan <kode> untuk detail atau !cicilan lunas <kode> untuk pelunasan.`,
+		sb.String(), utils.FormatMoney(totalOutstanding))
+}
+
+// startCreateSession memulai formulir interaktif pembuatan rencana cicilan
+func (c *InstallmentCommand) startCreateSession(msg *message.Message) string {
+	key, ok := formSessionKey(msg)
+	if c.formSessions == nil || !ok {
+		return "❌ Formulir interaktif tidak tersedia, cicilan belum dapat ditambahkan."
+	}
+
+	config, err := c.financeService.GetConfiguration(messageContext(msg))
+	if err != nil {
+		return fmt.Sprintf("Gagal memuat konfigurasi keuangan: %v", err)
+	}
+
+	principalField := amountFormField()
+	principalField.Key = "Pokok"
+	principalField.Prompt = "Berapa harga pokok barang/pinjaman?"
+	principalField.Hint = "6000000"
+
+	startField := dateFormField()
+	startField.Key = "Mulai"
+	startField.Prompt = "Tanggal jatuh tempo cicilan pertama?"
+	startField.Hint = "25 Juni 2025; cicilan berikutnya jatuh tempo di tanggal yang sama setiap bulan"
+
+	return c.formSessions.Start(key, &service.FormSession{
+		Title: "TAMBAH CICILAN",
+		Fields: []service.FormField{
+			{Key: "Deskripsi", Prompt: "Cicilan untuk apa?", Hint: "Laptop kantor"},
+			principalField,
+			{
+				Key:    "Tenor",
+				Prompt: "Berapa bulan tenornya?",
+				Hint:   "12",
+				Validate: func(answer string) (string, error) {
+					tenor, err := strconv.Atoi(strings.TrimSpace(strings.TrimSuffix(strings.ToLower(answer), "bulan")))
+					if err != nil || tenor < 1 || tenor > 120 {
+						return "", fmt.Errorf("tenor harus berupa angka bulan antara 1 dan 120")
+					}
+					return strconv.Itoa(tenor), nil
+				},
+			},
+			{
+				Key:      "Bunga",
+				Prompt:   "Bunga/biaya cicilan? Isi persen flat per bulan atau total nominal.",
+				Hint:     "1,5% atau 250000",
+				Optional: true,
+				Validate: func(answer string) (string, error) {
+					if _, err := parseInstallmentFee(answer, money.Money{}, 1); err != nil {
+						return "", err
+					}
+					return strings.TrimSpace(answer), nil
+				},
+			},
+			startField,
+			{Key: "Kategori", Prompt: "Pilih kategori:", Choices: config.ExpenseCategories},
+			{Key: "Metode", Prompt: "Pilih metode pembayaran:", Choices: config.PaymentMethods},
+			{Key: "Sumber", Prompt: "Pilih sumber dana:", Choices: config.StorageMedias},
+		},
+		OnComplete: func(values map[string]string, msg *message.Message) string {
+			return c.createPlan(values, msg)
+		},
+		OnCancel: func() string {
+			return "❌ Pencatatan cicilan dibatalkan."
+		},
+	}, msg)
+}
+
+// createPlan menyimpan rencana cicilan dari jawaban formulir
+func (c *InstallmentCommand) createPlan(values map[string]string, msg *message.Message) string {
+	ctx, cancel := actorContext(msg, 60*time.Second)
+	defer cancel()
+
+	principal, err := utils.ParseMoney(values["Pokok"])
+	if err != nil {
+		return fmt.Sprintf("❌ Pokok cicilan tidak valid: %v", err)
+	}
+	tenor, err := strconv.Atoi(values["Tenor"])
+	if err != nil {
+		return fmt.Sprintf("❌ Tenor tidak valid: %v", err)
+	}
+	fee, err := parseInstallmentFee(values["Bunga"], principal, tenor)
+	if err != nil {
+		return fmt.Sprintf("❌ %v", err)
+	}
+	start, err := utils.ParseDateWithFormats(values["Mulai"])
+	if err != nil {
+		return fmt.Sprintf("❌ Tanggal cicilan pertama tidak valid: %v", err)
+	}
+
+	createdBy := ""
+	if msg != nil && msg.Sender != nil {
+		createdBy = msg.Sender.Phone
+	}
+
+	plan, err := c.installments.Create(ctx, &finance.InstallmentPlan{
+		Description:   values["Deskripsi"],
+		Category:      values["Kategori"],
+		PaymentMethod: values["Metode"],
+		StorageMedia:  values["Sumber"],
+		Principal:     principal,
+		Fee:           fee,
+		Tenor:         tenor,
+		StartDate:     start,
+		CreatedBy:     createdBy,
+	})
+	if err != nil {
+		return fmt.Sprintf("❌ Gagal menyimpan cicilan: %v", err)
+	}
+
+	return "✅ Cicilan berhasil dicatat. Pengeluaran bulanan akan dicatat otomatis setiap jatuh tempo.\n\n" +
+		formatInstallmentDetail(plan)
+}
+
+// parseInstallmentFee membaca bunga/biaya cicilan: persen flat per bulan dari pokok ("1,5%") atau total nominal
+func parseInstallmentFee(value string, principal money.Money, tenor int) (money.Money, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "-" {
+		return money.Money{}, nil
+	}
+
+	if strings.HasSuffix(value, "%") {
+		rateText := strings.Replace(strings.TrimSpace(strings.TrimSuffix(value, "%")), ",", ".", 1)
+		rate, err := strconv.ParseFloat(rateText, 64)
+		if err != nil || rate < 0 || rate > 100 {
+			return money.Money{}, fmt.Errorf("persentase bunga tidak valid, contoh: 1,5%%")
+		}
+		return principal.MulFloat(rate / 100 * float64(tenor)).Round(), nil
+	}
+
+	fee, err := utils.ParseMoney(value)
+	if err != nil || fee.IsNegative() {
+		return money.Money{}, fmt.Errorf("bunga/biaya tidak valid, isi persen (1,5%%) atau nominal (250000)")
+	}
+	return fee, nil
+}
+
+// formatInstallmentDetail memformat detail rencana cicilan beserta riwayat pembayarannya
+func formatInstallmentDetail(plan *finance.InstallmentPlan) string {
+	var sb strings.Builder
+
+	sb.WriteString("────────────────────────\n")
+	sb.WriteString(fmt.Sprintf("💳 CICILAN %s 💳\n", strings.ToUpper(plan.Code)))
+	sb.WriteString("────────────────────────\n")
+	sb.WriteString(fmt.Sprintf("📖 Deskripsi: %s\n", plan.Description))
+	sb.WriteString(fmt.Sprintf("💰 Pokok: Rp %s\n", utils.FormatMoney(plan.Principal)))
+	sb.WriteString(fmt.Sprintf("📈 Bunga/Biaya: Rp %s\n", utils.FormatMoney(plan.Fee)))
+	sb.WriteString(fmt.Sprintf("🧮 Total: Rp %s (%d x Rp %s)\n", utils.FormatMoney(plan.Total()), plan.Tenor, utils.FormatMoney(plan.MonthlyAmount())))
+	sb.WriteString(fmt.Sprintf("🏷 Kategori: %s\n", plan.Category))
+	sb.WriteString(fmt.Sprintf("💳 Metode: %s\n", plan.PaymentMethod))
+	sb.WriteString(fmt.Sprintf("🏦 Sumber Dana: %s\n", plan.StorageMedia))
+	sb.WriteString("────────────────────────\n")
+
+	switch plan.Status {
+	case finance.InstallmentActive:
+		sb.WriteString(fmt.Sprintf("⏳ Sisa tenor: %d dari %d bulan\n", plan.RemainingTenor(), plan.Tenor))
+		sb.WriteString(fmt.Sprintf("💸 Sisa tagihan: Rp %s\n", utils.FormatMoney(plan.Outstanding())))
+		if next, ok := plan.NextDueDate(); ok {
+			sb.WriteString(fmt.Sprintf("📅 Jatuh tempo berikutnya: %s\n", utils.FormatDateID(next)))
+		}
+	case finance.InstallmentPaidOff:
+		sb.WriteString(fmt.Sprintf("✅ Dilunasi pada %s\n", utils.FormatDateID(plan.ClosedAt)))
+	default:
+		sb.WriteString(fmt.Sprintf("✅ Selesai pada %s\n", utils.FormatDateID(plan.ClosedAt)))
+	}
+
+	if len(plan.Payments) > 0 {
+		sb.WriteString("\n🧾 PEMBAYARAN TERCATAT:\n")
+		for _, payment := range plan.Payments {
+			label := fmt.Sprintf("%d/%d", payment.Sequence, plan.Tenor)
+			if payment.IsPayoff() {
+				label = "Pelunasan"
+			}
+			sb.WriteString(fmt.Sprintf("• %s - %s - Rp %s (%s)\n",
+				label, utils.FormatDateShort(payment.DueDate), utils.FormatMoney(payment.Amount), payment.RecordCode))
+		}
+	}
+	sb.WriteString("────────────────────────")
+
+	return sb.String()
+}
@@ -0,0 +1,130 @@
+package finance
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/command/common"
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/message"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/utils"
+)
+
+// memberLookbackMonths rentang bulan sebelum periode untuk menentukan anggota ledger bersama
+const memberLookbackMonths = 12
+
+// MemberCommand implementasi command rekap pengeluaran per anggota di ledger bersama
+type MemberCommand struct {
+	common.BaseCommand
+	financeService service.FinanceService
+}
+
+// NewMemberCommand membuat instance command baru
+func NewMemberCommand(financeService service.FinanceService) *MemberCommand {
+	cmd := &MemberCommand{
+		financeService: financeService,
+	}
+	cmd.Name = "anggota"
+	cmd.Description = "Menampilkan siapa mencatat pengeluaran apa dalam sebulan beserta saldo tiap anggota atas pengeluaran bersama. Anggota adalah pencatat transaksi ledger dalam 12 bulan terakhir; pengeluaran bertag #pribadi tidak dibagi rata."
+	cmd.Category = "Keuangan"
+	cmd.Usage = "!anggota [bulan] [tahun]"
+	return cmd
+}
+
+// Execute menjalankan command
+func (c *MemberCommand) Execute(args []string, msg *message.Message) (string, error) {
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	if len(args) > 0 {
+		parsed, err := parsePeriodArgs(args, now)
+		if err != nil {
+			return fmt.Sprintf("❌ %v. Contoh: !anggota mei 2025", err), nil
+		}
+		start = parsed
+	}
+	end := start.AddDate(0, 1, 0).Add(-time.Nanosecond)
+
+	ctx, cancel := actorContext(msg, 60*time.Second)
+	defer cancel()
+
+	// Anggota yang tidak mencatat apa pun bulan ini tetap ikut menanggung pengeluaran bersama
+	records, err := c.financeService.ListRecords(ctx, &finance.RecordFilter{
+		From: start.AddDate(0, -memberLookbackMonths, 0),
+		To:   end,
+	})
+	if err != nil {
+		return fmt.Sprintf("❌ Gagal memuat transaksi: %v", err), nil
+	}
+
+	var members []string
+	var periodRecords []*finance.FinanceRecord
+	for _, record := range records {
+		if record.Member != "" {
+			members = append(members, record.Member)
+		}
+		if !record.Date.Before(start) {
+			periodRecords = append(periodRecords, record)
+		}
+	}
+
+	report := finance.BuildMemberReport(periodRecords, members)
+	if len(periodRecords) == 0 || len(report.Members) == 0 {
+		return fmt.Sprintf("📭 Belum ada transaksi yang dicatat anggota pada %s.", utils.FormatMonthID(start)), nil
+	}
+
+	return formatMemberReport(report, start), nil
+}
+
+// formatMemberReport memformat rekap per anggota beserta saran pelunasan
+func formatMemberReport(report *finance.MemberReport, start time.Time) string {
+	var sb strings.Builder
+	sb.WriteString("────────────────────────\n")
+	sb.WriteString(fmt.Sprintf("👥 REKAP ANGGOTA %s 👥\n", strings.ToUpper(utils.FormatMonthID(start))))
+	sb.WriteString("────────────────────────\n")
+
+	sb.WriteString("💸 PENGELUARAN PER ANGGOTA\n")
+	for i, member := range report.Members {
+		sb.WriteString(fmt.Sprintf("%d. %s: Rp %s (%d transaksi)\n",
+			i+1, member.Member, utils.FormatMoney(member.Expense), member.RecordCount))
+		if personal := member.Expense.Sub(member.Shared); personal.IsPositive() {
+			sb.WriteString(fmt.Sprintf("   bersama Rp %s, pribadi Rp %s\n",
+				utils.FormatMoney(member.Shared), utils.FormatMoney(personal)))
+		}
+		if member.Income.IsPositive() {
+			sb.WriteString(fmt.Sprintf("   pemasukan Rp %s\n", utils.FormatMoney(member.Income)))
+		}
+	}
+	if report.Unattributed.IsPositive() {
+		sb.WriteString(fmt.Sprintf("⚠️ Tanpa pencatat: Rp %s (tidak dibagi)\n", utils.FormatMoney(report.Unattributed)))
+	}
+
+	sb.WriteString("\n⚖️ SALDO PENGELUARAN BERSAMA\n")
+	sb.WriteString(fmt.Sprintf("Total Rp %s, bagian rata Rp %s untuk %d anggota\n",
+		utils.FormatMoney(report.SharedTotal), utils.FormatMoney(report.FairShare), len(report.Members)))
+	for _, member := range report.Members {
+		switch {
+		case member.Balance.IsPositive():
+			sb.WriteString(fmt.Sprintf("• %s: +Rp %s (perlu menerima)\n", member.Member, utils.FormatMoney(member.Balance)))
+		case member.Balance.IsNegative():
+			sb.WriteString(fmt.Sprintf("• %s: -Rp %s (perlu membayar)\n", member.Member, utils.FormatMoney(member.Balance.Neg())))
+		default:
+			sb.WriteString(fmt.Sprintf("• %s: impas\n", member.Member))
+		}
+	}
+
+	if len(report.Settlements) > 0 {
+		sb.WriteString("\n💱 SARAN PELUNASAN\n")
+		for _, settlement := range report.Settlements {
+			sb.WriteString(fmt.Sprintf("• %s → %s: Rp %s\n",
+				settlement.From, settlement.To, utils.FormatMoney(settlement.Amount)))
+		}
+	}
+
+	sb.WriteString("────────────────────────\n")
+	sb.WriteString(fmt.Sprintf("💡 Tandai pengeluaran pribadi dengan #%s agar tidak dibagi rata.\n", finance.PersonalTag))
+	sb.WriteString("────────────────────────")
+
+	return sb.String()
+}
@@ -0,0 +1,91 @@
+package finance
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/money"
+	"github.com/gwenziro/botopia/internal/utils"
+)
+
+// parsePeriodArgs membaca periode dari argumen: "mei", "mei 2025", "05/2025", atau "2025-05".
+// Nama bulan tanpa tahun berarti bulan tersebut yang terakhir tidak melewati bulan berjalan.
+func parsePeriodArgs(args []string, now time.Time) (time.Time, error) {
+	if len(args) == 0 {
+		return time.Time{}, fmt.Errorf("periode belum diisi")
+	}
+
+	first := strings.ToLower(strings.TrimSpace(args[0]))
+
+	// Format angka: 2025-05 atau 05/2025
+	if start, err := time.ParseInLocation(finance.PeriodLayout, first, now.Location()); err == nil {
+		return start, nil
+	}
+	if start, err := time.ParseInLocation("01/2006", first, now.Location()); err == nil {
+		return start, nil
+	}
+
+	english, ok := utils.IndoMonthsMap[first]
+	if !ok {
+		return time.Time{}, fmt.Errorf("bulan '%s' tidak dikenali", args[0])
+	}
+	month, err := time.Parse("Jan", english[:3])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("bulan '%s' tidak dikenali", args[0])
+	}
+
+	year := now.Year()
+	if len(args) > 1 {
+		year, err = strconv.Atoi(args[1])
+		if err != nil || year < 2000 || year > 2100 {
+			return time.Time{}, fmt.Errorf("tahun '%s' tidak valid", args[1])
+		}
+	} else if month.Month() > now.Month() {
+		year--
+	}
+
+	return time.Date(year, month.Month(), 1, 0, 0, 0, 0, now.Location()), nil
+}
+
+// formatPeriodSnapshot memformat ringkasan angka sebuah periode
+func formatPeriodSnapshot(snapshot *finance.PeriodSnapshot) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("💰 Pemasukan: Rp %s\n", utils.FormatMoney(snapshot.Income)))
+	sb.WriteString(fmt.Sprintf("💸 Pengeluaran: Rp %s\n", utils.FormatMoney(snapshot.Expense)))
+	sb.WriteString(fmt.Sprintf("📈 Selisih: Rp %s\n", utils.FormatMoney(snapshot.Net)))
+	sb.WriteString(fmt.Sprintf("🧾 Jumlah transaksi: %d\n", snapshot.RecordCount))
+
+	writeAmounts(&sb, "📂 PENGELUARAN PER KATEGORI", snapshot.ExpenseByCategory)
+	writeAmounts(&sb, "📂 PEMASUKAN PER KATEGORI", snapshot.IncomeByCategory)
+	writeAmounts(&sb, "🏦 PERUBAHAN SALDO PER MEDIA", snapshot.BalanceByMedia)
+
+	return sb.String()
+}
+
+// writeAmounts menulis daftar nominal per nama, diurutkan dari nominal terbesar
+func writeAmounts(sb *strings.Builder, title string, amounts map[string]money.Money) {
+	if len(amounts) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(amounts))
+	for name := range amounts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if cmp := amounts[names[i]].Cmp(amounts[names[j]]); cmp != 0 {
+			return cmp > 0
+		}
+		return names[i] < names[j]
+	})
+
+	sb.WriteString("\n" + title + "\n")
+	for _, name := range names {
+		sb.WriteString(fmt.Sprintf("• %s: Rp %s\n", name, utils.FormatMoney(amounts[name])))
+	}
+}
@@ -0,0 +1,106 @@
+package finance
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/command/common"
+	"github.com/gwenziro/botopia/internal/domain/message"
+	"github.com/gwenziro/botopia/internal/domain/repository"
+	"github.com/gwenziro/botopia/internal/domain/service"
+)
+
+// ProofCommand implementasi command untuk mengirim ulang file bukti transaksi ke chat
+type ProofCommand struct {
+	common.BaseCommand
+	financeService service.FinanceService
+	connectionRepo repository.ConnectionRepository
+}
+
+// NewProofCommand membuat instance command baru
+func NewProofCommand(financeService service.FinanceService, connectionRepo repository.ConnectionRepository) *ProofCommand {
+	cmd := &ProofCommand{
+		financeService: financeService,
+		connectionRepo: connectionRepo,
+	}
+	cmd.Name = "bukti"
+	cmd.Description = "Mengirimkan file bukti transaksi yang tersimpan berdasarkan kode transaksi."
+	cmd.Category = "Keuangan"
+	cmd.Usage = "!bukti <kode_transaksi>"
+	return cmd
+}
+
+// Execute menjalankan command
+func (c *ProofCommand) Execute(args []string, msg *message.Message) (string, error) {
+	if len(args) == 0 {
+		return "❌ Mohon sertakan kode transaksi. Contoh: !bukti k_jan25_001", nil
+	}
+
+	code := strings.ToLower(strings.TrimSpace(args[0]))
+	if !transactionCodePattern.MatchString(code) {
+		return invalidCodeMessage(code), nil
+	}
+
+	ctx, cancel := actorContext(msg, 120*time.Second)
+	defer cancel()
+
+	record, err := c.financeService.GetRecordByCode(ctx, code)
+	if err != nil {
+		if strings.Contains(err.Error(), "tidak ditemukan") {
+			return fmt.Sprintf("❌ Transaksi dengan kode %s tidak ditemukan.", code), nil
+		}
+		return fmt.Sprintf("❌ Gagal mengambil transaksi: %v", err), nil
+	}
+
+	if !record.HasProof() {
+		return fmt.Sprintf("ℹ Transaksi %s belum memiliki bukti. Gunakan !unggah untuk melampirkan bukti.", code), nil
+	}
+
+	// Lampiran yang ditautkan bisa memakai URL yang sama, cukup kirim sekali
+	sent := make(map[string]bool)
+	var failures []string
+	total := len(record.Attachments)
+
+	for i, attachment := range record.Attachments {
+		if sent[attachment.URL] {
+			continue
+		}
+
+		data, err := c.financeService.DownloadAttachment(ctx, attachment)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%d. %s: %v", i+1, attachment.FileName, err))
+			continue
+		}
+
+		mimeType := attachment.MimeType
+		if mimeType == "" || mimeType == "application/octet-stream" {
+			mimeType = http.DetectContentType(data)
+		}
+
+		media := &message.OutgoingMedia{
+			Data:     data,
+			MimeType: mimeType,
+			FileName: attachment.FileName,
+			Caption:  fmt.Sprintf("🧾 Bukti %d/%d untuk %s - %s", i+1, total, record.UniqueCode, record.Description),
+		}
+		if err := c.connectionRepo.SendMedia(ctx, msg.Chat.ID, media); err != nil {
+			failures = append(failures, fmt.Sprintf("%d. %s: %v", i+1, attachment.FileName, err))
+			continue
+		}
+
+		sent[attachment.URL] = true
+	}
+
+	if len(sent) == 0 {
+		return fmt.Sprintf("❌ Gagal mengirim bukti transaksi %s:\n%s", code, strings.Join(failures, "\n")), nil
+	}
+
+	result := fmt.Sprintf("✅ %d file bukti transaksi %s berhasil dikirim.", len(sent), code)
+	if len(failures) > 0 {
+		result += fmt.Sprintf("\n\n⚠️ Sebagian bukti gagal dikirim:\n%s", strings.Join(failures, "\n"))
+	}
+
+	return result, nil
+}
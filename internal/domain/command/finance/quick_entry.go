@@ -0,0 +1,280 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/message"
+	"github.com/gwenziro/botopia/internal/domain/money"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/utils"
+)
+
+// quickEntryLookbackDays rentang riwayat untuk menebak pasangan sumber dana dan metode pembayaran
+const quickEntryLookbackDays = 90
+
+// quickEntryLines mengambil baris entri cepat dari pesan: sisa baris pertama setelah !keluar
+// dan setiap baris berikutnya yang tidak kosong
+func quickEntryLines(text string) []string {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "!keluar") {
+		return nil
+	}
+
+	var lines []string
+	for _, line := range strings.Split(strings.TrimPrefix(text, "!keluar"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "─") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// quickEntryResult hasil pencatatan satu baris entri cepat
+type quickEntryResult struct {
+	line    string
+	record  *finance.FinanceRecord
+	pending *finance.ApprovalRequest
+	alerts  []*finance.SpendingAnomaly
+	err     error
+
+	// draft dan duplicates terisi untuk baris yang ditahan karena mirip transaksi yang sudah tercatat
+	draft      *finance.FinanceRecord
+	duplicates []*finance.FinanceRecord
+}
+
+// processQuickEntries mencatat setiap baris sebagai pengeluaran terpisah dan merangkum hasilnya.
+// Baris yang gagal tidak menghentikan baris lainnya. Baris yang mirip transaksi tercatat ditahan
+// dan baru disimpan setelah pengirim menjawab ya.
+func (c *AddExpenseCommand) processQuickEntries(lines []string, msg *message.Message) string {
+	ctx, cancel := actorContext(msg, 120*time.Second)
+	defer cancel()
+
+	config, err := c.financeService.GetConfiguration(ctx)
+	if err != nil {
+		return fmt.Sprintf("Gagal memuat konfigurasi keuangan: %v", err)
+	}
+
+	var history []*finance.FinanceRecord
+	historyLoaded := false
+
+	today := time.Now()
+	results := make([]*quickEntryResult, 0, len(lines))
+	for _, line := range lines {
+		result := &quickEntryResult{line: line}
+		results = append(results, result)
+
+		draft, err := finance.ParseQuickEntry(line, today, config)
+		if err != nil {
+			result.err = err
+			continue
+		}
+
+		// Lengkapi field yang kosong menggunakan aturan kategori, lalu dari transaksi terakhir yang serupa
+		_ = c.financeService.ApplyCategoryRules(ctx, draft)
+		if draft.PaymentMethod == "" || draft.StorageMedia == "" {
+			if !historyLoaded {
+				history, _ = c.financeService.ListRecords(ctx, &finance.RecordFilter{
+					Type: finance.TypeExpense,
+					From: today.AddDate(0, 0, -quickEntryLookbackDays),
+				})
+				historyLoaded = true
+			}
+			completeFromHistory(draft, history)
+		}
+
+		if err := quickEntryMissing(draft); err != nil {
+			result.err = err
+			continue
+		}
+		if err := c.financeService.ValidateAddExpenseParams(ctx, draft.Category, draft.PaymentMethod, draft.StorageMedia); err != nil {
+			result.err = err
+			continue
+		}
+
+		// Baris yang kemungkinan ganda ditahan, seperti konfirmasi pada pencatatan satu transaksi
+		if duplicates, err := c.financeService.FindDuplicateRecords(ctx, draft); err == nil && len(duplicates) > 0 {
+			result.draft, result.duplicates = draft, duplicates
+			continue
+		}
+
+		c.saveQuickEntry(ctx, draft, msg, result)
+	}
+
+	return formatQuickEntryResults(results) + c.askHeldQuickEntries(results, msg)
+}
+
+// askHeldQuickEntries meminta konfirmasi untuk baris yang ditahan karena kemungkinan ganda.
+// Tanpa layanan konfirmasi, baris tersebut dilewati dan hanya diberi peringatan.
+func (c *AddExpenseCommand) askHeldQuickEntries(results []*quickEntryResult, msg *message.Message) string {
+	var held []*quickEntryResult
+	for _, result := range results {
+		if result.duplicates != nil {
+			held = append(held, result)
+		}
+	}
+	if len(held) == 0 || c.confirmations == nil || msg == nil || msg.Sender == nil || msg.Chat == nil {
+		return ""
+	}
+
+	c.confirmations.Ask(service.ConfirmationKey(msg.Chat.ID, msg.Sender.Phone), &service.PendingConfirmation{
+		OnConfirm: func() string {
+			ctx, cancel := actorContext(msg, 120*time.Second)
+			defer cancel()
+
+			confirmed := make([]*quickEntryResult, 0, len(held))
+			for _, result := range held {
+				entry := &quickEntryResult{line: result.line}
+				c.saveQuickEntry(ctx, result.draft, msg, entry)
+				confirmed = append(confirmed, entry)
+			}
+			return formatQuickEntryResults(confirmed)
+		},
+		OnCancel: func() string {
+			return fmt.Sprintf("❌ %d pengeluaran yang kemungkinan ganda tidak dicatat.", len(held))
+		},
+	})
+
+	return "\nBalas *ya* untuk tetap menyimpan baris yang kemungkinan ganda atau *tidak* untuk melewatinya."
+}
+
+// saveQuickEntry menyimpan satu entri cepat, atau mengajukannya jika memerlukan persetujuan
+func (c *AddExpenseCommand) saveQuickEntry(ctx context.Context, draft *finance.FinanceRecord, msg *message.Message, result *quickEntryResult) {
+	if c.approvals != nil && msg != nil && msg.Sender != nil && c.approvals.RequiresApproval(draft, msg.Sender.Phone) {
+		request, err := c.approvals.Submit(ctx, draft, "", newRecordAuthor("", msg))
+		if err != nil {
+			result.err = fmt.Errorf("gagal mengajukan persetujuan: %v", err)
+			return
+		}
+		result.pending = request
+		return
+	}
+
+	record, err := c.financeService.AddExpenseWithDate(
+		ctx, draft.Date, draft.Description, draft.Amount, draft.Category,
+		draft.PaymentMethod, draft.StorageMedia, draft.Notes, "",
+	)
+	if err != nil {
+		result.err = err
+		return
+	}
+	result.record = record
+
+	saveRecordAuthor(ctx, c.financeService, record.UniqueCode, msg)
+	if c.anomalies != nil {
+		result.alerts, _ = c.anomalies.Inspect(ctx, record)
+	}
+}
+
+// completeFromHistory mengisi metode atau sumber dana dari transaksi terbaru yang memakai pasangannya,
+// misalnya @Gopay melengkapi metode yang biasa dipakai bersama Gopay
+func completeFromHistory(draft *finance.FinanceRecord, history []*finance.FinanceRecord) {
+	for _, record := range history {
+		switch {
+		case draft.PaymentMethod == "" && draft.StorageMedia != "" && strings.EqualFold(record.StorageMedia, draft.StorageMedia):
+			draft.PaymentMethod = record.PaymentMethod
+		case draft.StorageMedia == "" && draft.PaymentMethod != "" && strings.EqualFold(record.PaymentMethod, draft.PaymentMethod):
+			draft.StorageMedia = record.StorageMedia
+		}
+		if draft.PaymentMethod != "" && draft.StorageMedia != "" {
+			return
+		}
+	}
+}
+
+// quickEntryMissing menjelaskan field wajib yang masih kosong beserta cara mengisinya
+func quickEntryMissing(draft *finance.FinanceRecord) error {
+	var missing []string
+	if draft.Category == "" {
+		missing = append(missing, "kategori (#Kategori)")
+	}
+	if draft.StorageMedia == "" {
+		missing = append(missing, "sumber dana (@Sumber)")
+	}
+	if draft.PaymentMethod == "" {
+		missing = append(missing, "metode (@Metode)")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%s belum diisi", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// formatQuickEntryResults memformat rangkuman entri cepat: transaksi yang tercatat dan kesalahan per baris
+func formatQuickEntryResults(results []*quickEntryResult) string {
+	var saved, pending, held, failed []string
+	var total money.Money
+
+	for i, result := range results {
+		switch {
+		case result.err != nil:
+			failed = append(failed, fmt.Sprintf("%d. \"%s\"\n   %v", i+1, result.line, result.err))
+
+		case result.duplicates != nil:
+			codes := make([]string, 0, len(result.duplicates))
+			for _, duplicate := range result.duplicates {
+				codes = append(codes, duplicate.UniqueCode)
+			}
+			held = append(held, fmt.Sprintf("%d. %s — Rp %s\n   Mirip dengan %s",
+				i+1, result.draft.Description, utils.FormatMoney(result.draft.Amount), strings.Join(codes, ", ")))
+
+		case result.pending != nil:
+			draft := result.pending.Record
+			pending = append(pending, fmt.Sprintf("%d. %s — Rp %s\n   ⏳ Kode persetujuan: %s",
+				i+1, draft.Description, utils.FormatMoney(draft.Amount), result.pending.Code))
+
+		default:
+			record := result.record
+			total = total.Add(record.Amount)
+			entry := fmt.Sprintf("%d. %s — Rp %s\n   🏷 %s | 🏦 %s | 💳 %s\n   ℹ %s",
+				i+1, record.Description, utils.FormatMoney(record.Amount),
+				record.Category, record.StorageMedia, record.PaymentMethod, record.UniqueCode)
+			for _, alert := range result.alerts {
+				entry += "\n   🚨 " + alert.Describe(record)
+			}
+			saved = append(saved, entry)
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("────────────────────────\n")
+	sb.WriteString(fmt.Sprintf("🧾 %d DARI %d PENGELUARAN DICATAT 🧾\n", len(saved), len(results)))
+	sb.WriteString("────────────────────────\n")
+
+	if len(saved) > 0 {
+		sb.WriteString("✅ TERCATAT\n")
+		sb.WriteString(strings.Join(saved, "\n"))
+		sb.WriteString(fmt.Sprintf("\n\n💰 Total: Rp %s\n", utils.FormatMoney(total)))
+	}
+	if len(pending) > 0 {
+		sb.WriteString("\n⏳ MENUNGGU PERSETUJUAN\n")
+		sb.WriteString(strings.Join(pending, "\n"))
+		sb.WriteString("\n")
+	}
+	if len(held) > 0 {
+		sb.WriteString("\n⚠️ KEMUNGKINAN DATA GANDA, BELUM DICATAT\n")
+		sb.WriteString(strings.Join(held, "\n"))
+		sb.WriteString("\n")
+	}
+	if len(failed) > 0 {
+		sb.WriteString("\n❌ GAGAL\n")
+		sb.WriteString(strings.Join(failed, "\n"))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("────────────────────────\n")
+	if len(failed) > 0 {
+		sb.WriteString("💡 Format per baris: deskripsi nominal #Kategori @Sumber, contoh: kopi 15rb #Makanan @Gopay\n")
+		sb.WriteString("Kirim ulang hanya baris yang gagal setelah diperbaiki.\n")
+	} else {
+		sb.WriteString("💡 Gunakan kode transaksi untuk melampirkan bukti, atau !batal untuk membatalkan yang terakhir.\n")
+	}
+	sb.WriteString("────────────────────────")
+
+	return sb.String()
+}
@@ -0,0 +1,34 @@
+package finance
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/message"
+	"github.com/gwenziro/botopia/internal/domain/service"
+)
+
+// newRecordAuthor membuat data pembuat transaksi dari pengirim pesan (nil jika tidak diketahui)
+func newRecordAuthor(code string, msg *message.Message) *finance.RecordAuthor {
+	if msg == nil || msg.Sender == nil || msg.Chat == nil {
+		return nil
+	}
+
+	return &finance.RecordAuthor{
+		RecordCode: code,
+		UserID:     msg.Sender.ID,
+		Phone:      msg.Sender.Phone,
+		ChatID:     msg.Chat.ID,
+		Name:       msg.Sender.PushName,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// saveRecordAuthor mencatat pengirim pesan sebagai pembuat transaksi.
+// Kegagalan tidak membatalkan pencatatan, hanya pengingat bukti yang tidak terkirim.
+func saveRecordAuthor(ctx context.Context, financeService service.FinanceService, code string, msg *message.Message) {
+	if author := newRecordAuthor(code, msg); author != nil {
+		_ = financeService.SaveRecordAuthor(ctx, author)
+	}
+}
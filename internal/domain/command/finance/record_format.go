@@ -0,0 +1,72 @@
+package finance
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/gwenziro/botopia/internal/domain/dto"
+	"github.com/gwenziro/botopia/internal/domain/finance"
+)
+
+// transactionCodePattern adalah format kode transaksi (k_mmm00_000 atau m_mmm00_000)
+var transactionCodePattern = regexp.MustCompile(`^[km]_[a-z]{3}\d{2}_\d{3}$`)
+
+// invalidCodeMessage mengembalikan pesan kesalahan untuk kode transaksi yang tidak valid
+func invalidCodeMessage(code string) string {
+	return fmt.Sprintf("❌ Format kode transaksi '%s' tidak valid. Format yang benar: k_mmm00_000 atau m_mmm00_000", code)
+}
+
+// formatRecordDetail memformat seluruh data record beserta lampirannya
+func formatRecordDetail(record *finance.FinanceRecord) string {
+	recordDTO := dto.FromFinanceRecord(record)
+
+	recordType := "PENGELUARAN"
+	storageTypeText := "Sumber Dana"
+	paymentMethodText := fmt.Sprintf("💳 Metode: %s\n", record.PaymentMethod)
+	if record.Type == finance.TypeIncome {
+		recordType = "PEMASUKAN"
+		storageTypeText = "Media Penyimpanan"
+		paymentMethodText = ""
+	}
+
+	tagText := ""
+	if len(record.Tags) > 0 {
+		tagText = fmt.Sprintf("🔖 Tag: %s\n", finance.FormatTags(record.Tags))
+	}
+	if record.Member != "" {
+		tagText += fmt.Sprintf("👤 Dicatat oleh: %s\n", record.Member)
+	}
+
+	proofStatus := "Belum tersedia"
+	if record.HasProof() {
+		proofStatus = fmt.Sprintf("✅ %d lampiran\n%s", len(record.Attachments), formatAttachmentList(record.Attachments))
+	}
+
+	return fmt.Sprintf(`────────────────────────
+🔎 DETAIL %s 🔎
+────────────────────────
+ℹ Kode Transaksi: %s
+📅 Tanggal: %s
+📖 Deskripsi: %s
+💰 Jumlah: Rp %s
+🏷 Kategori: %s
+%s🏦 %s: %s
+📝 Catatan: %s
+%s🧾 Bukti Transaksi: %s
+────────────────────────
+💡 Ketik !bukti %s untuk menerima file buktinya.
+────────────────────────`,
+		recordType,
+		record.UniqueCode,
+		recordDTO.DateFormatted,
+		record.Description,
+		recordDTO.AmountText,
+		record.Category,
+		paymentMethodText,
+		storageTypeText,
+		record.StorageMedia,
+		record.Notes,
+		tagText,
+		proofStatus,
+		record.UniqueCode)
+}
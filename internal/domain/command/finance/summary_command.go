@@ -0,0 +1,172 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/command/common"
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/message"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/utils"
+)
+
+// SummaryCommand implementasi command untuk melihat ringkasan keuangan bulanan
+type SummaryCommand struct {
+	common.BaseCommand
+	financeService service.FinanceService
+	closings       service.PeriodClosingService
+}
+
+// NewSummaryCommand membuat instance command baru
+func NewSummaryCommand(financeService service.FinanceService, closings service.PeriodClosingService) *SummaryCommand {
+	cmd := &SummaryCommand{
+		financeService: financeService,
+		closings:       closings,
+	}
+	cmd.Name = "ringkasan"
+	cmd.Description = "Menampilkan ringkasan pemasukan dan pengeluaran bulanan per kategori. Bulan yang sudah tutup buku menampilkan angka saat tutup buku. Sertakan #tag untuk meringkas seluruh transaksi bertag tersebut."
+	cmd.Category = "Keuangan"
+	cmd.Usage = "!ringkasan [bulan] [tahun] | !ringkasan #tag [bulan] [tahun]"
+	return cmd
+}
+
+// Execute menjalankan command
+func (c *SummaryCommand) Execute(args []string, msg *message.Message) (string, error) {
+	// Pisahkan #tag dari argumen periode
+	var tags, periodArgs []string
+	for _, arg := range args {
+		if finance.IsTag(arg) {
+			tags = append(tags, finance.NormalizeTag(arg))
+		} else {
+			periodArgs = append(periodArgs, arg)
+		}
+	}
+
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	if len(periodArgs) > 0 {
+		parsed, err := parsePeriodArgs(periodArgs, now)
+		if err != nil {
+			return fmt.Sprintf("❌ %v. Contoh: !ringkasan mei 2025 atau !ringkasan #liburan-bali", err), nil
+		}
+		start = parsed
+	}
+	period := finance.PeriodOf(start)
+
+	ctx, cancel := actorContext(msg, 60*time.Second)
+	defer cancel()
+
+	if len(tags) > 0 {
+		var from time.Time
+		if len(periodArgs) > 0 {
+			from = start
+		}
+		return c.summarizeTags(ctx, tags, from), nil
+	}
+
+	records, err := c.financeService.ListRecords(ctx, &finance.RecordFilter{
+		From: start,
+		To:   start.AddDate(0, 1, 0).Add(-time.Nanosecond),
+	})
+	if err != nil {
+		return fmt.Sprintf("❌ Gagal memuat transaksi: %v", err), nil
+	}
+	current := finance.BuildPeriodSnapshot(records, period)
+
+	var closing *finance.PeriodClosing
+	if c.closings != nil {
+		closing, err = c.closings.GetClosing(ctx, period)
+		if err != nil {
+			return fmt.Sprintf("❌ Gagal memeriksa tutup buku: %v", err), nil
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("────────────────────────\n")
+	sb.WriteString(fmt.Sprintf("📊 RINGKASAN %s 📊\n", strings.ToUpper(utils.FormatMonthID(start))))
+	sb.WriteString("────────────────────────\n")
+
+	if closing == nil {
+		sb.WriteString(formatPeriodSnapshot(current))
+		sb.WriteString("────────────────────────")
+		return sb.String(), nil
+	}
+
+	// Periode tertutup melaporkan angka saat tutup buku
+	sb.WriteString(fmt.Sprintf("🔒 Tutup buku %s oleh %s\n\n", utils.FormatDateID(closing.ClosedAt), closing.ClosedBy))
+	sb.WriteString(formatPeriodSnapshot(closing.Snapshot))
+
+	if snapshotChanged(closing.Snapshot, current) {
+		sb.WriteString("\n⚠️ Angka saat ini berbeda dari saat tutup buku (diubah admin):\n")
+		sb.WriteString(fmt.Sprintf("Pemasukan Rp %s, pengeluaran Rp %s, %d transaksi\n",
+			utils.FormatMoney(current.Income), utils.FormatMoney(current.Expense), current.RecordCount))
+	}
+	sb.WriteString("────────────────────────")
+
+	return sb.String(), nil
+}
+
+// summarizeTags meringkas transaksi yang memiliki seluruh tag; from kosong berarti tanpa batas periode
+func (c *SummaryCommand) summarizeTags(ctx context.Context, tags []string, from time.Time) string {
+	filter := &finance.RecordFilter{Tag: tags[0]}
+	if !from.IsZero() {
+		filter.From = from
+		filter.To = from.AddDate(0, 1, 0).Add(-time.Nanosecond)
+	}
+
+	records, err := c.financeService.ListRecords(ctx, filter)
+	if err != nil {
+		return fmt.Sprintf("❌ Gagal memuat transaksi: %v", err)
+	}
+
+	matched := make([]*finance.FinanceRecord, 0, len(records))
+	for _, record := range records {
+		if hasAllTags(record, tags) {
+			matched = append(matched, record)
+		}
+	}
+
+	label := finance.FormatTags(tags)
+	if len(matched) == 0 {
+		if !from.IsZero() {
+			return fmt.Sprintf("📭 Tidak ada transaksi dengan tag %s pada %s.", label, utils.FormatMonthID(from))
+		}
+		return fmt.Sprintf("📭 Tidak ada transaksi dengan tag %s.", label)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("────────────────────────\n")
+	sb.WriteString(fmt.Sprintf("📊 RINGKASAN %s 📊\n", strings.ToUpper(label)))
+	sb.WriteString("────────────────────────\n")
+	if !from.IsZero() {
+		sb.WriteString(fmt.Sprintf("📅 Periode: %s\n\n", utils.FormatMonthID(from)))
+	} else {
+		// Record terurut dari yang terbaru
+		sb.WriteString(fmt.Sprintf("📅 %s - %s\n\n",
+			utils.FormatDateID(matched[len(matched)-1].Date), utils.FormatDateID(matched[0].Date)))
+	}
+	sb.WriteString(formatPeriodSnapshot(finance.BuildSnapshot(matched)))
+	sb.WriteString("────────────────────────")
+
+	return sb.String()
+}
+
+// hasAllTags memeriksa apakah record memiliki seluruh tag
+func hasAllTags(record *finance.FinanceRecord, tags []string) bool {
+	for _, tag := range tags {
+		if !record.HasTag(tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// snapshotChanged memeriksa apakah angka periode berubah sejak tutup buku
+func snapshotChanged(closed, current *finance.PeriodSnapshot) bool {
+	return closed.RecordCount != current.RecordCount ||
+		!closed.Income.Equal(current.Income) ||
+		!closed.Expense.Equal(current.Expense)
+}
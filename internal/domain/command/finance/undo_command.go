@@ -0,0 +1,59 @@
+package finance
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/command/common"
+	"github.com/gwenziro/botopia/internal/domain/message"
+	"github.com/gwenziro/botopia/internal/domain/service"
+)
+
+// UndoCommand implementasi command untuk membatalkan aksi terakhir pengirim
+type UndoCommand struct {
+	common.BaseCommand
+	undo         service.UndoService
+	formSessions service.FormSessionService
+}
+
+// NewUndoCommand membuat instance command baru
+func NewUndoCommand(undo service.UndoService, formSessions service.FormSessionService) *UndoCommand {
+	cmd := &UndoCommand{
+		undo:         undo,
+		formSessions: formSessions,
+	}
+	cmd.Name = "batal"
+	cmd.Description = "Membatalkan aksi terakhir kamu: menghapus transaksi yang baru dicatat atau menghapus bukti yang baru diunggah."
+	cmd.Category = "Keuangan"
+	cmd.Usage = "!batal"
+	return cmd
+}
+
+// Execute menjalankan command
+func (c *UndoCommand) Execute(args []string, msg *message.Message) (string, error) {
+	if msg.Sender == nil {
+		return "❌ Pengirim tidak dikenali.", nil
+	}
+
+	// Formulir yang sedang diisi dibatalkan lebih dulu
+	if key, ok := formSessionKey(msg); ok && c.formSessions != nil && c.formSessions.Cancel(key) {
+		return "❌ Formulir yang sedang diisi dibatalkan.", nil
+	}
+
+	ctx, cancel := actorContext(msg, 60*time.Second)
+	defer cancel()
+
+	entry, err := c.undo.Undo(ctx, msg.Sender.Phone)
+	if errors.Is(err, service.ErrNothingToUndo) {
+		return fmt.Sprintf("ℹ Tidak ada aksi yang dapat dibatalkan dalam %d menit terakhir.", int(c.undo.GetWindow().Minutes())), nil
+	}
+	if err != nil {
+		return fmt.Sprintf("❌ Gagal membatalkan aksi: %v", err), nil
+	}
+
+	return fmt.Sprintf(`↩️ Aksi terakhir dibatalkan: %s
+Dicatat pada %s.
+Kirim !batal lagi untuk membatalkan aksi sebelumnya.`,
+		entry.Description, entry.CreatedAt.Format("02/01/2006 15:04")), nil
+}
@@ -1,7 +1,6 @@
 package finance
 
 import (
-	"context"
 	"fmt"
 	"os"
 	"regexp"
@@ -19,43 +18,113 @@ import (
 type UploadProofCommand struct {
 	common.BaseCommand
 	financeService service.FinanceService
+	formSessions   service.FormSessionService
 }
 
 // NewUploadProofCommand membuat instance command baru
-func NewUploadProofCommand(financeService service.FinanceService) *UploadProofCommand {
+func NewUploadProofCommand(financeService service.FinanceService, formSessions service.FormSessionService) *UploadProofCommand {
 	cmd := &UploadProofCommand{
 		financeService: financeService,
+		formSessions:   formSessions,
 	}
 	cmd.Name = "unggah"
-	cmd.Description = "Mengunggah bukti transaksi untuk catatan yang sudah ada. Kirim !unggah untuk mendapatkan form input data."
+	cmd.Description = "Mengunggah bukti transaksi untuk catatan yang sudah ada. Kirim !unggah untuk mengisi data langkah demi langkah, atau !unggah template untuk formulir lengkap."
 	cmd.Category = "Keuangan"
-	cmd.Usage = "!unggah <kode_transaksi>"
+	cmd.Usage = "!unggah [kode_transaksi|template]"
 	return cmd
 }
 
 // Execute menjalankan command
 func (c *UploadProofCommand) Execute(args []string, msg *message.Message) (string, error) {
-	// Jika tidak ada argumen, kirimkan form template
+	// Jika tidak ada argumen, mulai formulir interaktif
 	if len(args) == 0 {
+		return c.startFormSession(msg, nil), nil
+	}
+
+	// Template formulir untuk diisi sekaligus dalam satu pesan
+	if strings.EqualFold(args[0], "template") {
 		return c.getFormTemplate(), nil
 	}
 
 	// Cek apakah pesan adalah form yang diisi
-	if isFilledForm, form := c.parseFormInput(msg.Text); isFilledForm {
-		// Validasi media
+	isFilledForm, form := c.parseFormInput(msg.Text)
+	if isFilledForm {
+		// Tanpa media, foto bukti ditanyakan melalui formulir interaktif
 		if !msg.HasMedia() {
+			if c.formSessions != nil {
+				return c.startFormSession(msg, form), nil
+			}
 			return "❌ Mohon lampirkan foto bukti transaksi untuk diunggah.", nil
 		}
 
-		// Ambil kode transaksi dari form
+		// Ambil kode transaksi dan mode unggah dari form
 		transactionCode := form["Kode unik"]
-		return c.processUpload(msg, transactionCode)
+		replace := isReplaceMode(form["Mode"])
+		return c.processUpload(msg, transactionCode, replace)
+	}
+
+	// Unggah cepat: foto dengan caption "!unggah <kode> [ganti]"
+	if code := strings.ToLower(args[0]); transactionCodePattern.MatchString(code) {
+		if !msg.HasMedia() {
+			return "❌ Mohon lampirkan foto bukti transaksi untuk diunggah.", nil
+		}
+
+		replace := len(args) > 1 && isReplaceMode(args[1])
+		return c.processUpload(msg, code, replace)
+	}
+
+	// Form yang belum lengkap dilanjutkan dengan menanyakan field yang kosong
+	if len(form) > 0 {
+		return c.startFormSession(msg, form), nil
 	}
 
 	// Jika format tidak sesuai, berikan panduan penggunaan form
 	return "❌ Format tidak sesuai. Silakan gunakan format formulir yang tersedia dengan mengirim !unggah tanpa parameter tambahan.", nil
 }
 
+// startFormSession memulai formulir interaktif unggah bukti dengan jawaban awal opsional.
+// Jika sesi tidak tersedia, template formulir dikembalikan seperti biasa.
+func (c *UploadProofCommand) startFormSession(msg *message.Message, form map[string]string) string {
+	key, ok := formSessionKey(msg)
+	if c.formSessions == nil || !ok {
+		return c.getFormTemplate()
+	}
+
+	return c.formSessions.Start(key, &service.FormSession{
+		Title: "UNGGAH BUKTI TRANSAKSI",
+		Fields: []service.FormField{
+			{
+				Key:    "Kode unik",
+				Prompt: "Kode transaksi yang akan diberi bukti?",
+				Hint:   "k_mei25_001",
+				Validate: func(answer string) (string, error) {
+					code := strings.ToLower(answer)
+					if !transactionCodePattern.MatchString(code) {
+						return "", fmt.Errorf("format kode transaksi tidak valid, contoh: k_mei25_001 atau m_mei25_001")
+					}
+					return code, nil
+				},
+			},
+			{
+				Key:     "Mode",
+				Prompt:  "Tambahkan sebagai lampiran baru atau ganti semua bukti lama?",
+				Choices: []string{"tambah", "ganti"},
+			},
+			proofFormField(false),
+		},
+		Values: prefilledValues(form),
+		OnComplete: func(values map[string]string, msg *message.Message) string {
+			mediaPath := values["Bukti"]
+			defer os.Remove(mediaPath)
+
+			return c.uploadFile(msg, values["Kode unik"], mediaPath, isReplaceMode(values["Mode"]))
+		},
+		OnCancel: func() string {
+			return "❌ Unggah bukti transaksi dibatalkan."
+		},
+	}, msg)
+}
+
 // getFormTemplate mengembalikan template form unggah bukti
 func (c *UploadProofCommand) getFormTemplate() string {
 	return `!unggah
@@ -63,8 +132,11 @@ func (c *UploadProofCommand) getFormTemplate() string {
 ℹ FORMAT UNGGAH BUKTI TRANSAKSI ℹ
 ────────────────────────
 Kode unik: 
+Mode: tambah
 
 ────────────────────────
+Mode "tambah" menambahkan lampiran baru, mode "ganti" mengganti semua bukti lama.
+Cara cepat: kirim foto dengan caption !unggah <kode_transaksi>
 Tolong kirim bukti transaksi dengan format di atas, ya! 🙏`
 }
 
@@ -79,7 +151,7 @@ func (c *UploadProofCommand) parseFormInput(text string) (bool, map[string]strin
 	form := make(map[string]string)
 
 	// Ekstrak kode unik
-	re := regexp.MustCompile(`Kode unik:\s*(.*?)(?:\n|$)`)
+	re := regexp.MustCompile(`Kode unik:[ \t]*(.*?)(?:\n|$)`)
 	match := re.FindStringSubmatch(text)
 
 	if len(match) > 1 {
@@ -88,25 +160,27 @@ func (c *UploadProofCommand) parseFormInput(text string) (bool, map[string]strin
 		form["Kode unik"] = value
 	}
 
-	// Periksa apakah field wajib terisi
+	// Ekstrak mode unggah (opsional)
+	modeRe := regexp.MustCompile(`Mode:[ \t]*(.*?)(?:\n|$)`)
+	if modeMatch := modeRe.FindStringSubmatch(text); len(modeMatch) > 1 {
+		form["Mode"] = strings.TrimSpace(modeMatch[1])
+	}
+
+	// Form yang belum lengkap tetap dikembalikan agar dapat dilanjutkan secara interaktif
 	if form["Kode unik"] == "" {
-		return false, nil
+		return false, form
 	}
 
 	return true, form
 }
 
 // processUpload memproses unggahan bukti transaksi
-func (c *UploadProofCommand) processUpload(msg *message.Message, transactionCode string) (string, error) {
+func (c *UploadProofCommand) processUpload(msg *message.Message, transactionCode string, replace bool) (string, error) {
 	// Validasi format kode transaksi (k_xxx00_000 atau m_xxx00_000)
-	validCodePattern := regexp.MustCompile(`^[km]_[a-z]{3}\d{2}_\d{3}$`)
-	if !validCodePattern.MatchString(transactionCode) {
-		return fmt.Sprintf("❌ Format kode transaksi '%s' tidak valid. Format yang benar: k_mmm00_000 atau m_mmm00_000", transactionCode), nil
+	if !transactionCodePattern.MatchString(transactionCode) {
+		return invalidCodeMessage(transactionCode), nil
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-	defer cancel()
-
 	// Unduh media
 	mediaPath, err := msg.DownloadMedia()
 	if err != nil {
@@ -116,16 +190,27 @@ func (c *UploadProofCommand) processUpload(msg *message.Message, transactionCode
 	// Pastikan file akan dihapus setelah selesai
 	defer os.Remove(mediaPath)
 
-	// Unggah bukti transaksi
-	record, err := c.financeService.UploadTransactionProof(ctx, transactionCode, mediaPath)
+	return c.uploadFile(msg, transactionCode, mediaPath, replace), nil
+}
+
+// uploadFile mengunggah file bukti yang sudah diunduh dan memformat balasannya
+func (c *UploadProofCommand) uploadFile(msg *message.Message, transactionCode, mediaPath string, replace bool) string {
+	ctx, cancel := actorContext(msg, 60*time.Second)
+	defer cancel()
+
+	// Unggah bukti transaksi, tambahkan ke lampiran atau ganti semua bukti lama
+	var record *finance.FinanceRecord
+	var err error
+	if replace {
+		record, err = c.financeService.ReplaceTransactionProof(ctx, transactionCode, mediaPath)
+	} else {
+		record, err = c.financeService.UploadTransactionProof(ctx, transactionCode, mediaPath)
+	}
 	if err != nil {
 		if strings.Contains(err.Error(), "tidak ditemukan") {
-			return fmt.Sprintf("❌ Transaksi dengan kode %s tidak ditemukan.", transactionCode), nil
-		}
-		if strings.Contains(err.Error(), "sudah memiliki bukti") {
-			return fmt.Sprintf("❌ Transaksi dengan kode %s sudah memiliki bukti transaksi.", transactionCode), nil
+			return fmt.Sprintf("❌ Transaksi dengan kode %s tidak ditemukan.", transactionCode)
 		}
-		return fmt.Sprintf("❌ Gagal mengunggah bukti transaksi: %v", err), nil
+		return fmt.Sprintf("❌ Gagal mengunggah bukti transaksi: %v", err)
 	}
 
 	// Format response sukses menggunakan format baru yang lebih user-friendly
@@ -147,8 +232,13 @@ func (c *UploadProofCommand) processUpload(msg *message.Message, transactionCode
 		storageTypeText = "Sumber Dana"
 	}
 
+	title := "✅ BUKTI TRANSAKSI BERHASIL DITAMBAHKAN ✅"
+	if replace {
+		title = "✅ BUKTI TRANSAKSI BERHASIL DIGANTI ✅"
+	}
+
 	result := fmt.Sprintf(`────────────────────────
-✅ BUKTI TRANSAKSI BERHASIL DITAMBAHKAN ✅
+%s
 ────────────────────────
 Hai, pengguna 👋!
 Bukti transaksi Anda berhasil diunggah.
@@ -160,9 +250,10 @@ Bukti transaksi Anda berhasil diunggah.
 🏷 Kategori: %s
 %s🏦 %s: %s
 📝 Catatan: %s
-📄 Bukti Transaksi: ✅ Tersedia
-🔗 %s
+📄 Bukti Transaksi: ✅ %d lampiran
+%s
 ────────────────────────`,
+		title,
 		recordType,
 		recordDTO.DateFormatted,
 		record.Description,
@@ -172,7 +263,18 @@ Bukti transaksi Anda berhasil diunggah.
 		storageTypeText,
 		record.StorageMedia,
 		record.Notes,
-		record.ProofURL)
+		len(record.Attachments),
+		formatAttachmentList(record.Attachments))
 
-	return result, nil
+	return result
+}
+
+// isReplaceMode memeriksa apakah mode unggah meminta penggantian bukti lama
+func isReplaceMode(mode string) bool {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "ganti", "timpa", "replace":
+		return true
+	default:
+		return false
+	}
 }
@@ -0,0 +1,143 @@
+package finance
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/command/common"
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/message"
+	"github.com/gwenziro/botopia/internal/domain/service"
+	"github.com/gwenziro/botopia/internal/utils"
+)
+
+// ZakatCommand implementasi command ringkasan zakat dan pajak tahunan
+type ZakatCommand struct {
+	common.BaseCommand
+	zakatService service.ZakatService
+}
+
+// NewZakatCommand membuat instance command baru
+func NewZakatCommand(zakatService service.ZakatService) *ZakatCommand {
+	cmd := &ZakatCommand{
+		zakatService: zakatService,
+	}
+	cmd.Name = "zakat"
+	cmd.Description = "Menampilkan ringkasan tahunan untuk zakat dan pelaporan pajak: total pemasukan per kategori, saldo harta per media di akhir tahun, serta perkiraan zakat penghasilan dan zakat maal terhadap nisab. Harga emas untuk nisab diisi manual dengan !zakat emas."
+	cmd.Category = "Keuangan"
+	cmd.Usage = "!zakat [tahun] | !zakat emas <harga per gram>"
+	return cmd
+}
+
+// Execute menjalankan command
+func (c *ZakatCommand) Execute(args []string, msg *message.Message) (string, error) {
+	ctx, cancel := actorContext(msg, 60*time.Second)
+	defer cancel()
+
+	year := time.Now().Year()
+	if len(args) > 0 {
+		if strings.EqualFold(args[0], "emas") {
+			if len(args) < 2 {
+				return "❌ Harga emas belum diisi. Contoh: !zakat emas 1500000", nil
+			}
+			price, err := utils.ParseMoney(strings.Join(args[1:], ""))
+			if err != nil || !price.IsPositive() {
+				return "❌ Harga emas tidak valid. Contoh: !zakat emas 1500000", nil
+			}
+
+			settings, err := c.zakatService.SetGoldPrice(ctx, price)
+			if err != nil {
+				return fmt.Sprintf("❌ Gagal menyimpan harga emas: %v", err), nil
+			}
+			return fmt.Sprintf("✅ Harga emas disimpan: Rp %s/gram\n⚖️ Nisab (%g gram): Rp %s\nKetik !zakat untuk melihat perkiraan zakat.",
+				utils.FormatMoney(settings.GoldPricePerGram), settings.NisabGrams, utils.FormatMoney(settings.Nisab())), nil
+		}
+
+		parsed, err := strconv.Atoi(args[0])
+		if err != nil {
+			return "❌ Tahun tidak valid. Contoh: !zakat 2024", nil
+		}
+		year = parsed
+	}
+
+	report, err := c.zakatService.Report(ctx, year)
+	if err != nil {
+		return fmt.Sprintf("❌ Gagal menyusun laporan zakat: %v", err), nil
+	}
+
+	return formatZakatReport(report), nil
+}
+
+// formatZakatReport memformat ringkasan zakat dan pajak tahunan
+func formatZakatReport(report *finance.ZakatReport) string {
+	var sb strings.Builder
+	sb.WriteString("────────────────────────\n")
+	sb.WriteString(fmt.Sprintf("🕌 LAPORAN ZAKAT & PAJAK %d 🕌\n", report.Year))
+	sb.WriteString("────────────────────────\n")
+
+	sb.WriteString(fmt.Sprintf("💰 Total pemasukan: Rp %s\n", utils.FormatMoney(report.TotalIncome)))
+	sb.WriteString(fmt.Sprintf("💸 Total pengeluaran: Rp %s\n", utils.FormatMoney(report.TotalExpense)))
+	if len(report.IncomeByCategory) > 0 {
+		sb.WriteString("\n📂 PEMASUKAN PER KATEGORI\n")
+		for _, item := range report.IncomeByCategory {
+			sb.WriteString(fmt.Sprintf("• %s: Rp %s%s\n", item.Name, utils.FormatMoney(item.Amount), excludedNote(item)))
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("\n🏦 HARTA PER MEDIA (%s)\n", utils.FormatDateID(report.AsOf)))
+	if len(report.AssetsByMedia) == 0 {
+		sb.WriteString("Belum ada saldo tercatat.\n")
+	}
+	for _, item := range report.AssetsByMedia {
+		sb.WriteString(fmt.Sprintf("• %s: Rp %s%s\n", item.Name, utils.FormatMoney(item.Amount), excludedNote(item)))
+	}
+	sb.WriteString(fmt.Sprintf("Total harta: Rp %s\n", utils.FormatMoney(report.TotalAssets)))
+
+	sb.WriteString("\n⚖️ PERKIRAAN ZAKAT\n")
+	if !report.NisabKnown {
+		sb.WriteString("Harga emas belum diisi sehingga nisab belum dapat dihitung.\n")
+		sb.WriteString("Ketik !zakat emas <harga per gram>, contoh: !zakat emas 1500000\n")
+	} else {
+		settings := report.Settings
+		sb.WriteString(fmt.Sprintf("Nisab %g gram × Rp %s = Rp %s\n",
+			settings.NisabGrams, utils.FormatMoney(settings.GoldPricePerGram), utils.FormatMoney(report.Nisab)))
+
+		if settings.AnnualIncome {
+			sb.WriteString(fmt.Sprintf("Zakat penghasilan (%g%% dari Rp %s setahun): Rp %s\n",
+				settings.RatePercent, utils.FormatMoney(report.ZakatableIncome), utils.FormatMoney(report.IncomeZakat)))
+		} else {
+			reached := 0
+			for _, month := range report.Months {
+				if month.ReachesNisab {
+					reached++
+				}
+			}
+			sb.WriteString(fmt.Sprintf("Zakat penghasilan (%g%%, %d bulan ≥ nisab bulanan Rp %s): Rp %s\n",
+				settings.RatePercent, reached, utils.FormatMoney(report.MonthlyNisab), utils.FormatMoney(report.IncomeZakat)))
+		}
+
+		if report.MaalReachesNisab {
+			sb.WriteString(fmt.Sprintf("Zakat maal (%g%% dari harta): Rp %s\n",
+				settings.RatePercent, utils.FormatMoney(report.MaalZakat)))
+		} else {
+			sb.WriteString("Zakat maal: harta belum mencapai nisab\n")
+		}
+		sb.WriteString(fmt.Sprintf("🧮 Total perkiraan: Rp %s\n", utils.FormatMoney(report.TotalZakat())))
+	}
+
+	sb.WriteString("────────────────────────\n")
+	sb.WriteString("💡 Perkiraan ini menganggap saldo akhir tahun sudah tersimpan satu haul. Aturan dan laporan cetak tersedia di halaman Laporan.\n")
+	sb.WriteString("────────────────────────")
+
+	return sb.String()
+}
+
+// excludedNote menandai kategori atau media yang tidak dihitung zakat
+func excludedNote(item *finance.NamedAmount) string {
+	if item.Excluded {
+		return " (dikecualikan)"
+	}
+	return ""
+}
@@ -11,6 +11,9 @@ type Contact struct {
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 	IsActive  bool      `json:"isActive"` // Digunakan untuk whitelist
+
+	// LedgerID ledger keuangan kontak atau grup; kosong berarti memakai ledger utama
+	LedgerID string `json:"ledgerId,omitempty"`
 }
 
 // NewContact membuat instance kontak baru
@@ -4,25 +4,29 @@ import (
 	"time"
 
 	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/money"
 	"github.com/gwenziro/botopia/internal/utils"
 )
 
 // FinanceRecordDTO adalah DTO untuk record keuangan
 type FinanceRecordDTO struct {
-	UniqueCode    string    `json:"uniqueCode"`
-	Date          time.Time `json:"date"`
-	DateFormatted string    `json:"dateFormatted"`
-	Description   string    `json:"description"`
-	Amount        float64   `json:"amount"`
-	AmountText    string    `json:"amountText"`
-	Category      string    `json:"category"`
-	PaymentMethod string    `json:"paymentMethod,omitempty"`
-	StorageMedia  string    `json:"storageMedia"`
-	Notes         string    `json:"notes"`
-	ProofURL      string    `json:"proofUrl"`
-	HasProof      bool      `json:"hasProof"`
-	Type          string    `json:"type"`
-	TypeText      string    `json:"typeText"`
+	UniqueCode    string                `json:"uniqueCode"`
+	Date          time.Time             `json:"date"`
+	DateFormatted string                `json:"dateFormatted"`
+	Description   string                `json:"description"`
+	Amount        money.Money           `json:"amount"`
+	AmountText    string                `json:"amountText"`
+	Category      string                `json:"category"`
+	PaymentMethod string                `json:"paymentMethod,omitempty"`
+	StorageMedia  string                `json:"storageMedia"`
+	Notes         string                `json:"notes"`
+	Tags          []string              `json:"tags"`
+	Member        string                `json:"member,omitempty"`
+	ProofURL      string                `json:"proofUrl"`
+	HasProof      bool                  `json:"hasProof"`
+	Attachments   []*finance.Attachment `json:"attachments"`
+	Type          string                `json:"type"`
+	TypeText      string                `json:"typeText"`
 }
 
 // FromFinanceRecord mengkonversi domain model ke DTO
@@ -47,8 +51,11 @@ func FromFinanceRecord(record *finance.FinanceRecord) *FinanceRecordDTO {
 		PaymentMethod: record.PaymentMethod,
 		StorageMedia:  record.StorageMedia,
 		Notes:         record.Notes,
+		Tags:          record.Tags,
+		Member:        record.Member,
 		ProofURL:      record.ProofURL,
-		HasProof:      record.ProofURL != "" && record.ProofURL != "-",
+		HasProof:      record.HasProof(),
+		Attachments:   record.Attachments,
 		Type:          string(record.Type),
 		TypeText:      typeText,
 	}
@@ -8,8 +8,9 @@ type ValidationError struct {
 	Message string
 }
 
+// Error mengembalikan pesan validasi apa adanya karena pesan sudah menyebut field yang bermasalah
 func (e ValidationError) Error() string {
-	return fmt.Sprintf("validation error on %s: %s", e.Field, e.Message)
+	return e.Message
 }
 
 // NewValidationError membuat error validasi baru
@@ -44,3 +45,23 @@ func (e DuplicateProofError) Error() string {
 func NewDuplicateProofError(code string) DuplicateProofError {
 	return DuplicateProofError{Code: code}
 }
+
+// PeriodClosedError merepresentasikan perubahan transaksi pada periode yang sudah tutup buku
+type PeriodClosedError struct {
+	Period    string
+	Operation string
+	Hint      string
+}
+
+func (e PeriodClosedError) Error() string {
+	message := fmt.Sprintf("periode %s sudah tutup buku, transaksi pada periode ini tidak dapat %s", e.Period, e.Operation)
+	if e.Hint != "" {
+		message += ". " + e.Hint
+	}
+	return message
+}
+
+// NewPeriodClosedError membuat error periode sudah tutup buku
+func NewPeriodClosedError(period, operation, hint string) PeriodClosedError {
+	return PeriodClosedError{Period: period, Operation: operation, Hint: hint}
+}
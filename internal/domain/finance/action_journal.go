@@ -0,0 +1,64 @@
+package finance
+
+import "time"
+
+// UndoKind jenis operasi kebalikan untuk membatalkan sebuah aksi
+type UndoKind string
+
+const (
+	// UndoDeleteRecord menghapus transaksi yang baru ditambahkan
+	UndoDeleteRecord UndoKind = "delete_record"
+
+	// UndoRestoreProof mengembalikan bukti transaksi ke kondisi sebelum diunggah
+	UndoRestoreProof UndoKind = "restore_proof"
+
+	// UndoRestoreConfiguration mengembalikan konfigurasi keuangan sebelumnya
+	UndoRestoreConfiguration UndoKind = "restore_configuration"
+
+	// UndoDeleteCategoryRule menghapus aturan kategori yang baru ditambahkan
+	UndoDeleteCategoryRule UndoKind = "delete_category_rule"
+
+	// UndoRestoreCategoryRule mengembalikan aturan kategori yang diubah atau dihapus
+	UndoRestoreCategoryRule UndoKind = "restore_category_rule"
+)
+
+// JournalEntry mencatat aksi yang mengubah data beserta cara membatalkannya
+type JournalEntry struct {
+	ID string `json:"id"`
+
+	// Phone nomor pengirim WhatsApp, atau actor dashboard (misalnya "web:admin") untuk aksi dari web
+	Phone       string    `json:"phone"`
+	ChatID      string    `json:"chat_id,omitempty"`
+	Kind        UndoKind  `json:"kind"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	// Ledger tempat aksi dilakukan; kosong berarti ledger utama
+	Ledger string `json:"ledger,omitempty"`
+
+	// RecordCode kode transaksi yang terdampak
+	RecordCode string `json:"record_code,omitempty"`
+
+	// ProofURL dan Attachments adalah kondisi bukti sebelum aksi (untuk UndoRestoreProof)
+	ProofURL    string        `json:"proof_url,omitempty"`
+	Attachments []*Attachment `json:"attachments,omitempty"`
+
+	// Configuration adalah konfigurasi sebelum aksi (untuk UndoRestoreConfiguration)
+	Configuration *Configuration `json:"configuration,omitempty"`
+
+	// RuleID aturan kategori yang terdampak; CategoryRule adalah kondisi aturan sebelum aksi
+	// (untuk UndoRestoreCategoryRule)
+	RuleID       string        `json:"rule_id,omitempty"`
+	CategoryRule *CategoryRule `json:"category_rule,omitempty"`
+
+	// UndoneAt waktu aksi dibatalkan (nil jika belum)
+	UndoneAt *time.Time `json:"undone_at,omitempty"`
+}
+
+// IsUndoable memeriksa apakah aksi belum dibatalkan dan masih dalam batas waktu
+func (e *JournalEntry) IsUndoable(now time.Time, window time.Duration) bool {
+	if e.UndoneAt != nil {
+		return false
+	}
+	return window <= 0 || now.Sub(e.CreatedAt) <= window
+}
@@ -0,0 +1,202 @@
+package finance
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/money"
+)
+
+// AnomalyKind jenis kejanggalan pengeluaran
+type AnomalyKind string
+
+const (
+	// AnomalyCategorySpike nominal jauh di atas median pengeluaran pengirim pada kategori yang sama
+	AnomalyCategorySpike AnomalyKind = "category_spike"
+
+	// AnomalyNewMerchant pengeluaran besar untuk deskripsi yang belum pernah dicatat pengirim
+	AnomalyNewMerchant AnomalyKind = "new_merchant"
+
+	// AnomalyRunRate laju pengeluaran kategori bulan berjalan melampaui rata-rata bulanan
+	AnomalyRunRate AnomalyKind = "run_rate"
+)
+
+// minRunRateDays hari minimal berjalan sebelum pengeluaran bulan ini diproyeksikan ke akhir bulan;
+// sebelumnya total berjalan dibandingkan apa adanya agar awal bulan tidak memicu peringatan palsu
+const minRunRateDays = 7
+
+// AnomalyPolicy aturan deteksi pengeluaran yang tidak biasa
+type AnomalyPolicy struct {
+	// MedianMultiplier kelipatan median kategori yang dianggap tidak biasa (0 berarti nonaktif)
+	MedianMultiplier float64
+
+	// MinHistory jumlah minimal riwayat kategori sebelum median dipakai
+	MinHistory int
+
+	// NewMerchantAmount nominal minimal pengeluaran pertama untuk deskripsi baru (0 berarti nonaktif)
+	NewMerchantAmount money.Money
+
+	// RunRateMultiplier kelipatan rata-rata bulanan kategori untuk proyeksi bulan berjalan (0 berarti nonaktif)
+	RunRateMultiplier float64
+
+	// RunRateMonths jumlah bulan penuh sebelumnya sebagai dasar rata-rata bulanan
+	RunRateMonths int
+}
+
+// SpendingAnomaly kejanggalan yang ditemukan pada sebuah pengeluaran
+type SpendingAnomaly struct {
+	Kind AnomalyKind
+
+	// Actual nominal yang diperiksa: nominal transaksi, atau proyeksi bulan berjalan untuk AnomalyRunRate
+	Actual money.Money
+
+	// Baseline pembanding: median kategori atau rata-rata bulanan; nol untuk AnomalyNewMerchant
+	Baseline money.Money
+}
+
+// DetectAnomalies membandingkan pengeluaran baru dengan riwayat pengirimnya.
+// history boleh berisi record itu sendiri; record dengan kode yang sama diabaikan.
+func (p *AnomalyPolicy) DetectAnomalies(record *FinanceRecord, history []*FinanceRecord) []*SpendingAnomaly {
+	if record == nil || record.Type != TypeExpense || !record.Amount.IsPositive() {
+		return nil
+	}
+
+	own := senderHistory(record, history)
+	var anomalies []*SpendingAnomaly
+
+	if anomaly := p.categorySpike(record, own); anomaly != nil {
+		anomalies = append(anomalies, anomaly)
+	}
+	if anomaly := p.newMerchant(record, own); anomaly != nil {
+		anomalies = append(anomalies, anomaly)
+	}
+	if anomaly := p.runRate(record, own); anomaly != nil {
+		anomalies = append(anomalies, anomaly)
+	}
+
+	return anomalies
+}
+
+// senderHistory menyaring pengeluaran sebelumnya milik anggota yang sama; tanpa anggota, seluruh ledger dipakai
+func senderHistory(record *FinanceRecord, history []*FinanceRecord) []*FinanceRecord {
+	member := strings.TrimSpace(record.Member)
+
+	var own []*FinanceRecord
+	for _, existing := range history {
+		if existing.Type != TypeExpense || existing.Date.After(record.Date) {
+			continue
+		}
+		if record.UniqueCode != "" && existing.UniqueCode == record.UniqueCode {
+			continue
+		}
+		if member != "" && !strings.EqualFold(strings.TrimSpace(existing.Member), member) {
+			continue
+		}
+		own = append(own, existing)
+	}
+	return own
+}
+
+// categorySpike memeriksa nominal terhadap median pengeluaran kategori yang sama
+func (p *AnomalyPolicy) categorySpike(record *FinanceRecord, own []*FinanceRecord) *SpendingAnomaly {
+	if p.MedianMultiplier <= 0 {
+		return nil
+	}
+
+	var amounts []money.Money
+	for _, existing := range own {
+		if strings.EqualFold(existing.Category, record.Category) {
+			amounts = append(amounts, existing.Amount)
+		}
+	}
+	if len(amounts) == 0 || len(amounts) < p.MinHistory {
+		return nil
+	}
+
+	baseline := median(amounts)
+	if !baseline.IsPositive() || record.Amount.Cmp(baseline.MulFloat(p.MedianMultiplier)) <= 0 {
+		return nil
+	}
+
+	return &SpendingAnomaly{Kind: AnomalyCategorySpike, Actual: record.Amount, Baseline: baseline}
+}
+
+// newMerchant memeriksa pengeluaran besar pertama untuk deskripsi yang belum pernah dicatat
+func (p *AnomalyPolicy) newMerchant(record *FinanceRecord, own []*FinanceRecord) *SpendingAnomaly {
+	if !p.NewMerchantAmount.IsPositive() || record.Amount.LessThan(p.NewMerchantAmount) {
+		return nil
+	}
+
+	// Tanpa riwayat sama sekali semua deskripsi terlihat baru, peringatan belum bermakna
+	if len(own) == 0 {
+		return nil
+	}
+
+	for _, existing := range own {
+		if DescriptionSimilarity(existing.Description, record.Description) >= minDescriptionSimilarity {
+			return nil
+		}
+	}
+
+	return &SpendingAnomaly{Kind: AnomalyNewMerchant, Actual: record.Amount}
+}
+
+// runRate memproyeksikan pengeluaran kategori bulan berjalan dan membandingkannya dengan rata-rata bulanan
+func (p *AnomalyPolicy) runRate(record *FinanceRecord, own []*FinanceRecord) *SpendingAnomaly {
+	if p.RunRateMultiplier <= 0 || p.RunRateMonths <= 0 {
+		return nil
+	}
+
+	monthStart := time.Date(record.Date.Year(), record.Date.Month(), 1, 0, 0, 0, 0, record.Date.Location())
+	baseStart := monthStart.AddDate(0, -p.RunRateMonths, 0)
+
+	monthToDate := record.Amount
+	var baseTotal money.Money
+	hasBase := false
+	for _, existing := range own {
+		if !strings.EqualFold(existing.Category, record.Category) {
+			continue
+		}
+		switch {
+		case !existing.Date.Before(monthStart):
+			monthToDate = monthToDate.Add(existing.Amount)
+		case !existing.Date.Before(baseStart):
+			baseTotal = baseTotal.Add(existing.Amount)
+			hasBase = true
+		}
+	}
+	if !hasBase {
+		return nil
+	}
+
+	baseline := baseTotal.Div(int64(p.RunRateMonths)).Round()
+	projected := monthToDate
+	if day := record.Date.Day(); day >= minRunRateDays {
+		projected = monthToDate.MulFloat(float64(endOfMonth(record.Date).Day()) / float64(day)).Round()
+	}
+
+	// Proyeksi dari satu-dua transaksi sangat fluktuatif, jadi total berjalan juga harus sudah melewati rata-rata
+	if !baseline.IsPositive() || monthToDate.Cmp(baseline) <= 0 ||
+		projected.Cmp(baseline.MulFloat(p.RunRateMultiplier)) <= 0 {
+		return nil
+	}
+
+	return &SpendingAnomaly{Kind: AnomalyRunRate, Actual: projected, Baseline: baseline}
+}
+
+// Describe menjelaskan kejanggalan dalam satu kalimat untuk pesan WhatsApp
+func (a *SpendingAnomaly) Describe(record *FinanceRecord) string {
+	switch a.Kind {
+	case AnomalyCategorySpike:
+		return fmt.Sprintf("Rp %s jauh di atas biasanya untuk kategori %s (median Rp %s)",
+			a.Actual.Format(), record.Category, a.Baseline.Format())
+	case AnomalyNewMerchant:
+		return fmt.Sprintf("Pengeluaran besar pertama untuk \"%s\"", record.Description)
+	case AnomalyRunRate:
+		return fmt.Sprintf("Pengeluaran %s bulan ini diperkirakan Rp %s, di atas rata-rata bulanan Rp %s",
+			record.Category, a.Actual.Format(), a.Baseline.Format())
+	default:
+		return string(a.Kind)
+	}
+}
@@ -0,0 +1,55 @@
+package finance
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ApprovalStatus status pengajuan persetujuan pengeluaran
+type ApprovalStatus string
+
+const (
+	// ApprovalPending menunggu keputusan approver
+	ApprovalPending ApprovalStatus = "pending"
+
+	// ApprovalApproved disetujui dan sudah dicatat ke pembukuan
+	ApprovalApproved ApprovalStatus = "approved"
+
+	// ApprovalRejected ditolak approver
+	ApprovalRejected ApprovalStatus = "rejected"
+)
+
+// ApprovalRequest merepresentasikan pengeluaran besar yang menunggu persetujuan
+type ApprovalRequest struct {
+	Code      string         `json:"code"`
+	Record    *FinanceRecord `json:"record"`
+	ProofPath string         `json:"proof_path,omitempty"`
+	Requester *RecordAuthor  `json:"requester"`
+	Status    ApprovalStatus `json:"status"`
+	CreatedAt time.Time      `json:"created_at"`
+
+	// Ledger tujuan pencatatan setelah disetujui; kosong berarti ledger utama
+	Ledger string `json:"ledger,omitempty"`
+
+	// Diisi setelah ada keputusan
+	DecidedBy  string    `json:"decided_by,omitempty"`
+	DecidedAt  time.Time `json:"decided_at,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+	RecordCode string    `json:"record_code,omitempty"`
+}
+
+// IsPending memeriksa apakah pengajuan masih menunggu keputusan
+func (r *ApprovalRequest) IsPending() bool {
+	return r.Status == ApprovalPending
+}
+
+// ApprovalCodePrefix mengembalikan awalan kode persetujuan untuk bulan tertentu (p_mmm00_)
+func ApprovalCodePrefix(date time.Time) string {
+	return fmt.Sprintf("p_%s%02d_", GetMonthAbbr(date.Month()), date.Year()%100)
+}
+
+// IsApprovalCode memeriksa apakah kode merupakan kode persetujuan
+func IsApprovalCode(code string) bool {
+	return strings.HasPrefix(code, "p_")
+}
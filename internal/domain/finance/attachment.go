@@ -0,0 +1,74 @@
+package finance
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Attachment merepresentasikan satu lampiran bukti transaksi
+type Attachment struct {
+	RecordCode string    `json:"recordCode"`
+	FileName   string    `json:"fileName"`
+	MimeType   string    `json:"mimeType"`
+	URL        string    `json:"url"`
+	UploadedAt time.Time `json:"uploadedAt"`
+
+	// Hash konten file untuk mendeteksi bukti yang sama
+	Hash string `json:"hash,omitempty"`
+
+	// LinkedFrom berisi kode transaksi asal jika file yang sama sudah pernah diunggah
+	LinkedFrom string `json:"linkedFrom,omitempty"`
+}
+
+// ProcessedProof adalah hasil pemrosesan file bukti sebelum diunggah
+type ProcessedProof struct {
+	// Path file hasil pemrosesan (bisa sama dengan file asli)
+	Path string
+
+	// Hash SHA-256 dari konten file asli
+	Hash string
+
+	OriginalSize int64
+	Size         int64
+	Width        int
+	Height       int
+	Normalized   bool
+}
+
+// NewAttachment membuat lampiran baru untuk transaksi
+func NewAttachment(recordCode, filePath, url string) *Attachment {
+	return &Attachment{
+		RecordCode: recordCode,
+		FileName:   filepath.Base(filePath),
+		MimeType:   MimeTypeFromPath(filePath),
+		URL:        url,
+		UploadedAt: time.Now(),
+	}
+}
+
+// IsImage memeriksa apakah lampiran berupa gambar
+func (a *Attachment) IsImage() bool {
+	return strings.HasPrefix(a.MimeType, "image/")
+}
+
+// MimeTypeFromPath menebak tipe MIME dari ekstensi file
+func MimeTypeFromPath(filePath string) string {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".png":
+		return "image/png"
+	case ".webp":
+		return "image/webp"
+	case ".pdf":
+		return "application/pdf"
+	case ".mp4":
+		return "video/mp4"
+	default:
+		return "image/jpeg"
+	}
+}
+
+// HasProof memeriksa apakah record memiliki minimal satu bukti transaksi
+func (r *FinanceRecord) HasProof() bool {
+	return len(r.Attachments) > 0 || (r.ProofURL != "" && r.ProofURL != "-")
+}
@@ -0,0 +1,143 @@
+package finance
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CategoryRule merepresentasikan aturan kategorisasi otomatis berbasis kata kunci
+type CategoryRule struct {
+	ID       string     `json:"id"`
+	Name     string     `json:"name"`
+	Keywords []string   `json:"keywords"`
+	Type     RecordType `json:"type"` // Kosong berarti berlaku untuk pemasukan & pengeluaran
+
+	// Nilai yang diisikan ketika aturan cocok
+	Category      string `json:"category"`
+	PaymentMethod string `json:"paymentMethod"`
+	StorageMedia  string `json:"storageMedia"`
+
+	// Override menimpa nilai yang sudah diisi pengguna
+	Override bool `json:"override"`
+	Priority int  `json:"priority"`
+	IsActive bool `json:"isActive"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// CategorySuggestion adalah saran aturan yang dipelajari dari riwayat transaksi
+type CategorySuggestion struct {
+	Keyword    string     `json:"keyword"`
+	Type       RecordType `json:"type"`
+	Category   string     `json:"category"`
+	Count      int        `json:"count"`
+	Confidence float64    `json:"confidence"`
+}
+
+// RuleTestResult berisi hasil pengujian aturan terhadap riwayat transaksi
+type RuleTestResult struct {
+	TotalRecords int              `json:"totalRecords"`
+	MatchCount   int              `json:"matchCount"`
+	ChangedCount int              `json:"changedCount"`
+	Samples      []*FinanceRecord `json:"samples"`
+}
+
+// NewCategoryRule membuat instance aturan kategori baru
+func NewCategoryRule(name string, keywords []string) *CategoryRule {
+	now := time.Now()
+	return &CategoryRule{
+		ID:        fmt.Sprintf("rule_%d", now.UnixNano()),
+		Name:      name,
+		Keywords:  NormalizeKeywords(keywords),
+		IsActive:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// NormalizeKeywords merapikan daftar kata kunci (huruf kecil, tanpa duplikat)
+func NormalizeKeywords(keywords []string) []string {
+	seen := make(map[string]bool)
+	result := make([]string, 0, len(keywords))
+
+	for _, keyword := range keywords {
+		keyword = strings.ToLower(strings.TrimSpace(keyword))
+		if keyword == "" || seen[keyword] {
+			continue
+		}
+		seen[keyword] = true
+		result = append(result, keyword)
+	}
+
+	return result
+}
+
+// Validate memvalidasi aturan kategori
+func (r *CategoryRule) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("nama aturan harus diisi")
+	}
+
+	if len(r.Keywords) == 0 {
+		return fmt.Errorf("minimal satu kata kunci harus diisi")
+	}
+
+	if r.Type != "" && r.Type != TypeExpense && r.Type != TypeIncome {
+		return fmt.Errorf("tipe aturan '%s' tidak valid", r.Type)
+	}
+
+	if r.Category == "" && r.PaymentMethod == "" && r.StorageMedia == "" {
+		return fmt.Errorf("aturan harus mengisi minimal salah satu dari kategori, metode, atau sumber")
+	}
+
+	return nil
+}
+
+// AppliesTo memeriksa apakah aturan berlaku untuk tipe record tertentu
+func (r *CategoryRule) AppliesTo(typ RecordType) bool {
+	return r.Type == "" || r.Type == typ
+}
+
+// Matches memeriksa apakah deskripsi mengandung salah satu kata kunci aturan
+func (r *CategoryRule) Matches(typ RecordType, description string) bool {
+	if !r.IsActive || !r.AppliesTo(typ) {
+		return false
+	}
+
+	description = strings.ToLower(description)
+	for _, keyword := range r.Keywords {
+		if keyword != "" && strings.Contains(description, keyword) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Apply mengisi field record sesuai aturan dan mengembalikan true jika ada yang berubah.
+// allowOverride=false membuat aturan hanya mengisi field yang masih kosong.
+func (r *CategoryRule) Apply(record *FinanceRecord, allowOverride bool) bool {
+	changed := false
+
+	assign := func(field *string, value string) {
+		if value == "" || *field == value {
+			return
+		}
+		if *field == "" || (r.Override && allowOverride) {
+			*field = value
+			changed = true
+		}
+	}
+
+	assign(&record.Category, r.Category)
+	assign(&record.StorageMedia, r.StorageMedia)
+
+	// Metode pembayaran hanya relevan untuk pengeluaran
+	if record.Type == TypeExpense {
+		assign(&record.PaymentMethod, r.PaymentMethod)
+	}
+
+	return changed
+}
@@ -8,4 +8,7 @@ type Configuration struct {
 	PaymentMethods    []string
 	ExpenseCategories []string
 	IncomeCategories  []string
+
+	// Spreadsheets peta tahun awal partisi ke ID spreadsheet yang menyimpan transaksinya
+	Spreadsheets map[int]string
 }
@@ -0,0 +1,84 @@
+package finance
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DefaultDuplicateWindowDays adalah rentang hari default untuk mendeteksi transaksi ganda
+const DefaultDuplicateWindowDays = 3
+
+// minDescriptionSimilarity adalah kemiripan minimal deskripsi agar dianggap transaksi yang sama
+const minDescriptionSimilarity = 0.5
+
+// IsPossibleDuplicateOf memeriksa apakah record kemungkinan sama dengan record lain:
+// tipe dan nominal sama, tanggal berdekatan, dan deskripsi mirip
+func (r *FinanceRecord) IsPossibleDuplicateOf(other *FinanceRecord, windowDays int) bool {
+	if other == nil || r.Type != other.Type {
+		return false
+	}
+
+	if !r.Amount.Equal(other.Amount) {
+		return false
+	}
+
+	if daysBetween(r.Date, other.Date) > windowDays {
+		return false
+	}
+
+	return DescriptionSimilarity(r.Description, other.Description) >= minDescriptionSimilarity
+}
+
+// DescriptionSimilarity menghitung kemiripan dua deskripsi (0 sampai 1)
+// berdasarkan kata yang sama di kedua deskripsi
+func DescriptionSimilarity(a, b string) float64 {
+	a = strings.ToLower(strings.TrimSpace(a))
+	b = strings.ToLower(strings.TrimSpace(b))
+
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b || strings.Contains(a, b) || strings.Contains(b, a) {
+		return 1
+	}
+
+	wordsA := descriptionWords(a)
+	wordsB := descriptionWords(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for word := range wordsA {
+		if wordsB[word] {
+			shared++
+		}
+	}
+
+	union := len(wordsA) + len(wordsB) - shared
+	return float64(shared) / float64(union)
+}
+
+// descriptionWords memecah deskripsi menjadi himpunan kata
+func descriptionWords(text string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		words[word] = true
+	}
+	return words
+}
+
+// daysBetween menghitung selisih hari kalender antara dua tanggal
+func daysBetween(a, b time.Time) int {
+	dayA := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	dayB := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+
+	days := int(dayA.Sub(dayB).Hours() / 24)
+	if days < 0 {
+		days = -days
+	}
+	return days
+}
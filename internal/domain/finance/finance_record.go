@@ -3,6 +3,9 @@ package finance
 import (
 	"fmt"
 	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/errors"
+	"github.com/gwenziro/botopia/internal/domain/money"
 )
 
 // RecordType definisi tipe record keuangan
@@ -26,7 +29,7 @@ type FinanceRecord struct {
 	// Data Utama
 	Date        time.Time
 	Description string
-	Amount      float64
+	Amount      money.Money
 	Category    string
 
 	// Field khusus pengeluaran
@@ -35,29 +38,44 @@ type FinanceRecord struct {
 	// Field umum
 	StorageMedia string
 	Notes        string
-	ProofURL     string
+	ProofURL     string // Bukti utama, disimpan di sheet transaksi
+
+	// Tag lintas kategori (#liburan-bali) dari deskripsi dan catatan, disimpan di kolom Tag
+	Tags []string
+
+	// Member anggota yang mencatat transaksi (nama WhatsApp atau nomor), disimpan di kolom Dicatat Oleh
+	Member string
+
+	// Seluruh lampiran bukti, disimpan di sheet Lampiran
+	Attachments []*Attachment
 }
 
 // Validate memvalidasi finance record
 func (r *FinanceRecord) Validate() error {
 	if r.Description == "" {
-		return fmt.Errorf("deskripsi harus diisi")
+		return errors.NewValidationError("deskripsi", "deskripsi harus diisi")
+	}
+
+	if !r.Amount.IsPositive() {
+		return errors.NewValidationError("nominal", "nominal harus lebih dari 0")
 	}
 
-	if r.Amount <= 0 {
-		return fmt.Errorf("nominal harus lebih dari 0")
+	// Sheet hanya menyimpan angka nominal, sehingga seluruh record harus dalam mata uang pembukuan
+	if r.Amount.Currency() != money.DefaultCurrency {
+		return errors.NewValidationError("nominal",
+			fmt.Sprintf("mata uang %s belum didukung, nominal harus dalam %s", r.Amount.Currency(), money.DefaultCurrency))
 	}
 
 	if r.Category == "" {
-		return fmt.Errorf("kategori harus diisi")
+		return errors.NewValidationError("kategori", "kategori harus diisi")
 	}
 
 	if r.Type == TypeExpense && r.PaymentMethod == "" {
-		return fmt.Errorf("metode pembayaran harus diisi untuk pengeluaran")
+		return errors.NewValidationError("metode", "metode pembayaran harus diisi untuk pengeluaran")
 	}
 
 	if r.StorageMedia == "" {
-		return fmt.Errorf("media penyimpanan/sumber dana harus diisi")
+		return errors.NewValidationError("media", "media penyimpanan/sumber dana harus diisi")
 	}
 
 	return nil
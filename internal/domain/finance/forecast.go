@@ -0,0 +1,368 @@
+package finance
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/money"
+)
+
+const (
+	// DefaultForecastMonths jumlah bulan ke depan yang diproyeksikan secara default
+	DefaultForecastMonths = 3
+
+	// MaxForecastMonths batas jumlah bulan proyeksi
+	MaxForecastMonths = 12
+
+	// forecastLookbackDays rentang riwayat untuk menghitung rata-rata pengeluaran harian
+	forecastLookbackDays = 90
+
+	// forecastMinLookbackDays rentang minimal agar riwayat yang pendek tidak membesar-besarkan rata-rata
+	forecastMinLookbackDays = 14
+
+	// recurringHistoryMonths rentang riwayat untuk mendeteksi transaksi rutin
+	recurringHistoryMonths = 6
+
+	// recurringMinMonths jumlah bulan berbeda minimal agar transaksi dianggap rutin
+	recurringMinMonths = 2
+
+	// recurringAmountTolerance selisih nominal maksimal antar kejadian transaksi rutin
+	recurringAmountTolerance = 0.1
+
+	// recurringMaxGapDays transaksi rutin dianggap berhenti jika tidak muncul selama ini
+	recurringMaxGapDays = 45
+)
+
+// RecurringTransaction transaksi yang terdeteksi terjadi setiap bulan
+type RecurringTransaction struct {
+	Type         RecordType  `json:"type"`
+	Description  string      `json:"description"`
+	Category     string      `json:"category"`
+	StorageMedia string      `json:"storageMedia"`
+	Amount       money.Money `json:"amount"`
+	DayOfMonth   int         `json:"dayOfMonth"`
+	Months       int         `json:"months"`
+	LastDate     time.Time   `json:"lastDate"`
+
+	// seenThisMonth menandai transaksi rutin yang sudah tercatat pada bulan berjalan
+	seenThisMonth bool
+}
+
+// CategorySpend rata-rata pengeluaran harian tidak rutin per kategori dan sumber dana
+type CategorySpend struct {
+	Category     string      `json:"category"`
+	StorageMedia string      `json:"storageMedia"`
+	DailyAmount  money.Money `json:"dailyAmount"`
+}
+
+// MonthBalance perkiraan saldo pada akhir sebuah bulan
+type MonthBalance struct {
+	Month   time.Time   `json:"month"`
+	Balance money.Money `json:"balance"`
+}
+
+// MediaForecast proyeksi saldo untuk satu media penyimpanan
+type MediaForecast struct {
+	StorageMedia      string          `json:"storageMedia"`
+	CurrentBalance    money.Money     `json:"currentBalance"`
+	EndOfMonthBalance money.Money     `json:"endOfMonthBalance"`
+	MonthEnds         []*MonthBalance `json:"monthEnds"`
+	LowestBalance     money.Money     `json:"lowestBalance"`
+	LowestDate        time.Time       `json:"lowestDate"`
+
+	// NegativeDate tanggal pertama saldo diperkirakan minus (nil jika tidak pernah)
+	NegativeDate *time.Time `json:"negativeDate,omitempty"`
+}
+
+// CashFlowForecast hasil proyeksi arus kas seluruh media penyimpanan
+type CashFlowForecast struct {
+	GeneratedAt  time.Time               `json:"generatedAt"`
+	Until        time.Time               `json:"until"`
+	Media        []*MediaForecast        `json:"media"`
+	Recurring    []*RecurringTransaction `json:"recurring"`
+	DailySpend   []*CategorySpend        `json:"dailySpend"`
+	LookbackDays int                     `json:"lookbackDays"`
+}
+
+// BuildCashFlowForecast memproyeksikan saldo setiap media penyimpanan hingga akhir bulan ke-months
+// dari bulan berjalan. Saldo awal dihitung dari seluruh record, lalu setiap hari ditambah transaksi
+// rutin yang jatuh tempo dan dikurangi rata-rata pengeluaran harian yang tidak rutin.
+func BuildCashFlowForecast(records []*FinanceRecord, storageMedias []string, now time.Time, months int) *CashFlowForecast {
+	if months < 0 {
+		months = DefaultForecastMonths
+	}
+	if months > MaxForecastMonths {
+		months = MaxForecastMonths
+	}
+
+	today := startOfDay(now)
+	until := time.Date(today.Year(), today.Month()+time.Month(months)+1, 0, 0, 0, 0, 0, today.Location())
+
+	recurring, recurringKeys := detectRecurring(records, today)
+	dailySpend, lookbackDays := averageDailySpend(records, recurringKeys, today)
+
+	// Saldo awal per media penyimpanan
+	balances := make(map[string]money.Money)
+	for _, media := range storageMedias {
+		balances[media] = money.Money{}
+	}
+	for _, record := range records {
+		if record.StorageMedia == "" || record.Date.After(now) {
+			continue
+		}
+		balances[record.StorageMedia] = balances[record.StorageMedia].Add(signedAmount(record))
+	}
+
+	forecasts := make(map[string]*MediaForecast)
+	names := make([]string, 0, len(balances))
+	for media, balance := range balances {
+		forecasts[media] = &MediaForecast{
+			StorageMedia:   media,
+			CurrentBalance: balance,
+			LowestBalance:  balance,
+			LowestDate:     today,
+		}
+		names = append(names, media)
+	}
+	sort.Strings(names)
+
+	dailyByMedia := make(map[string]money.Money)
+	for _, spend := range dailySpend {
+		dailyByMedia[spend.StorageMedia] = dailyByMedia[spend.StorageMedia].Add(spend.DailyAmount)
+	}
+
+	// Simulasi harian mulai besok
+	for day := today.AddDate(0, 0, 1); !day.After(until); day = day.AddDate(0, 0, 1) {
+		for _, item := range recurring {
+			if !item.dueOn(day, today) {
+				continue
+			}
+			if _, exists := balances[item.StorageMedia]; !exists {
+				continue
+			}
+			if item.Type == TypeIncome {
+				balances[item.StorageMedia] = balances[item.StorageMedia].Add(item.Amount)
+			} else {
+				balances[item.StorageMedia] = balances[item.StorageMedia].Sub(item.Amount)
+			}
+		}
+
+		for media := range balances {
+			balances[media] = balances[media].Sub(dailyByMedia[media])
+			forecast := forecasts[media]
+
+			if balances[media].LessThan(forecast.LowestBalance) {
+				forecast.LowestBalance = balances[media]
+				forecast.LowestDate = day
+			}
+			if balances[media].IsNegative() && forecast.NegativeDate == nil {
+				negativeDate := day
+				forecast.NegativeDate = &negativeDate
+			}
+
+			// Catat saldo akhir bulan
+			if day.Day() == endOfMonth(day).Day() {
+				forecast.MonthEnds = append(forecast.MonthEnds, &MonthBalance{Month: day, Balance: balances[media]})
+				if day.Year() == today.Year() && day.Month() == today.Month() {
+					forecast.EndOfMonthBalance = balances[media]
+				}
+			}
+		}
+	}
+
+	// Hari ini adalah hari terakhir bulan, saldo akhir bulan sama dengan saldo saat ini
+	if today.Day() == endOfMonth(today).Day() {
+		for _, forecast := range forecasts {
+			forecast.EndOfMonthBalance = forecast.CurrentBalance
+		}
+	}
+
+	result := &CashFlowForecast{
+		GeneratedAt:  now,
+		Until:        until,
+		Recurring:    recurring,
+		DailySpend:   dailySpend,
+		LookbackDays: lookbackDays,
+	}
+	for _, media := range names {
+		result.Media = append(result.Media, forecasts[media])
+	}
+	return result
+}
+
+// dueOn memeriksa apakah transaksi rutin jatuh tempo pada tanggal tertentu
+func (r *RecurringTransaction) dueOn(day, today time.Time) bool {
+	dueDay := r.DayOfMonth
+	if last := endOfMonth(day).Day(); dueDay > last {
+		dueDay = last
+	}
+	if day.Day() != dueDay {
+		return false
+	}
+
+	// Transaksi rutin yang sudah tercatat bulan ini tidak dihitung dua kali
+	sameMonth := day.Year() == today.Year() && day.Month() == today.Month()
+	return !(sameMonth && r.seenThisMonth)
+}
+
+// recurringKey mengelompokkan record yang kemungkinan merupakan transaksi rutin yang sama
+func recurringKey(record *FinanceRecord) string {
+	return strings.Join([]string{
+		string(record.Type),
+		strings.ToLower(strings.TrimSpace(record.Description)),
+		record.Category,
+		record.StorageMedia,
+	}, "|")
+}
+
+// detectRecurring mencari transaksi yang muncul di beberapa bulan berbeda dengan nominal serupa
+func detectRecurring(records []*FinanceRecord, today time.Time) ([]*RecurringTransaction, map[string]bool) {
+	since := today.AddDate(0, -recurringHistoryMonths, 0)
+
+	groups := make(map[string][]*FinanceRecord)
+	for _, record := range records {
+		if record.Date.Before(since) || record.Date.After(today.AddDate(0, 0, 1)) {
+			continue
+		}
+		key := recurringKey(record)
+		groups[key] = append(groups[key], record)
+	}
+
+	var recurring []*RecurringTransaction
+	keys := make(map[string]bool)
+
+	for key, group := range groups {
+		sort.Slice(group, func(i, j int) bool { return group[i].Date.Before(group[j].Date) })
+
+		// Hitung bulan berbeda dan pastikan nominal tidak jauh dari median
+		months := make(map[string]bool)
+		amounts := make([]money.Money, 0, len(group))
+		days := make([]int, 0, len(group))
+		for _, record := range group {
+			months[record.Date.Format("2006-01")] = true
+			amounts = append(amounts, record.Amount)
+			days = append(days, record.Date.Day())
+		}
+		if len(months) < recurringMinMonths {
+			continue
+		}
+
+		medianAmount := median(amounts)
+		consistent := true
+		for _, amount := range amounts {
+			if medianAmount.IsZero() || amount.Sub(medianAmount).Abs().Float64()/medianAmount.Float64() > recurringAmountTolerance {
+				consistent = false
+				break
+			}
+		}
+		if !consistent {
+			continue
+		}
+
+		last := group[len(group)-1]
+		if today.Sub(startOfDay(last.Date)) > recurringMaxGapDays*24*time.Hour {
+			continue
+		}
+
+		sortedDays := append([]int(nil), days...)
+		sort.Ints(sortedDays)
+
+		recurring = append(recurring, &RecurringTransaction{
+			Type:          last.Type,
+			Description:   last.Description,
+			Category:      last.Category,
+			StorageMedia:  last.StorageMedia,
+			Amount:        last.Amount,
+			DayOfMonth:    sortedDays[len(sortedDays)/2],
+			Months:        len(months),
+			LastDate:      last.Date,
+			seenThisMonth: last.Date.Year() == today.Year() && last.Date.Month() == today.Month(),
+		})
+		keys[key] = true
+	}
+
+	sort.Slice(recurring, func(i, j int) bool {
+		if recurring[i].DayOfMonth != recurring[j].DayOfMonth {
+			return recurring[i].DayOfMonth < recurring[j].DayOfMonth
+		}
+		return recurring[i].Description < recurring[j].Description
+	})
+
+	return recurring, keys
+}
+
+// averageDailySpend menghitung rata-rata pengeluaran harian tidak rutin per kategori dan sumber dana
+func averageDailySpend(records []*FinanceRecord, recurringKeys map[string]bool, today time.Time) ([]*CategorySpend, int) {
+	since := today.AddDate(0, 0, -forecastLookbackDays)
+
+	// Riwayat yang lebih pendek dari rentang penuh dihitung sejak record pertama
+	earliest := today
+	totals := make(map[string]*CategorySpend)
+	for _, record := range records {
+		if record.Type != TypeExpense || record.Date.Before(since) || record.Date.After(today.AddDate(0, 0, 1)) {
+			continue
+		}
+		if record.Date.Before(earliest) {
+			earliest = startOfDay(record.Date)
+		}
+		if recurringKeys[recurringKey(record)] {
+			continue
+		}
+
+		key := record.Category + "|" + record.StorageMedia
+		spend, exists := totals[key]
+		if !exists {
+			spend = &CategorySpend{Category: record.Category, StorageMedia: record.StorageMedia}
+			totals[key] = spend
+		}
+		spend.DailyAmount = spend.DailyAmount.Add(record.Amount)
+	}
+
+	lookbackDays := int(today.Sub(earliest).Hours()/24) + 1
+	if lookbackDays < forecastMinLookbackDays {
+		lookbackDays = forecastMinLookbackDays
+	}
+	if lookbackDays > forecastLookbackDays {
+		lookbackDays = forecastLookbackDays
+	}
+
+	result := make([]*CategorySpend, 0, len(totals))
+	for _, spend := range totals {
+		spend.DailyAmount = spend.DailyAmount.Div(int64(lookbackDays))
+		result = append(result, spend)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[j].DailyAmount.LessThan(result[i].DailyAmount) })
+	return result, lookbackDays
+}
+
+// signedAmount mengembalikan nominal positif untuk pemasukan dan negatif untuk pengeluaran
+func signedAmount(record *FinanceRecord) money.Money {
+	if record.Type == TypeIncome {
+		return record.Amount
+	}
+	return record.Amount.Neg()
+}
+
+// startOfDay mengembalikan awal hari dari sebuah waktu
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// endOfMonth mengembalikan hari terakhir bulan dari sebuah waktu
+func endOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location())
+}
+
+// median mengembalikan nilai tengah dari daftar nominal
+func median(values []money.Money) money.Money {
+	sorted := append([]money.Money(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].LessThan(sorted[j]) })
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return sorted[mid-1].Add(sorted[mid]).Div(2)
+	}
+	return sorted[mid]
+}
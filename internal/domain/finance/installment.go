@@ -0,0 +1,184 @@
+package finance
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/money"
+)
+
+// InstallmentStatus status rencana cicilan
+type InstallmentStatus string
+
+const (
+	// InstallmentActive cicilan masih berjalan dan dijadwalkan setiap bulan
+	InstallmentActive InstallmentStatus = "active"
+
+	// InstallmentCompleted seluruh tenor sudah tercatat sebagai pengeluaran
+	InstallmentCompleted InstallmentStatus = "completed"
+
+	// InstallmentPaidOff cicilan dilunasi sebelum tenor berakhir
+	InstallmentPaidOff InstallmentStatus = "paid_off"
+)
+
+// InstallmentCodePrefix awalan kode rencana cicilan
+const InstallmentCodePrefix = "c_"
+
+// InstallmentPayment pengeluaran yang sudah dicatat untuk sebuah rencana cicilan
+type InstallmentPayment struct {
+	// Sequence nomor cicilan (1..Tenor), 0 untuk pelunasan dipercepat
+	Sequence   int         `json:"sequence"`
+	DueDate    time.Time   `json:"due_date"`
+	Amount     money.Money `json:"amount"`
+	RecordCode string      `json:"record_code"`
+	RecordedAt time.Time   `json:"recorded_at"`
+}
+
+// IsPayoff memeriksa apakah pembayaran merupakan pelunasan dipercepat
+func (p *InstallmentPayment) IsPayoff() bool {
+	return p.Sequence == 0
+}
+
+// InstallmentPlan rencana cicilan (misalnya kartu kredit) yang dicatat sebagai pengeluaran bulanan
+type InstallmentPlan struct {
+	Code          string      `json:"code"`
+	Description   string      `json:"description"`
+	Category      string      `json:"category"`
+	PaymentMethod string      `json:"payment_method"`
+	StorageMedia  string      `json:"storage_media"`
+	Principal     money.Money `json:"principal"`
+
+	// Fee total bunga dan biaya selama tenor
+	Fee   money.Money `json:"fee"`
+	Tenor int         `json:"tenor"`
+
+	// StartDate tanggal jatuh tempo cicilan pertama; tanggalnya dipakai untuk bulan berikutnya
+	StartDate time.Time `json:"start_date"`
+
+	Status    InstallmentStatus     `json:"status"`
+	Payments  []*InstallmentPayment `json:"payments"`
+	CreatedBy string                `json:"created_by"`
+	CreatedAt time.Time             `json:"created_at"`
+	ClosedAt  time.Time             `json:"closed_at,omitempty"`
+
+	// Ledger tempat cicilan dicatat; kosong berarti ledger utama
+	Ledger string `json:"ledger,omitempty"`
+}
+
+// Validate memeriksa kelengkapan data rencana cicilan
+func (p *InstallmentPlan) Validate() error {
+	if strings.TrimSpace(p.Description) == "" {
+		return fmt.Errorf("deskripsi cicilan tidak boleh kosong")
+	}
+	if !p.Principal.IsPositive() {
+		return fmt.Errorf("pokok cicilan harus lebih dari 0")
+	}
+	if p.Fee.IsNegative() {
+		return fmt.Errorf("bunga/biaya cicilan tidak boleh negatif")
+	}
+	if p.Tenor < 1 || p.Tenor > 120 {
+		return fmt.Errorf("tenor cicilan harus antara 1 dan 120 bulan")
+	}
+	if p.StartDate.IsZero() {
+		return fmt.Errorf("tanggal cicilan pertama belum diisi")
+	}
+	return nil
+}
+
+// IsActive memeriksa apakah cicilan masih berjalan
+func (p *InstallmentPlan) IsActive() bool {
+	return p.Status == InstallmentActive
+}
+
+// Total mengembalikan total yang harus dibayar (pokok ditambah bunga/biaya)
+func (p *InstallmentPlan) Total() money.Money {
+	return p.Principal.Add(p.Fee)
+}
+
+// MonthlyAmount mengembalikan nominal cicilan per bulan, dibulatkan ke rupiah
+func (p *InstallmentPlan) MonthlyAmount() money.Money {
+	return p.Total().Div(int64(p.Tenor)).Round()
+}
+
+// InstallmentAmount mengembalikan nominal cicilan ke-n; selisih pembulatan masuk ke cicilan terakhir
+func (p *InstallmentPlan) InstallmentAmount(sequence int) money.Money {
+	if sequence == p.Tenor {
+		return p.Total().Sub(p.MonthlyAmount().Mul(int64(p.Tenor - 1)))
+	}
+	return p.MonthlyAmount()
+}
+
+// DueDate mengembalikan tanggal jatuh tempo cicilan ke-n.
+// Tanggal yang tidak ada di bulan tersebut (misalnya 31) dimundurkan ke akhir bulan.
+func (p *InstallmentPlan) DueDate(sequence int) time.Time {
+	start := p.StartDate
+	month := time.Date(start.Year(), start.Month()+time.Month(sequence-1), 1, 0, 0, 0, 0, start.Location())
+
+	day := start.Day()
+	if lastDay := month.AddDate(0, 1, -1).Day(); day > lastDay {
+		day = lastDay
+	}
+
+	return time.Date(month.Year(), month.Month(), day, 0, 0, 0, 0, start.Location())
+}
+
+// PaidCount mengembalikan jumlah cicilan bulanan yang sudah dicatat
+func (p *InstallmentPlan) PaidCount() int {
+	count := 0
+	for _, payment := range p.Payments {
+		if !payment.IsPayoff() {
+			count++
+		}
+	}
+	return count
+}
+
+// PaidAmount mengembalikan total nominal yang sudah dicatat sebagai pengeluaran
+func (p *InstallmentPlan) PaidAmount() money.Money {
+	total := money.Money{}
+	for _, payment := range p.Payments {
+		total = total.Add(payment.Amount)
+	}
+	return total
+}
+
+// RemainingTenor mengembalikan sisa bulan cicilan yang belum dicatat
+func (p *InstallmentPlan) RemainingTenor() int {
+	if !p.IsActive() {
+		return 0
+	}
+	return p.Tenor - p.PaidCount()
+}
+
+// Outstanding mengembalikan sisa tagihan cicilan yang belum dicatat
+func (p *InstallmentPlan) Outstanding() money.Money {
+	if !p.IsActive() {
+		return money.Money{}
+	}
+	return p.Total().Sub(p.PaidAmount())
+}
+
+// NextDueDate mengembalikan tanggal jatuh tempo cicilan berikutnya (false jika tidak ada)
+func (p *InstallmentPlan) NextDueDate() (time.Time, bool) {
+	if p.RemainingTenor() == 0 {
+		return time.Time{}, false
+	}
+	return p.DueDate(p.PaidCount() + 1), true
+}
+
+// DueSequences mengembalikan nomor cicilan yang sudah jatuh tempo tapi belum dicatat
+func (p *InstallmentPlan) DueSequences(now time.Time) []int {
+	if !p.IsActive() {
+		return nil
+	}
+
+	var due []int
+	for sequence := p.PaidCount() + 1; sequence <= p.Tenor; sequence++ {
+		if p.DueDate(sequence).After(now) {
+			break
+		}
+		due = append(due, sequence)
+	}
+	return due
+}
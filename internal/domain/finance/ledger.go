@@ -0,0 +1,80 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultLedgerID ledger bawaan yang memakai spreadsheet utama dari konfigurasi aplikasi
+const DefaultLedgerID = ""
+
+// ledgerIDPattern membatasi ID ledger agar aman dipakai sebagai kunci dan di URL
+var ledgerIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{1,31}$`)
+
+// Ledger pembukuan terpisah (misalnya satu rumah tangga) dengan spreadsheet dan konfigurasinya sendiri
+type Ledger struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	SpreadsheetID string `json:"spreadsheetId"`
+
+	// DriveFolderID folder Drive untuk spreadsheet partisi tahunan; kosong berarti memakai folder utama
+	DriveFolderID string `json:"driveFolderId,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Validate memeriksa kelengkapan data ledger
+func (l *Ledger) Validate() error {
+	if !ledgerIDPattern.MatchString(l.ID) {
+		return fmt.Errorf("ID ledger harus 2-32 karakter huruf kecil, angka, '-' atau '_'")
+	}
+	if strings.TrimSpace(l.Name) == "" {
+		return fmt.Errorf("nama ledger tidak boleh kosong")
+	}
+	if strings.TrimSpace(l.SpreadsheetID) == "" {
+		return fmt.Errorf("ID spreadsheet ledger tidak boleh kosong")
+	}
+	return nil
+}
+
+// NormalizeLedgerID menyeragamkan penulisan ID ledger
+func NormalizeLedgerID(id string) string {
+	return strings.ToLower(strings.TrimSpace(id))
+}
+
+// ledgerKey kunci context untuk ledger
+type ledgerKey struct{}
+
+// WithLedger menyimpan ID ledger di context agar operasi keuangan berikutnya diarahkan ke ledger tersebut
+func WithLedger(ctx context.Context, ledgerID string) context.Context {
+	return context.WithValue(ctx, ledgerKey{}, NormalizeLedgerID(ledgerID))
+}
+
+// LedgerFromContext mengambil ID ledger dari context, default ke ledger utama
+func LedgerFromContext(ctx context.Context) string {
+	if ledgerID, ok := ctx.Value(ledgerKey{}).(string); ok {
+		return ledgerID
+	}
+	return DefaultLedgerID
+}
+
+// LedgerLabel mengembalikan nama ledger untuk tampilan log dan pesan
+func LedgerLabel(ledgerID string) string {
+	if ledgerID == DefaultLedgerID {
+		return "utama"
+	}
+	return ledgerID
+}
+
+// LedgerScopedKey membuat kunci penyimpanan yang unik antar ledger, karena kode transaksi
+// dan periode setiap ledger dihitung sendiri-sendiri; ledger utama memakai kunci apa adanya
+func LedgerScopedKey(ledgerID, key string) string {
+	if ledgerID == DefaultLedgerID {
+		return key
+	}
+	return ledgerID + "/" + key
+}
@@ -0,0 +1,171 @@
+package finance
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/gwenziro/botopia/internal/domain/money"
+)
+
+// PersonalTag menandai pengeluaran pribadi di ledger bersama yang tidak dibagi rata ke anggota lain
+const PersonalTag = "pribadi"
+
+// memberKey kunci context untuk anggota pencatat transaksi
+type memberKey struct{}
+
+// WithMember menyimpan anggota pencatat di context, misalnya pengaju saat pengeluaran disetujui orang lain
+func WithMember(ctx context.Context, member string) context.Context {
+	return context.WithValue(ctx, memberKey{}, strings.TrimSpace(member))
+}
+
+// MemberFromContext mengambil anggota pencatat yang disimpan dengan WithMember
+func MemberFromContext(ctx context.Context) (string, bool) {
+	member, ok := ctx.Value(memberKey{}).(string)
+	return member, ok
+}
+
+// MemberSummary rekap transaksi satu anggota dalam satu periode
+type MemberSummary struct {
+	Member      string
+	Expense     money.Money
+	Income      money.Money
+	RecordCount int
+
+	// Shared pengeluaran bersama (tanpa tag #pribadi) yang dibayar anggota
+	Shared money.Money
+
+	// Balance selisih Shared dengan bagian rata; positif berarti anggota perlu menerima
+	Balance money.Money
+}
+
+// Settlement saran pembayaran antar anggota untuk menyeimbangkan pengeluaran bersama
+type Settlement struct {
+	From   string
+	To     string
+	Amount money.Money
+}
+
+// MemberReport rekap per anggota sebuah ledger bersama
+type MemberReport struct {
+	// Members diurutkan dari pengeluaran terbesar
+	Members []*MemberSummary
+
+	SharedTotal money.Money
+	FairShare   money.Money
+
+	// Unattributed pengeluaran tanpa pencatat (misalnya dari web atau cicilan otomatis), tidak dibagi
+	Unattributed money.Money
+
+	Settlements []*Settlement
+}
+
+// BuildMemberReport merekap transaksi periode per anggota. members adalah anggota yang ikut
+// menanggung pengeluaran bersama walaupun tidak mencatat apa pun pada periode tersebut.
+func BuildMemberReport(records []*FinanceRecord, members []string) *MemberReport {
+	report := &MemberReport{}
+	byMember := make(map[string]*MemberSummary)
+
+	summaryOf := func(member string) *MemberSummary {
+		summary, exists := byMember[member]
+		if !exists {
+			summary = &MemberSummary{Member: member}
+			byMember[member] = summary
+			report.Members = append(report.Members, summary)
+		}
+		return summary
+	}
+
+	for _, member := range members {
+		if member = strings.TrimSpace(member); member != "" {
+			summaryOf(member)
+		}
+	}
+
+	for _, record := range records {
+		member := strings.TrimSpace(record.Member)
+		if member == "" {
+			if record.Type == TypeExpense {
+				report.Unattributed = report.Unattributed.Add(record.Amount)
+			}
+			continue
+		}
+
+		summary := summaryOf(member)
+		summary.RecordCount++
+		if record.Type == TypeIncome {
+			summary.Income = summary.Income.Add(record.Amount)
+			continue
+		}
+
+		summary.Expense = summary.Expense.Add(record.Amount)
+		if !record.HasTag(PersonalTag) {
+			summary.Shared = summary.Shared.Add(record.Amount)
+			report.SharedTotal = report.SharedTotal.Add(record.Amount)
+		}
+	}
+
+	if len(report.Members) > 0 {
+		report.FairShare = report.SharedTotal.Div(int64(len(report.Members))).Round()
+		for _, summary := range report.Members {
+			summary.Balance = summary.Shared.Sub(report.FairShare)
+		}
+	}
+
+	sort.SliceStable(report.Members, func(i, j int) bool {
+		return report.Members[i].Expense.Cmp(report.Members[j].Expense) > 0
+	})
+	report.Settlements = settle(report.Members)
+
+	return report
+}
+
+// settle memasangkan anggota yang kurang bayar dengan anggota yang lebih bayar, dari selisih terbesar
+func settle(members []*MemberSummary) []*Settlement {
+	type position struct {
+		member string
+		amount money.Money
+	}
+
+	var debtors, creditors []*position
+	for _, summary := range members {
+		switch {
+		case summary.Balance.IsNegative():
+			debtors = append(debtors, &position{summary.Member, summary.Balance.Neg()})
+		case summary.Balance.IsPositive():
+			creditors = append(creditors, &position{summary.Member, summary.Balance})
+		}
+	}
+
+	byAmount := func(positions []*position) {
+		sort.SliceStable(positions, func(i, j int) bool {
+			return positions[i].amount.Cmp(positions[j].amount) > 0
+		})
+	}
+	byAmount(debtors)
+	byAmount(creditors)
+
+	var settlements []*Settlement
+	for i, j := 0, 0; i < len(debtors) && j < len(creditors); {
+		debtor, creditor := debtors[i], creditors[j]
+
+		amount := debtor.amount
+		if creditor.amount.LessThan(amount) {
+			amount = creditor.amount
+		}
+		if amount.IsPositive() {
+			settlements = append(settlements, &Settlement{From: debtor.member, To: creditor.member, Amount: amount})
+		}
+
+		debtor.amount = debtor.amount.Sub(amount)
+		creditor.amount = creditor.amount.Sub(amount)
+		if !debtor.amount.IsPositive() {
+			i++
+		}
+		if !creditor.amount.IsPositive() {
+			j++
+		}
+	}
+
+	return settlements
+}
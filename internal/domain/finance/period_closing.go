@@ -0,0 +1,104 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/money"
+)
+
+// PeriodLayout format kunci periode tutup buku (tahun-bulan)
+const PeriodLayout = "2006-01"
+
+// PeriodSnapshot ringkasan angka sebuah periode pada saat tutup buku
+type PeriodSnapshot struct {
+	Income            money.Money            `json:"income"`
+	Expense           money.Money            `json:"expense"`
+	Net               money.Money            `json:"net"`
+	RecordCount       int                    `json:"record_count"`
+	IncomeByCategory  map[string]money.Money `json:"income_by_category"`
+	ExpenseByCategory map[string]money.Money `json:"expense_by_category"`
+	BalanceByMedia    map[string]money.Money `json:"balance_by_media"`
+}
+
+// PeriodClosing merepresentasikan periode bulanan yang sudah tutup buku
+type PeriodClosing struct {
+	Period   string          `json:"period"`
+	Snapshot *PeriodSnapshot `json:"snapshot"`
+	ClosedAt time.Time       `json:"closed_at"`
+	ClosedBy string          `json:"closed_by"`
+
+	// Ledger pemilik periode; kosong berarti ledger utama
+	Ledger string `json:"ledger,omitempty"`
+}
+
+// Key mengembalikan kunci penyimpanan yang unik antar ledger
+func (c *PeriodClosing) Key() string {
+	return LedgerScopedKey(c.Ledger, c.Period)
+}
+
+// PeriodOf mengembalikan kunci periode (YYYY-MM) untuk tanggal
+func PeriodOf(date time.Time) string {
+	return date.Format(PeriodLayout)
+}
+
+// ParsePeriod mengubah kunci periode menjadi tanggal awal bulan
+func ParsePeriod(period string) (time.Time, error) {
+	start, err := time.ParseInLocation(PeriodLayout, period, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("periode %q tidak valid, gunakan format YYYY-MM", period)
+	}
+	return start, nil
+}
+
+// BuildPeriodSnapshot menghitung ringkasan record yang bertanggal di periode tertentu
+func BuildPeriodSnapshot(records []*FinanceRecord, period string) *PeriodSnapshot {
+	inPeriod := make([]*FinanceRecord, 0, len(records))
+	for _, record := range records {
+		if PeriodOf(record.Date) == period {
+			inPeriod = append(inPeriod, record)
+		}
+	}
+
+	return BuildSnapshot(inPeriod)
+}
+
+// BuildSnapshot menghitung ringkasan pemasukan, pengeluaran, dan perubahan saldo dari seluruh record
+func BuildSnapshot(records []*FinanceRecord) *PeriodSnapshot {
+	snapshot := &PeriodSnapshot{
+		IncomeByCategory:  make(map[string]money.Money),
+		ExpenseByCategory: make(map[string]money.Money),
+		BalanceByMedia:    make(map[string]money.Money),
+	}
+
+	for _, record := range records {
+		snapshot.RecordCount++
+		if record.Type == TypeIncome {
+			snapshot.Income = snapshot.Income.Add(record.Amount)
+			snapshot.IncomeByCategory[record.Category] = snapshot.IncomeByCategory[record.Category].Add(record.Amount)
+			snapshot.BalanceByMedia[record.StorageMedia] = snapshot.BalanceByMedia[record.StorageMedia].Add(record.Amount)
+		} else {
+			snapshot.Expense = snapshot.Expense.Add(record.Amount)
+			snapshot.ExpenseByCategory[record.Category] = snapshot.ExpenseByCategory[record.Category].Add(record.Amount)
+			snapshot.BalanceByMedia[record.StorageMedia] = snapshot.BalanceByMedia[record.StorageMedia].Sub(record.Amount)
+		}
+	}
+	snapshot.Net = snapshot.Income.Sub(snapshot.Expense)
+
+	return snapshot
+}
+
+// periodOverrideKey kunci context untuk izin mengubah periode tertutup
+type periodOverrideKey struct{}
+
+// WithPeriodOverride menandai context agar perubahan pada periode tertutup diizinkan (tetap diaudit)
+func WithPeriodOverride(ctx context.Context) context.Context {
+	return context.WithValue(ctx, periodOverrideKey{}, true)
+}
+
+// HasPeriodOverride memeriksa apakah context membawa izin mengubah periode tertutup
+func HasPeriodOverride(ctx context.Context) bool {
+	override, _ := ctx.Value(periodOverrideKey{}).(bool)
+	return override
+}
@@ -0,0 +1,98 @@
+package finance
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/money"
+)
+
+// ProofReminderPolicy menentukan transaksi tanpa bukti yang perlu diingatkan
+type ProofReminderPolicy struct {
+	// MinAgeDays umur minimal transaksi (hari) sebelum diingatkan
+	MinAgeDays int
+
+	// MinAmount nominal minimal transaksi yang diingatkan (0 berarti semua)
+	MinAmount money.Money
+
+	// Categories membatasi pengingat ke kategori tertentu (kosong berarti semua)
+	Categories []string
+
+	// Interval jarak minimal antar pengingat untuk transaksi yang sama
+	Interval time.Duration
+}
+
+// NeedsReminder memeriksa apakah record belum memiliki bukti dan memenuhi kriteria pengingat
+func (p *ProofReminderPolicy) NeedsReminder(record *FinanceRecord, now time.Time) bool {
+	if record.HasProof() {
+		return false
+	}
+
+	if now.Sub(record.Date) < time.Duration(p.MinAgeDays)*24*time.Hour {
+		return false
+	}
+
+	if p.MinAmount.IsPositive() && record.Amount.LessThan(p.MinAmount) {
+		return false
+	}
+
+	if len(p.Categories) == 0 {
+		return true
+	}
+
+	for _, category := range p.Categories {
+		if strings.EqualFold(strings.TrimSpace(category), record.Category) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ProofReminderTitle judul pesan pengingat bukti, dipakai untuk mengenali balasan atas pengingat
+const ProofReminderTitle = "PENGINGAT BUKTI TRANSAKSI"
+
+// proofReminderLinePattern mengenali baris "1. k_mei25_001" pada pesan pengingat
+var proofReminderLinePattern = regexp.MustCompile(`(?m)^(\d+)\. (\S+)$`)
+
+// ProofReminderCodes mengambil kode transaksi berurutan dari teks pesan pengingat bukti.
+// Mengembalikan nil jika teks bukan pesan pengingat.
+func ProofReminderCodes(text string) []string {
+	if !strings.Contains(text, ProofReminderTitle) {
+		return nil
+	}
+
+	var codes []string
+	for _, match := range proofReminderLinePattern.FindAllStringSubmatch(text, -1) {
+		codes = append(codes, match[2])
+	}
+	return codes
+}
+
+// SelectReminderCode memilih kode dari pengingat berdasarkan caption balasan: kosong untuk
+// pengingat satu transaksi, nomor urut, atau kode transaksinya langsung
+func SelectReminderCode(codes []string, caption string) (string, bool) {
+	caption = strings.TrimSpace(caption)
+	if caption == "" {
+		if len(codes) == 1 {
+			return codes[0], true
+		}
+		return "", false
+	}
+
+	if index, err := strconv.Atoi(strings.TrimSuffix(caption, ".")); err == nil {
+		if index >= 1 && index <= len(codes) {
+			return codes[index-1], true
+		}
+		return "", false
+	}
+
+	for _, code := range codes {
+		if strings.EqualFold(code, caption) {
+			return code, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,132 @@
+package finance
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gwenziro/botopia/internal/domain/money"
+)
+
+// quickAmountPattern mengenali kata nominal pada entri cepat, misalnya 15000, 15.000, 15rb, 1,5jt atau Rp15rb
+var quickAmountPattern = regexp.MustCompile(`(?i)^(rp\.?)?\d[\d.,]*(rb|ribu|k|jt|juta)?$`)
+
+// quickAmountSuffixes singkatan nominal yang boleh ditulis terpisah dari angkanya, misalnya "15 rb"
+var quickAmountSuffixes = map[string]bool{"rb": true, "ribu": true, "k": true, "jt": true, "juta": true}
+
+// ParseQuickEntry membaca satu baris entri cepat pengeluaran. #nama yang cocok dengan kategori menjadi
+// kategori, #nama lainnya tetap menjadi tag di deskripsi. @nama dicocokkan dengan sumber dana lalu
+// metode pembayaran. Field yang tidak disebut dibiarkan kosong untuk dilengkapi pemanggil.
+func ParseQuickEntry(line string, date time.Time, config *Configuration) (*FinanceRecord, error) {
+	words := strings.Fields(line)
+	if len(words) == 0 {
+		return nil, fmt.Errorf("baris kosong")
+	}
+
+	record := &FinanceRecord{
+		Type:  TypeExpense,
+		Date:  date,
+		Notes: "-",
+	}
+
+	amountIndex, amountWords := quickAmountPosition(words)
+	if amountIndex < 0 {
+		return nil, fmt.Errorf("nominal tidak ditemukan, contoh: 15rb atau 15000")
+	}
+	amount, err := money.Parse(strings.Join(words[amountIndex:amountIndex+amountWords], ""))
+	if err != nil || !amount.IsPositive() {
+		return nil, fmt.Errorf("nominal '%s' tidak valid", strings.Join(words[amountIndex:amountIndex+amountWords], " "))
+	}
+	record.Amount = amount
+
+	var description []string
+	for i, word := range words {
+		if i >= amountIndex && i < amountIndex+amountWords {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(word, "@") && len(word) > 1:
+			name := word[1:]
+			if media, ok := matchConfigName(config.StorageMedias, name); ok && record.StorageMedia == "" {
+				record.StorageMedia = media
+			} else if method, ok := matchConfigName(config.PaymentMethods, name); ok && record.PaymentMethod == "" {
+				record.PaymentMethod = method
+			} else {
+				return nil, fmt.Errorf("sumber dana atau metode '%s' tidak dikenali", name)
+			}
+
+		case IsTag(word) && record.Category == "":
+			// Tag yang bukan nama kategori tetap menjadi tag biasa di deskripsi
+			if category, ok := matchConfigName(config.ExpenseCategories, NormalizeTag(word)); ok {
+				record.Category = category
+				continue
+			}
+			description = append(description, word)
+
+		default:
+			description = append(description, word)
+		}
+	}
+
+	record.Description = strings.Join(description, " ")
+	if len(ParseTags(record.Description)) == len(strings.Fields(record.Description)) {
+		return nil, fmt.Errorf("deskripsi belum diisi")
+	}
+
+	return record, nil
+}
+
+// quickAmountPosition mencari kata nominal: utamakan yang bersingkatan (15rb) atau berawalan Rp,
+// selain itu angka terakhir. Mengembalikan indeks dan jumlah kata ("15 rb" terdiri dari dua kata).
+func quickAmountPosition(words []string) (int, int) {
+	index, count := -1, 0
+	explicit := false
+
+	for i, word := range words {
+		if !quickAmountPattern.MatchString(word) {
+			continue
+		}
+
+		width := 1
+		match := quickAmountPattern.FindStringSubmatch(word)
+		marked := match[1] != "" || match[2] != ""
+		if match[2] == "" && i+1 < len(words) && quickAmountSuffixes[strings.ToLower(words[i+1])] {
+			width = 2
+			marked = true
+		}
+
+		if marked || !explicit {
+			index, count, explicit = i, width, marked
+		}
+	}
+
+	return index, count
+}
+
+// matchConfigName mencocokkan nama dengan daftar konfigurasi tanpa membedakan huruf besar/kecil,
+// spasi, atau tanda baca, sehingga @BankBCA cocok dengan "Bank BCA"
+func matchConfigName(options []string, name string) (string, bool) {
+	key := compactName(name)
+	if key == "" {
+		return "", false
+	}
+	for _, option := range options {
+		if compactName(option) == key {
+			return option, true
+		}
+	}
+	return "", false
+}
+
+// compactName menyisakan huruf dan angka dalam huruf kecil
+func compactName(text string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, text)
+}
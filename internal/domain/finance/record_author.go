@@ -0,0 +1,37 @@
+package finance
+
+import "time"
+
+// RecordAuthor mencatat pengguna WhatsApp yang membuat sebuah transaksi
+type RecordAuthor struct {
+	RecordCode string    `json:"record_code"`
+	UserID     string    `json:"user_id"`
+	Phone      string    `json:"phone"`
+	ChatID     string    `json:"chat_id"`
+	Name       string    `json:"name,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+
+	// Ledger tempat transaksi dicatat; kosong berarti ledger utama
+	Ledger string `json:"ledger,omitempty"`
+
+	// RemindedAt waktu terakhir pengingat bukti dikirim untuk transaksi ini
+	RemindedAt time.Time `json:"reminded_at,omitempty"`
+}
+
+// PrivateChatID mengembalikan JID chat pribadi pembuat transaksi
+func (a *RecordAuthor) PrivateChatID() string {
+	return a.UserID + "@s.whatsapp.net"
+}
+
+// Key mengembalikan kunci penyimpanan yang unik antar ledger
+func (a *RecordAuthor) Key() string {
+	return LedgerScopedKey(a.Ledger, a.RecordCode)
+}
+
+// DisplayName mengembalikan nama WhatsApp pembuat transaksi, atau nomornya jika nama tidak diketahui
+func (a *RecordAuthor) DisplayName() string {
+	if a.Name != "" {
+		return a.Name
+	}
+	return a.Phone
+}
@@ -0,0 +1,59 @@
+package finance
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// RecordFilter kriteria pencarian record keuangan
+type RecordFilter struct {
+	Type         RecordType
+	Category     string
+	StorageMedia string
+	Tag          string
+	From         time.Time
+	To           time.Time
+}
+
+// Matches memeriksa apakah record memenuhi filter
+func (f *RecordFilter) Matches(r *FinanceRecord) bool {
+	if f.Type != "" && r.Type != f.Type {
+		return false
+	}
+	if f.Category != "" && !strings.EqualFold(r.Category, f.Category) {
+		return false
+	}
+	if f.StorageMedia != "" && !strings.EqualFold(r.StorageMedia, f.StorageMedia) {
+		return false
+	}
+	if f.Tag != "" && !r.HasTag(f.Tag) {
+		return false
+	}
+	if !f.From.IsZero() && r.Date.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && r.Date.After(f.To) {
+		return false
+	}
+	return true
+}
+
+// FilterRecords mengembalikan record yang memenuhi filter, diurutkan dari yang terbaru
+func FilterRecords(records []*FinanceRecord, filter *RecordFilter) []*FinanceRecord {
+	result := make([]*FinanceRecord, 0, len(records))
+	for _, record := range records {
+		if filter == nil || filter.Matches(record) {
+			result = append(result, record)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if !result[i].Date.Equal(result[j].Date) {
+			return result[i].Date.After(result[j].Date)
+		}
+		return result[i].Number > result[j].Number
+	})
+
+	return result
+}
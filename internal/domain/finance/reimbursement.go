@@ -0,0 +1,136 @@
+package finance
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/money"
+)
+
+// ReimbursableTag tag penanda pengeluaran yang akan diklaim ke kantor (#reimburse)
+const ReimbursableTag = "reimburse"
+
+// ClaimCodePrefix awalan kode klaim reimbursement
+const ClaimCodePrefix = "r_"
+
+// ClaimStatus status klaim reimbursement
+type ClaimStatus string
+
+const (
+	// ClaimSubmitted klaim sudah diajukan dan menunggu penggantian
+	ClaimSubmitted ClaimStatus = "submitted"
+
+	// ClaimPaid penggantian sudah diterima sebagai pemasukan
+	ClaimPaid ClaimStatus = "paid"
+)
+
+// IsReimbursable memeriksa apakah pengeluaran ditandai untuk diklaim (#reimburse)
+func (r *FinanceRecord) IsReimbursable() bool {
+	return r.Type == TypeExpense && r.HasTag(ReimbursableTag)
+}
+
+// ClaimItem pengeluaran yang termasuk dalam sebuah klaim, disalin saat klaim dibuat
+type ClaimItem struct {
+	RecordCode  string      `json:"record_code"`
+	Date        time.Time   `json:"date"`
+	Description string      `json:"description"`
+	Category    string      `json:"category"`
+	Amount      money.Money `json:"amount"`
+	ProofURLs   []string    `json:"proof_urls,omitempty"`
+}
+
+// NewClaimItem menyalin data pengeluaran beserta tautan buktinya menjadi item klaim
+func NewClaimItem(record *FinanceRecord) *ClaimItem {
+	item := &ClaimItem{
+		RecordCode:  record.UniqueCode,
+		Date:        record.Date,
+		Description: record.Description,
+		Category:    record.Category,
+		Amount:      record.Amount,
+	}
+
+	seen := make(map[string]bool)
+	addURL := func(url string) {
+		if url == "" || url == "-" || seen[url] {
+			return
+		}
+		seen[url] = true
+		item.ProofURLs = append(item.ProofURLs, url)
+	}
+	for _, attachment := range record.Attachments {
+		addURL(attachment.URL)
+	}
+	addURL(record.ProofURL)
+
+	return item
+}
+
+// ReimbursementClaim kumpulan pengeluaran yang diajukan untuk diganti kantor
+type ReimbursementClaim struct {
+	Code        string       `json:"code"`
+	Title       string       `json:"title"`
+	Status      ClaimStatus  `json:"status"`
+	Items       []*ClaimItem `json:"items"`
+	SubmittedBy string       `json:"submitted_by"`
+	SubmittedAt time.Time    `json:"submitted_at"`
+
+	// IncomeCode kode pemasukan penggantian yang melunasi klaim
+	IncomeCode string      `json:"income_code,omitempty"`
+	PaidAmount money.Money `json:"paid_amount"`
+	PaidAt     time.Time   `json:"paid_at,omitempty"`
+
+	// Ledger pemilik pengeluaran dalam klaim; kosong berarti ledger utama
+	Ledger string `json:"ledger,omitempty"`
+}
+
+// Validate memeriksa kelengkapan data klaim
+func (c *ReimbursementClaim) Validate() error {
+	if strings.TrimSpace(c.Title) == "" {
+		return fmt.Errorf("judul klaim tidak boleh kosong")
+	}
+	if len(c.Items) == 0 {
+		return fmt.Errorf("klaim harus berisi minimal satu pengeluaran")
+	}
+	return nil
+}
+
+// IsPaid memeriksa apakah penggantian klaim sudah diterima
+func (c *ReimbursementClaim) IsPaid() bool {
+	return c.Status == ClaimPaid
+}
+
+// Total mengembalikan total nominal pengeluaran yang diklaim
+func (c *ReimbursementClaim) Total() money.Money {
+	total := money.Money{}
+	for _, item := range c.Items {
+		total = total.Add(item.Amount)
+	}
+	return total
+}
+
+// Difference mengembalikan selisih penggantian terhadap total klaim (negatif jika kurang bayar)
+func (c *ReimbursementClaim) Difference() money.Money {
+	if !c.IsPaid() {
+		return money.Money{}
+	}
+	return c.PaidAmount.Sub(c.Total())
+}
+
+// HasRecord memeriksa apakah pengeluaran dengan kode tertentu termasuk dalam klaim
+func (c *ReimbursementClaim) HasRecord(code string) bool {
+	for _, item := range c.Items {
+		if item.RecordCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusLabel mengembalikan label status klaim dalam bahasa Indonesia
+func (c *ReimbursementClaim) StatusLabel() string {
+	if c.IsPaid() {
+		return "Lunas"
+	}
+	return "Diajukan"
+}
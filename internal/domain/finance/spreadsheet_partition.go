@@ -0,0 +1,58 @@
+package finance
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// codeYearPattern mengambil dua digit tahun dari kode unik, misalnya 25 dari k_mei25_002
+var codeYearPattern = regexp.MustCompile(`^[km]_[a-z]{3}(\d{2})_\d+$`)
+
+// PartitionStart mengembalikan tahun awal partisi spreadsheet untuk sebuah tahun.
+// Dengan spanYears 1 setiap tahun memiliki partisi sendiri; dengan 2 tahun 2026 dan 2027 berbagi partisi 2026.
+func PartitionStart(year, spanYears int) int {
+	if spanYears <= 1 {
+		return year
+	}
+	return year - year%spanYears
+}
+
+// PartitionLabel mengembalikan label partisi untuk nama spreadsheet (2026 atau 2026-2027)
+func PartitionLabel(start, spanYears int) string {
+	if spanYears <= 1 {
+		return strconv.Itoa(start)
+	}
+	return strconv.Itoa(start) + "-" + strconv.Itoa(start+spanYears-1)
+}
+
+// PartitionOverlaps memeriksa apakah partisi yang dimulai pada tahun start beririsan dengan rentang tanggal.
+// Tanggal nol berarti rentang tidak dibatasi di sisi tersebut.
+func PartitionOverlaps(start, spanYears int, from, to time.Time) bool {
+	if spanYears < 1 {
+		spanYears = 1
+	}
+	end := start + spanYears - 1
+
+	if !from.IsZero() && end < from.Year() {
+		return false
+	}
+	if !to.IsZero() && start > to.Year() {
+		return false
+	}
+	return true
+}
+
+// CodeYear mengambil tahun transaksi dari kode unik (k_mei25_002 menjadi 2025)
+func CodeYear(code string) (int, bool) {
+	match := codeYearPattern.FindStringSubmatch(code)
+	if match == nil {
+		return 0, false
+	}
+
+	yearShort, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, false
+	}
+	return 2000 + yearShort, true
+}
@@ -0,0 +1,100 @@
+package finance
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// tagPattern mengenali tag berawalan # yang berdiri sendiri, misalnya #liburan-bali atau #kantor
+var tagPattern = regexp.MustCompile(`(?:^|[\s,;(])#([\p{L}\p{N}_][\p{L}\p{N}_-]*)`)
+
+// NormalizeTag menyeragamkan penulisan tag: tanpa awalan #, huruf kecil
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+}
+
+// IsTag memeriksa apakah kata merupakan tag (#nama)
+func IsTag(word string) bool {
+	return tagPattern.MatchString(strings.TrimSpace(word))
+}
+
+// ParseTags mengambil seluruh tag unik dari teks sesuai urutan kemunculan
+func ParseTags(texts ...string) []string {
+	var tags []string
+	seen := make(map[string]bool)
+
+	for _, text := range texts {
+		for _, match := range tagPattern.FindAllStringSubmatch(text, -1) {
+			tag := NormalizeTag(match[1])
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	return tags
+}
+
+// FormatTags menggabungkan tag menjadi teks berawalan # untuk kolom sheet dan tampilan
+func FormatTags(tags []string) string {
+	formatted := make([]string, len(tags))
+	for i, tag := range tags {
+		formatted[i] = "#" + tag
+	}
+	return strings.Join(formatted, " ")
+}
+
+// RemoveTag menghapus seluruh penulisan sebuah tag dari teks
+func RemoveTag(text, tag string) string {
+	tag = NormalizeTag(tag)
+	words := strings.Fields(text)
+	kept := words[:0]
+	for _, word := range words {
+		if IsTag(word) && NormalizeTag(strings.TrimRight(word, ",;")) == tag {
+			continue
+		}
+		kept = append(kept, word)
+	}
+	return strings.Join(kept, " ")
+}
+
+// RefreshTags mengisi ulang tag record dari deskripsi dan catatan
+func (r *FinanceRecord) RefreshTags() {
+	r.Tags = ParseTags(r.Description, r.Notes)
+}
+
+// HasTag memeriksa apakah record memiliki tag tertentu (tanpa membedakan huruf besar/kecil)
+func (r *FinanceRecord) HasTag(tag string) bool {
+	tag = NormalizeTag(tag)
+	for _, own := range r.Tags {
+		if own == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// CollectTags mengumpulkan tag dari record, diurutkan dari yang paling sering dipakai
+func CollectTags(records []*FinanceRecord) []string {
+	counts := make(map[string]int)
+	for _, record := range records {
+		for _, tag := range record.Tags {
+			counts[tag]++
+		}
+	}
+
+	tags := make([]string, 0, len(counts))
+	for tag := range counts {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if counts[tags[i]] != counts[tags[j]] {
+			return counts[tags[i]] > counts[tags[j]]
+		}
+		return tags[i] < tags[j]
+	})
+
+	return tags
+}
@@ -0,0 +1,252 @@
+package finance
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/money"
+)
+
+const (
+	// DefaultNisabGrams nisab zakat maal dan penghasilan setara 85 gram emas
+	DefaultNisabGrams = 85.0
+
+	// DefaultZakatRatePercent kadar zakat maal dan penghasilan
+	DefaultZakatRatePercent = 2.5
+)
+
+// ZakatSettings aturan perhitungan zakat sebuah ledger
+type ZakatSettings struct {
+	// GoldPricePerGram harga emas per gram yang diisi manual; 0 berarti nisab belum dapat dihitung
+	GoldPricePerGram money.Money `json:"goldPricePerGram"`
+	NisabGrams       float64     `json:"nisabGrams"`
+	RatePercent      float64     `json:"ratePercent"`
+
+	// AnnualIncome membandingkan total penghasilan setahun dengan nisab tahunan,
+	// bukan penghasilan tiap bulan dengan nisab bulanan
+	AnnualIncome bool `json:"annualIncome"`
+
+	// ExcludedIncomeCategories kategori pemasukan yang bukan penghasilan (misalnya transfer antar rekening)
+	ExcludedIncomeCategories []string `json:"excludedIncomeCategories"`
+
+	// ExcludedStorageMedias media yang tidak dihitung sebagai harta (misalnya dana titipan)
+	ExcludedStorageMedias []string `json:"excludedStorageMedias"`
+
+	// Ledger pemilik pengaturan; kosong berarti ledger utama
+	Ledger string `json:"ledger,omitempty"`
+
+	UpdatedAt time.Time `json:"updatedAt"`
+	UpdatedBy string    `json:"updatedBy,omitempty"`
+}
+
+// DefaultZakatSettings mengembalikan pengaturan zakat bawaan tanpa harga emas
+func DefaultZakatSettings() *ZakatSettings {
+	return &ZakatSettings{
+		NisabGrams:               DefaultNisabGrams,
+		RatePercent:              DefaultZakatRatePercent,
+		ExcludedIncomeCategories: []string{},
+		ExcludedStorageMedias:    []string{},
+	}
+}
+
+// Validate memeriksa kewajaran pengaturan zakat
+func (s *ZakatSettings) Validate() error {
+	if s.GoldPricePerGram.IsNegative() {
+		return fmt.Errorf("harga emas tidak boleh negatif")
+	}
+	if s.NisabGrams <= 0 {
+		return fmt.Errorf("nisab harus lebih dari 0 gram")
+	}
+	if s.RatePercent <= 0 || s.RatePercent > 100 {
+		return fmt.Errorf("kadar zakat harus di antara 0 dan 100 persen")
+	}
+	return nil
+}
+
+// HasGoldPrice memeriksa apakah harga emas sudah diisi sehingga nisab dapat dihitung
+func (s *ZakatSettings) HasGoldPrice() bool {
+	return s.GoldPricePerGram.IsPositive()
+}
+
+// Nisab mengembalikan nisab tahunan dalam rupiah
+func (s *ZakatSettings) Nisab() money.Money {
+	return s.GoldPricePerGram.MulFloat(s.NisabGrams).Round()
+}
+
+// zakatOf menghitung zakat dari nominal sesuai kadar
+func (s *ZakatSettings) zakatOf(amount money.Money) money.Money {
+	return amount.MulFloat(s.RatePercent / 100).Round()
+}
+
+// NamedAmount nominal dengan nama (kategori atau media) untuk laporan terurut
+type NamedAmount struct {
+	Name   string      `json:"name"`
+	Amount money.Money `json:"amount"`
+
+	// Excluded menandai nominal yang tidak ikut dihitung zakat
+	Excluded bool `json:"excluded,omitempty"`
+}
+
+// ZakatMonth penghasilan dan zakat penghasilan satu bulan
+type ZakatMonth struct {
+	Month        time.Time   `json:"month"`
+	Income       money.Money `json:"income"`
+	ReachesNisab bool        `json:"reachesNisab"`
+	Zakat        money.Money `json:"zakat"`
+}
+
+// ZakatReport laporan tahunan untuk zakat dan pelaporan pajak
+type ZakatReport struct {
+	Year        int            `json:"year"`
+	Settings    *ZakatSettings `json:"settings"`
+	GeneratedAt time.Time      `json:"generatedAt"`
+
+	// AsOf batas tanggal saldo harta: akhir tahun, atau hari ini untuk tahun berjalan
+	AsOf time.Time `json:"asOf"`
+
+	// NisabKnown false jika harga emas belum diisi; zakat tidak dihitung
+	NisabKnown   bool        `json:"nisabKnown"`
+	Nisab        money.Money `json:"nisab"`
+	MonthlyNisab money.Money `json:"monthlyNisab"`
+
+	IncomeByCategory []*NamedAmount `json:"incomeByCategory"`
+	TotalIncome      money.Money    `json:"totalIncome"`
+	ZakatableIncome  money.Money    `json:"zakatableIncome"`
+	TotalExpense     money.Money    `json:"totalExpense"`
+	Months           []*ZakatMonth  `json:"months"`
+	IncomeZakat      money.Money    `json:"incomeZakat"`
+
+	AssetsByMedia    []*NamedAmount `json:"assetsByMedia"`
+	TotalAssets      money.Money    `json:"totalAssets"`
+	MaalReachesNisab bool           `json:"maalReachesNisab"`
+	MaalZakat        money.Money    `json:"maalZakat"`
+}
+
+// TotalZakat mengembalikan perkiraan zakat penghasilan ditambah zakat maal
+func (r *ZakatReport) TotalZakat() money.Money {
+	return r.IncomeZakat.Add(r.MaalZakat)
+}
+
+// BuildZakatReport menghitung laporan tahunan dari seluruh record hingga akhir tahun.
+// Saldo harta per media adalah akumulasi pemasukan dikurangi pengeluaran sejak awal pencatatan,
+// dan zakat maal diperkirakan dengan anggapan saldo akhir tahun sudah tersimpan selama satu haul.
+func BuildZakatReport(records []*FinanceRecord, year int, settings *ZakatSettings, now time.Time) *ZakatReport {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, now.Location())
+	end := start.AddDate(1, 0, 0).Add(-time.Nanosecond)
+	asOf := end
+	if now.Before(end) {
+		asOf = now
+	}
+
+	report := &ZakatReport{
+		Year:        year,
+		Settings:    settings,
+		GeneratedAt: now,
+		AsOf:        asOf,
+		NisabKnown:  settings.HasGoldPrice(),
+		Nisab:       settings.Nisab(),
+	}
+	report.MonthlyNisab = report.Nisab.Div(12).Round()
+
+	excludedCategory := nameSet(settings.ExcludedIncomeCategories)
+	excludedMedia := nameSet(settings.ExcludedStorageMedias)
+
+	incomeByCategory := make(map[string]money.Money)
+	assetsByMedia := make(map[string]money.Money)
+	monthlyIncome := make([]money.Money, 12)
+
+	for _, record := range records {
+		if record.Date.After(asOf) {
+			continue
+		}
+		if record.StorageMedia != "" {
+			assetsByMedia[record.StorageMedia] = assetsByMedia[record.StorageMedia].Add(signedAmount(record))
+		}
+		if record.Date.Before(start) {
+			continue
+		}
+
+		if record.Type == TypeExpense {
+			report.TotalExpense = report.TotalExpense.Add(record.Amount)
+			continue
+		}
+
+		incomeByCategory[record.Category] = incomeByCategory[record.Category].Add(record.Amount)
+		report.TotalIncome = report.TotalIncome.Add(record.Amount)
+		if !excludedCategory[strings.ToLower(record.Category)] {
+			report.ZakatableIncome = report.ZakatableIncome.Add(record.Amount)
+			month := int(record.Date.Month()) - 1
+			monthlyIncome[month] = monthlyIncome[month].Add(record.Amount)
+		}
+	}
+
+	report.IncomeByCategory = sortedNamedAmounts(incomeByCategory, excludedCategory)
+	report.AssetsByMedia = sortedNamedAmounts(assetsByMedia, excludedMedia)
+	for _, asset := range report.AssetsByMedia {
+		if !asset.Excluded {
+			// Saldo minus (misalnya tagihan kartu kredit) mengurangi harta sebagai utang
+			report.TotalAssets = report.TotalAssets.Add(asset.Amount)
+		}
+	}
+
+	// Zakat penghasilan: per bulan terhadap nisab bulanan, atau total setahun terhadap nisab tahunan
+	for month, income := range monthlyIncome {
+		if time.Month(month+1) > asOf.Month() && asOf.Year() == year {
+			break
+		}
+		entry := &ZakatMonth{
+			Month:  time.Date(year, time.Month(month+1), 1, 0, 0, 0, 0, now.Location()),
+			Income: income,
+		}
+		if report.NisabKnown && !settings.AnnualIncome && income.IsPositive() && income.Cmp(report.MonthlyNisab) >= 0 {
+			entry.ReachesNisab = true
+			entry.Zakat = settings.zakatOf(income)
+			report.IncomeZakat = report.IncomeZakat.Add(entry.Zakat)
+		}
+		report.Months = append(report.Months, entry)
+	}
+	if report.NisabKnown && settings.AnnualIncome && report.ZakatableIncome.Cmp(report.Nisab) >= 0 {
+		report.IncomeZakat = settings.zakatOf(report.ZakatableIncome)
+	}
+
+	if report.NisabKnown && report.TotalAssets.IsPositive() && report.TotalAssets.Cmp(report.Nisab) >= 0 {
+		report.MaalReachesNisab = true
+		report.MaalZakat = settings.zakatOf(report.TotalAssets)
+	}
+
+	return report
+}
+
+// nameSet membuat himpunan nama (huruf kecil) untuk pencocokan kategori dan media
+func nameSet(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			set[name] = true
+		}
+	}
+	return set
+}
+
+// sortedNamedAmounts mengurutkan nominal dari yang terbesar dan menandai nama yang dikecualikan
+func sortedNamedAmounts(amounts map[string]money.Money, excluded map[string]bool) []*NamedAmount {
+	list := make([]*NamedAmount, 0, len(amounts))
+	for name, amount := range amounts {
+		list = append(list, &NamedAmount{
+			Name:     name,
+			Amount:   amount,
+			Excluded: excluded[strings.ToLower(name)],
+		})
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if cmp := list[i].Amount.Cmp(list[j].Amount); cmp != 0 {
+			return cmp > 0
+		}
+		return list[i].Name < list[j].Name
+	})
+
+	return list
+}
@@ -42,6 +42,9 @@ type Message struct {
 	Caption    string
 	MediaURL   string
 	ReplyingTo *Message
+
+	// LedgerID ledger keuangan pengirim atau grup, diisi saat pesan diterima (kosong berarti ledger utama)
+	LedgerID string
 }
 
 // HasMedia memeriksa apakah pesan mengandung media
@@ -0,0 +1,16 @@
+package message
+
+import "strings"
+
+// OutgoingMedia merepresentasikan file yang akan dikirim ke chat WhatsApp
+type OutgoingMedia struct {
+	Data     []byte
+	MimeType string
+	FileName string
+	Caption  string
+}
+
+// IsImage memeriksa apakah media dikirim sebagai gambar (bukan dokumen)
+func (m *OutgoingMedia) IsImage() bool {
+	return strings.HasPrefix(m.MimeType, "image/")
+}
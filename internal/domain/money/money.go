@@ -0,0 +1,493 @@
+// Package money menyediakan tipe nominal uang presisi tetap berbasis satuan terkecil (sen)
+// agar penjumlahan transaksi tidak mengakumulasi galat pembulatan float64.
+package money
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Currency kode mata uang ISO 4217
+type Currency string
+
+const (
+	// IDR Rupiah Indonesia
+	IDR Currency = "IDR"
+
+	// DefaultCurrency mata uang pembukuan yang dipakai jika tidak disebutkan
+	DefaultCurrency = IDR
+)
+
+// Scale jumlah satuan terkecil dalam satu satuan utama; seluruh nominal disimpan dengan 2 angka desimal
+const Scale = 100
+
+// maxMinor batas nominal yang masih dapat dikonversi tanpa kehilangan presisi ke angka sheet (float64)
+const maxMinor = 1 << 53
+
+// Money nominal uang dalam satuan terkecil beserta mata uangnya.
+// Nilai nol (Money{}) berarti 0 dalam DefaultCurrency.
+type Money struct {
+	minor    int64
+	currency Currency
+}
+
+// New membuat nominal dari satuan terkecil (sen) dan mata uang
+func New(minor int64, currency Currency) Money {
+	return Money{minor: minor, currency: currency}
+}
+
+// FromMinor membuat nominal DefaultCurrency dari satuan terkecil (sen)
+func FromMinor(minor int64) Money {
+	return Money{minor: minor}
+}
+
+// FromInt membuat nominal DefaultCurrency dari satuan utama (misalnya rupiah)
+func FromInt(major int64) Money {
+	return Money{minor: major * Scale}
+}
+
+// FromFloat membuat nominal DefaultCurrency dari angka float, dibulatkan ke sen terdekat.
+// Dipakai untuk nilai yang memang berasal dari float (angka sheet, konfigurasi lama).
+func FromFloat(value float64) Money {
+	return Money{minor: int64(math.Round(value * Scale))}
+}
+
+// Minor mengembalikan nominal dalam satuan terkecil
+func (m Money) Minor() int64 {
+	return m.minor
+}
+
+// Currency mengembalikan mata uang nominal
+func (m Money) Currency() Currency {
+	if m.currency == "" {
+		return DefaultCurrency
+	}
+	return m.currency
+}
+
+// Float64 mengembalikan nominal dalam satuan utama sebagai float, untuk rasio dan nilai sel sheet.
+// Konversi ini lossless untuk nominal hingga ±90 triliun.
+func (m Money) Float64() float64 {
+	return float64(m.minor) / Scale
+}
+
+// IsZero memeriksa apakah nominal bernilai nol
+func (m Money) IsZero() bool {
+	return m.minor == 0
+}
+
+// IsPositive memeriksa apakah nominal lebih dari nol
+func (m Money) IsPositive() bool {
+	return m.minor > 0
+}
+
+// IsNegative memeriksa apakah nominal kurang dari nol
+func (m Money) IsNegative() bool {
+	return m.minor < 0
+}
+
+// Equal memeriksa apakah dua nominal sama, termasuk mata uangnya
+func (m Money) Equal(other Money) bool {
+	return m.minor == other.minor && m.Currency() == other.Currency()
+}
+
+// SameCurrency memeriksa apakah dua nominal bermata uang sama sehingga dapat dibandingkan
+func (m Money) SameCurrency(other Money) bool {
+	return m.Currency() == other.Currency()
+}
+
+// Compare membandingkan dua nominal: -1 jika lebih kecil, 0 jika sama, 1 jika lebih besar.
+// Nominal bermata uang berbeda tidak dapat dibandingkan dan mengembalikan error.
+func (m Money) Compare(other Money) (int, error) {
+	if !m.SameCurrency(other) {
+		return 0, fmt.Errorf("mata uang berbeda (%s dan %s)", m.Currency(), other.Currency())
+	}
+	switch {
+	case m.minor < other.minor:
+		return -1, nil
+	case m.minor > other.minor:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// Cmp membandingkan dua nominal: -1 jika lebih kecil, 0 jika sama, 1 jika lebih besar.
+// Nominal bermata uang berbeda diurutkan menurut kode mata uangnya agar pengurutan tidak panic;
+// gunakan Compare jika perbedaan mata uang perlu ditangani.
+func (m Money) Cmp(other Money) int {
+	cmp, err := m.Compare(other)
+	if err != nil {
+		return strings.Compare(string(m.Currency()), string(other.Currency()))
+	}
+	return cmp
+}
+
+// LessThan memeriksa apakah nominal lebih kecil dari nominal lain; false jika mata uangnya berbeda
+func (m Money) LessThan(other Money) bool {
+	cmp, err := m.Compare(other)
+	return err == nil && cmp < 0
+}
+
+// Add menjumlahkan dua nominal dengan mata uang yang sama
+func (m Money) Add(other Money) Money {
+	m.mustMatch(other)
+	return Money{minor: m.minor + other.minor, currency: m.pick(other)}
+}
+
+// Sub mengurangi nominal dengan nominal lain bermata uang sama
+func (m Money) Sub(other Money) Money {
+	m.mustMatch(other)
+	return Money{minor: m.minor - other.minor, currency: m.pick(other)}
+}
+
+// Neg mengembalikan nominal dengan tanda berlawanan
+func (m Money) Neg() Money {
+	return Money{minor: -m.minor, currency: m.currency}
+}
+
+// Abs mengembalikan nilai mutlak nominal
+func (m Money) Abs() Money {
+	if m.minor < 0 {
+		return m.Neg()
+	}
+	return m
+}
+
+// Mul mengalikan nominal dengan bilangan bulat
+func (m Money) Mul(factor int64) Money {
+	return Money{minor: m.minor * factor, currency: m.currency}
+}
+
+// MulFloat mengalikan nominal dengan faktor pecahan (misalnya persentase bunga), dibulatkan ke sen terdekat
+func (m Money) MulFloat(factor float64) Money {
+	return Money{minor: int64(math.Round(float64(m.minor) * factor)), currency: m.currency}
+}
+
+// Div membagi nominal dengan bilangan bulat, dibulatkan ke sen terdekat (setengah menjauhi nol)
+func (m Money) Div(divisor int64) Money {
+	if divisor == 0 {
+		panic("money: pembagian dengan nol")
+	}
+	return Money{minor: roundDiv(m.minor, divisor), currency: m.currency}
+}
+
+// Round membulatkan nominal ke satuan utama terdekat (misalnya rupiah penuh)
+func (m Money) Round() Money {
+	return Money{minor: roundDiv(m.minor, Scale) * Scale, currency: m.currency}
+}
+
+// Decimal mengembalikan nominal sebagai angka desimal polos berpemisah titik (150000 atau 12.50)
+func (m Money) Decimal() string {
+	sign := ""
+	minor := m.minor
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+
+	major, cents := minor/Scale, minor%Scale
+	if cents == 0 {
+		return fmt.Sprintf("%s%d", sign, major)
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, major, cents)
+}
+
+// Format mengembalikan nominal dalam format Indonesia tanpa simbol mata uang (150.000 atau 12,50)
+func (m Money) Format() string {
+	sign := ""
+	minor := m.minor
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+
+	digits := strconv.FormatInt(minor/Scale, 10)
+	var sb strings.Builder
+	for i, c := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			sb.WriteByte('.')
+		}
+		sb.WriteRune(c)
+	}
+
+	if cents := minor % Scale; cents != 0 {
+		return fmt.Sprintf("%s%s,%02d", sign, sb.String(), cents)
+	}
+	return sign + sb.String()
+}
+
+// String mengembalikan nominal desimal beserta kode mata uang jika bukan DefaultCurrency
+func (m Money) String() string {
+	if m.Currency() != DefaultCurrency {
+		return m.Decimal() + " " + string(m.Currency())
+	}
+	return m.Decimal()
+}
+
+// MarshalJSON menulis nominal sebagai angka JSON dalam satuan utama.
+// Mata uang selain DefaultCurrency ditulis sebagai string "12.50 USD" agar tidak hilang.
+func (m Money) MarshalJSON() ([]byte, error) {
+	if m.Currency() != DefaultCurrency {
+		return json.Marshal(m.String())
+	}
+	return []byte(m.Decimal()), nil
+}
+
+// UnmarshalJSON membaca nominal dari angka JSON atau string yang dapat di-parse
+func (m *Money) UnmarshalJSON(data []byte) error {
+	text := strings.TrimSpace(string(data))
+	if text == "null" {
+		*m = Money{}
+		return nil
+	}
+
+	if strings.HasPrefix(text, `"`) {
+		var value string
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+
+		// Nominal bermata uang lain yang ditulis MarshalJSON ("12.50 USD") dibaca apa adanya
+		if currency, rest, ok := splitCurrency(strings.TrimSpace(value)); ok && currency != DefaultCurrency {
+			parsed, err := parseDecimal(strings.TrimSpace(rest))
+			if err != nil {
+				return fmt.Errorf("nominal %s tidak valid", value)
+			}
+			*m = New(parsed.minor, currency)
+			return nil
+		}
+
+		parsed, err := Parse(value)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	}
+
+	parsed, err := parseDecimal(text)
+	if err != nil {
+		// Angka berformat eksponen dari data lama dibaca sebagai float
+		value, floatErr := strconv.ParseFloat(text, 64)
+		if floatErr != nil {
+			return fmt.Errorf("nominal %s tidak valid", text)
+		}
+		parsed = FromFloat(value)
+	}
+	*m = parsed
+	return nil
+}
+
+// Sum menjumlahkan seluruh nominal
+func Sum(values ...Money) Money {
+	total := Money{}
+	for _, value := range values {
+		total = total.Add(value)
+	}
+	return total
+}
+
+// currencyPrefixPattern dan currencySuffixPattern mengenali kode tiga huruf yang berdiri sendiri
+// di awal atau akhir teks nominal, sehingga kata seperti "rupiah" tidak terbaca sebagai kode
+var (
+	currencyPrefixPattern = regexp.MustCompile(`^([A-Za-z]{3})([^A-Za-z].*)$`)
+	currencySuffixPattern = regexp.MustCompile(`^(.*[^A-Za-z])([A-Za-z]{3})$`)
+)
+
+// knownCurrencies kode mata uang yang dikenali saat membaca nominal. Kode tiga huruf lain
+// dianggap bagian dari teks dan dibuang seperti huruf lainnya.
+var knownCurrencies = map[Currency]bool{
+	IDR: true, "USD": true, "EUR": true, "SGD": true, "MYR": true, "JPY": true,
+	"AUD": true, "GBP": true, "CNY": true, "SAR": true, "HKD": true, "THB": true,
+}
+
+// shorthandPattern mengenali singkatan nominal sehari-hari seperti "15rb", "15k", "1,5jt" atau "2 juta"
+var shorthandPattern = regexp.MustCompile(`(?i)^(.*\d)\s*(rb|ribu|k|jt|juta)$`)
+
+// shorthandMultipliers kelipatan untuk setiap singkatan nominal
+var shorthandMultipliers = map[string]int64{
+	"rb":   1000,
+	"ribu": 1000,
+	"k":    1000,
+	"jt":   1000000,
+	"juta": 1000000,
+}
+
+// Parse membaca teks nominal seperti "150000", "Rp 150.000", "1.234.567,89", "12.50", "50000 rupiah",
+// atau singkatan "15rb" dan "1,5jt". Pemisah dengan tepat tiga angka di belakangnya dianggap
+// pemisah ribuan, selain itu pemisah desimal. Kode mata uang selain DefaultCurrency (misalnya
+// "USD 12.50") dikenali namun ditolak karena pembukuan belum mendukung banyak mata uang.
+func Parse(text string) (Money, error) {
+	original := text
+	text = strings.TrimSpace(text)
+
+	multiplier := int64(1)
+	if match := shorthandPattern.FindStringSubmatch(text); match != nil {
+		text = strings.TrimSpace(match[1])
+		multiplier = shorthandMultipliers[strings.ToLower(match[2])]
+	}
+
+	if strings.HasPrefix(strings.ToLower(text), "rp") {
+		text = strings.TrimSpace(text[2:])
+	} else if currency, rest, ok := splitCurrency(text); ok {
+		if currency != DefaultCurrency {
+			return Money{}, fmt.Errorf("mata uang %s belum didukung, nominal harus dalam %s", currency, DefaultCurrency)
+		}
+		text = rest
+	}
+
+	negative := false
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "-") {
+		negative = true
+		text = text[1:]
+	}
+
+	// Buang karakter selain angka dan pemisah, termasuk akhiran ",-" khas penulisan rupiah
+	cleaned := strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || r == '.' || r == ',' {
+			return r
+		}
+		return -1
+	}, text)
+	cleaned = strings.TrimRight(cleaned, ".,")
+	if cleaned == "" {
+		return Money{}, fmt.Errorf("nominal %q tidak valid", original)
+	}
+
+	parsed, err := parseDecimal(normalizeSeparators(cleaned))
+	if err != nil {
+		return Money{}, fmt.Errorf("nominal %q tidak valid", original)
+	}
+	// Periksa batas sebelum mengalikan agar singkatan besar seperti "90000000000jt" tidak overflow
+	if parsed.minor > maxMinor/multiplier {
+		return Money{}, fmt.Errorf("nominal %q tidak valid", original)
+	}
+	parsed = parsed.Mul(multiplier)
+	if negative {
+		parsed = parsed.Neg()
+	}
+
+	return parsed, nil
+}
+
+// splitCurrency memisahkan kode mata uang yang dikenali di awal atau akhir teks nominal
+func splitCurrency(text string) (Currency, string, bool) {
+	if match := currencyPrefixPattern.FindStringSubmatch(text); match != nil {
+		if currency := Currency(strings.ToUpper(match[1])); knownCurrencies[currency] {
+			return currency, match[2], true
+		}
+	}
+	if match := currencySuffixPattern.FindStringSubmatch(text); match != nil {
+		if currency := Currency(strings.ToUpper(match[2])); knownCurrencies[currency] {
+			return currency, match[1], true
+		}
+	}
+	return "", text, false
+}
+
+// normalizeSeparators mengubah pemisah ribuan dan desimal ke format desimal polos (titik sebagai desimal)
+func normalizeSeparators(text string) string {
+	lastDot := strings.LastIndex(text, ".")
+	lastComma := strings.LastIndex(text, ",")
+
+	// Kedua pemisah muncul: yang terakhir adalah pemisah desimal
+	if lastDot >= 0 && lastComma >= 0 {
+		if lastComma > lastDot {
+			return strings.Replace(strings.ReplaceAll(text, ".", ""), ",", ".", 1)
+		}
+		return strings.ReplaceAll(text, ",", "")
+	}
+
+	separator := "."
+	if lastComma >= 0 {
+		separator = ","
+	}
+
+	count := strings.Count(text, separator)
+	if count == 0 {
+		return text
+	}
+
+	// Lebih dari satu pemisah sejenis atau tepat tiga angka di belakangnya berarti pemisah ribuan
+	last := strings.LastIndex(text, separator)
+	if count > 1 || len(text)-last-1 == 3 {
+		return strings.ReplaceAll(text, separator, "")
+	}
+	return strings.Replace(text, separator, ".", 1)
+}
+
+// parseDecimal membaca angka desimal polos (titik sebagai pemisah desimal) tanpa melalui float.
+// Angka di belakang sen dibulatkan setengah menjauhi nol.
+func parseDecimal(text string) (Money, error) {
+	negative := strings.HasPrefix(text, "-")
+	text = strings.TrimPrefix(text, "-")
+
+	intPart, fracPart, _ := strings.Cut(text, ".")
+	if intPart == "" {
+		intPart = "0"
+	}
+	if strings.Trim(intPart, "0123456789") != "" || strings.Trim(fracPart, "0123456789") != "" {
+		return Money{}, fmt.Errorf("angka %q tidak valid", text)
+	}
+
+	major, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil || major > maxMinor/Scale {
+		return Money{}, fmt.Errorf("angka %q terlalu besar", text)
+	}
+
+	roundUp := len(fracPart) > 2 && fracPart[2] >= '5'
+	fracPart = (fracPart + "00")[:2]
+	cents, _ := strconv.ParseInt(fracPart, 10, 64)
+
+	minor := major*Scale + cents
+	if roundUp {
+		minor++
+	}
+	if negative {
+		minor = -minor
+	}
+
+	return Money{minor: minor}, nil
+}
+
+// roundDiv membagi bilangan bulat dengan pembulatan setengah menjauhi nol
+func roundDiv(value, divisor int64) int64 {
+	quotient, remainder := value/divisor, value%divisor
+	if remainder < 0 {
+		remainder = -remainder
+	}
+	absDivisor := divisor
+	if absDivisor < 0 {
+		absDivisor = -absDivisor
+	}
+
+	if remainder*2 >= absDivisor {
+		if (value < 0) != (divisor < 0) {
+			quotient--
+		} else {
+			quotient++
+		}
+	}
+	return quotient
+}
+
+// mustMatch memastikan dua nominal bermata uang sama sebelum dioperasikan
+func (m Money) mustMatch(other Money) {
+	if m.Currency() != other.Currency() {
+		panic(fmt.Sprintf("money: mata uang berbeda (%s dan %s)", m.Currency(), other.Currency()))
+	}
+}
+
+// pick mempertahankan mata uang eksplisit salah satu operand
+func (m Money) pick(other Money) Currency {
+	if m.currency != "" {
+		return m.currency
+	}
+	return other.currency
+}
@@ -0,0 +1,88 @@
+package money
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Money
+		wantErr bool
+	}{
+		{input: "15000", want: FromInt(15000)},
+		{input: "15rb", want: FromInt(15000)},
+		{input: "15 ribu", want: FromInt(15000)},
+		{input: "15k", want: FromInt(15000)},
+		{input: "1,5jt", want: FromInt(1500000)},
+		{input: "2 juta", want: FromInt(2000000)},
+		{input: "Rp15.000", want: FromInt(15000)},
+		{input: "Rp 150.000,-", want: FromInt(150000)},
+		{input: "1.000,50", want: FromMinor(100050)},
+		{input: "1,000.50", want: FromMinor(100050)},
+		{input: "12.50", want: FromMinor(1250)},
+		{input: "1.234.567", want: FromInt(1234567)},
+		{input: "50000 rupiah", want: FromInt(50000)},
+		{input: "IDR 75000", want: FromInt(75000)},
+		{input: "-5000", want: FromInt(-5000)},
+		{input: "USD 100", wantErr: true},
+		{input: "100usd", wantErr: true},
+		{input: "abc", wantErr: true},
+		{input: "", wantErr: true},
+		{input: "90000000000jt", wantErr: true},
+		{input: "9000000000 juta", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Parse(%q) = %v, ingin error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Parse(%q) = %v, ingin %v", tt.input, got, tt.want)
+			}
+			if got.Currency() != DefaultCurrency {
+				t.Errorf("Parse(%q) mata uang %s, ingin %s", tt.input, got.Currency(), DefaultCurrency)
+			}
+		})
+	}
+}
+
+func TestCompareDifferentCurrency(t *testing.T) {
+	idr := FromInt(100)
+	usd := New(100*Scale, "USD")
+
+	if _, err := usd.Compare(idr); err == nil {
+		t.Error("Compare mata uang berbeda seharusnya error")
+	}
+	if usd.LessThan(idr) || idr.LessThan(usd) {
+		t.Error("LessThan mata uang berbeda seharusnya false")
+	}
+
+	// Cmp tidak boleh panic agar pengurutan tetap aman
+	if usd.Cmp(idr) == 0 {
+		t.Error("Cmp mata uang berbeda seharusnya tidak sama")
+	}
+}
+
+func TestJSONRoundTripKeepsCurrency(t *testing.T) {
+	original := New(1250, "USD")
+
+	data, err := original.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON error: %v", err)
+	}
+
+	var decoded Money
+	if err := decoded.UnmarshalJSON(data); err != nil {
+		t.Fatalf("UnmarshalJSON(%s) error: %v", data, err)
+	}
+	if !decoded.Equal(original) {
+		t.Errorf("UnmarshalJSON(%s) = %v, ingin %v", data, decoded, original)
+	}
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+)
+
+// ActionJournalRepository mendefinisikan kontrak penyimpanan jurnal aksi untuk pembatalan
+type ActionJournalRepository interface {
+	// Save menyimpan atau memperbarui entri jurnal
+	Save(ctx context.Context, entry *finance.JournalEntry) error
+
+	// FindLatestByPhone mencari entri terbaru milik nomor pengirim yang belum dibatalkan (nil jika tidak ada)
+	FindLatestByPhone(ctx context.Context, phone string) (*finance.JournalEntry, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+)
+
+// ApprovalRepository mendefinisikan kontrak penyimpanan pengajuan persetujuan pengeluaran
+type ApprovalRepository interface {
+	// FindAll mendapatkan seluruh pengajuan, diurutkan dari yang paling lama
+	FindAll(ctx context.Context) ([]*finance.ApprovalRequest, error)
+
+	// FindByCode mencari pengajuan berdasarkan kode persetujuan
+	FindByCode(ctx context.Context, code string) (*finance.ApprovalRequest, error)
+
+	// Save menyimpan atau memperbarui pengajuan
+	Save(ctx context.Context, request *finance.ApprovalRequest) error
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gwenziro/botopia/internal/domain/audit"
+)
+
+// AuditRepository mendefinisikan kontrak penyimpanan audit log yang hanya bisa ditambah
+type AuditRepository interface {
+	// Append menambahkan entri di akhir log
+	Append(ctx context.Context, entry *audit.Entry) error
+
+	// Last mendapatkan entri terakhir (nil jika log masih kosong)
+	Last(ctx context.Context) (*audit.Entry, error)
+
+	// FindAll mendapatkan seluruh entri sesuai urutan penulisan
+	FindAll(ctx context.Context) ([]*audit.Entry, error)
+}
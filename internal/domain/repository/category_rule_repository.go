@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+)
+
+// CategoryRuleRepository mendefinisikan kontrak untuk repository aturan kategori
+type CategoryRuleRepository interface {
+	// FindAll mendapatkan semua aturan kategori
+	FindAll(ctx context.Context) ([]*finance.CategoryRule, error)
+
+	// FindByID mencari aturan berdasarkan ID
+	FindByID(ctx context.Context, id string) (*finance.CategoryRule, error)
+
+	// Save menyimpan aturan baru atau memperbarui yang sudah ada
+	Save(ctx context.Context, rule *finance.CategoryRule) error
+
+	// Delete menghapus aturan
+	Delete(ctx context.Context, id string) error
+}
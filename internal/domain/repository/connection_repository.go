@@ -24,6 +24,9 @@ type ConnectionRepository interface {
 	// SendMessage mengirim pesan
 	SendMessage(ctx context.Context, chatID string, text string) error
 
+	// SendMedia mengirim gambar atau dokumen
+	SendMedia(ctx context.Context, chatID string, media *message.OutgoingMedia) error
+
 	// RegisterMessageHandler mendaftarkan handler untuk pesan masuk
 	RegisterMessageHandler(handler func(*message.Message))
 
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/gwenziro/botopia/internal/domain/finance"
 )
@@ -17,6 +18,16 @@ type FinanceRepository interface {
 	// GetRecentRecords mendapatkan record keuangan terbaru
 	GetRecentRecords(ctx context.Context, limit int) ([]*finance.FinanceRecord, error)
 
+	// GetAllRecords mendapatkan seluruh record keuangan (pemasukan & pengeluaran), termasuk tahun yang diarsipkan
+	GetAllRecords(ctx context.Context) ([]*finance.FinanceRecord, error)
+
+	// GetRecordsBetween mendapatkan record dari penyimpanan yang mencakup rentang tanggal (nol berarti tidak dibatasi)
+	GetRecordsBetween(ctx context.Context, from, to time.Time) ([]*finance.FinanceRecord, error)
+
+	// EnsurePartition memastikan penyimpanan untuk periode tanggal tertentu tersedia,
+	// mengembalikan ID spreadsheet dan apakah spreadsheet baru dibuat
+	EnsurePartition(ctx context.Context, date time.Time) (string, bool, error)
+
 	// GetConfiguration mendapatkan konfigurasi keuangan
 	GetConfiguration(ctx context.Context) (*finance.Configuration, error)
 
@@ -35,6 +46,24 @@ type FinanceRepository interface {
 	// UpdateRecordProof memperbarui URL bukti transaksi
 	UpdateRecordProof(ctx context.Context, code string, proofURL string) error
 
+	// UpdateRecord memperbarui data record berdasarkan kode unik (kode, nomor, dan bukti tidak berubah)
+	UpdateRecord(ctx context.Context, record *finance.FinanceRecord) error
+
+	// DeleteRecord menghapus record berdasarkan kode unik
+	DeleteRecord(ctx context.Context, code string) error
+
+	// GetAttachments mendapatkan seluruh lampiran bukti untuk kode transaksi
+	GetAttachments(ctx context.Context, code string) ([]*finance.Attachment, error)
+
+	// AddAttachment menambahkan lampiran bukti transaksi
+	AddAttachment(ctx context.Context, attachment *finance.Attachment) error
+
+	// FindAttachmentByHash mencari lampiran dengan hash konten tertentu (nil jika tidak ada)
+	FindAttachmentByHash(ctx context.Context, hash string) (*finance.Attachment, error)
+
+	// DeleteAttachments menghapus seluruh lampiran bukti untuk kode transaksi
+	DeleteAttachments(ctx context.Context, code string) error
+
 	// UpdateConfiguration memperbarui konfigurasi
 	UpdateConfiguration(ctx context.Context, config *finance.Configuration) error
 }
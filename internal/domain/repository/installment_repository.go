@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+)
+
+// InstallmentRepository mendefinisikan kontrak penyimpanan rencana cicilan
+type InstallmentRepository interface {
+	// FindAll mendapatkan seluruh rencana cicilan, diurutkan dari yang paling lama
+	FindAll(ctx context.Context) ([]*finance.InstallmentPlan, error)
+
+	// FindByCode mencari rencana cicilan berdasarkan kode (nil jika tidak ditemukan)
+	FindByCode(ctx context.Context, code string) (*finance.InstallmentPlan, error)
+
+	// Save menyimpan atau memperbarui rencana cicilan
+	Save(ctx context.Context, plan *finance.InstallmentPlan) error
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+)
+
+// LedgerRepository mendefinisikan kontrak penyimpanan ledger tambahan
+type LedgerRepository interface {
+	// FindAll mendapatkan seluruh ledger, diurutkan berdasarkan nama
+	FindAll(ctx context.Context) ([]*finance.Ledger, error)
+
+	// FindByID mencari ledger berdasarkan ID (nil jika tidak ditemukan)
+	FindByID(ctx context.Context, id string) (*finance.Ledger, error)
+
+	// Save menyimpan atau memperbarui ledger
+	Save(ctx context.Context, ledger *finance.Ledger) error
+
+	// Delete menghapus ledger
+	Delete(ctx context.Context, id string) error
+}
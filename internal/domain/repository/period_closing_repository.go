@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+)
+
+// PeriodClosingRepository mendefinisikan kontrak penyimpanan periode tutup buku
+type PeriodClosingRepository interface {
+	// FindAll mendapatkan seluruh periode tertutup di ledger context, diurutkan dari periode terlama
+	FindAll(ctx context.Context) ([]*finance.PeriodClosing, error)
+
+	// FindByPeriod mencari periode tertutup di ledger context (nil jika periode masih terbuka)
+	FindByPeriod(ctx context.Context, period string) (*finance.PeriodClosing, error)
+
+	// Save menyimpan periode tertutup
+	Save(ctx context.Context, closing *finance.PeriodClosing) error
+
+	// Delete membuka kembali periode di ledger context dengan menghapus data tutup bukunya
+	Delete(ctx context.Context, period string) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+)
+
+// ProofStorageRepository mendefinisikan kontrak penyimpanan file bukti transaksi
+type ProofStorageRepository interface {
+	// GetName mengembalikan nama backend penyimpanan (google, local, s3)
+	GetName() string
+
+	// IsConfigured memeriksa apakah penyimpanan sudah dikonfigurasi
+	IsConfigured() bool
+
+	// UploadProof menyimpan file bukti untuk transaksi dan mengembalikan URL-nya
+	UploadProof(ctx context.Context, filePath string, transactionCode string) (string, error)
+
+	// DownloadProof mengambil isi file bukti dari URL yang dihasilkan UploadProof
+	DownloadProof(ctx context.Context, proofURL string) ([]byte, error)
+}
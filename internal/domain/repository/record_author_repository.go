@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+)
+
+// RecordAuthorRepository mendefinisikan kontrak penyimpanan pembuat transaksi
+type RecordAuthorRepository interface {
+	// FindAll mendapatkan seluruh data pembuat transaksi
+	FindAll(ctx context.Context) ([]*finance.RecordAuthor, error)
+
+	// FindByCode mencari pembuat transaksi berdasarkan kode transaksi di ledger context
+	FindByCode(ctx context.Context, code string) (*finance.RecordAuthor, error)
+
+	// Save menyimpan atau memperbarui data pembuat transaksi
+	Save(ctx context.Context, author *finance.RecordAuthor) error
+}
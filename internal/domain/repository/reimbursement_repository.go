@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+)
+
+// ReimbursementRepository mendefinisikan kontrak penyimpanan klaim reimbursement
+type ReimbursementRepository interface {
+	// FindAll mendapatkan seluruh klaim reimbursement, diurutkan dari yang paling lama
+	FindAll(ctx context.Context) ([]*finance.ReimbursementClaim, error)
+
+	// FindByCode mencari klaim berdasarkan kode (nil jika tidak ditemukan)
+	FindByCode(ctx context.Context, code string) (*finance.ReimbursementClaim, error)
+
+	// Save menyimpan atau memperbarui klaim
+	Save(ctx context.Context, claim *finance.ReimbursementClaim) error
+}
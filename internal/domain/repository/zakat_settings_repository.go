@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+)
+
+// ZakatSettingsRepository mendefinisikan kontrak penyimpanan pengaturan zakat per ledger
+type ZakatSettingsRepository interface {
+	// Get mendapatkan pengaturan zakat ledger context (nil jika belum pernah diatur)
+	Get(ctx context.Context) (*finance.ZakatSettings, error)
+
+	// Save menyimpan pengaturan zakat sesuai ledger pada pengaturan
+	Save(ctx context.Context, settings *finance.ZakatSettings) error
+}
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+)
+
+// AnomalyService memeriksa pengeluaran baru terhadap riwayat pengirim dan memberi tahu pemilik jika tidak biasa
+type AnomalyService interface {
+	// Inspect memeriksa pengeluaran yang baru dicatat; kejanggalan juga dikirim ke pemilik selain pengirim
+	Inspect(ctx context.Context, record *finance.FinanceRecord) ([]*finance.SpendingAnomaly, error)
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/money"
+)
+
+// ApprovalService mendefinisikan alur persetujuan untuk pengeluaran besar
+type ApprovalService interface {
+	// GetThreshold mengembalikan batas nominal pengeluaran yang memerlukan persetujuan
+	GetThreshold() money.Money
+
+	// IsApprover memeriksa apakah nomor telepon termasuk approver
+	IsApprover(phone string) bool
+
+	// RequiresApproval memeriksa apakah record dari pengaju perlu disetujui sebelum dicatat
+	RequiresApproval(record *finance.FinanceRecord, requesterPhone string) bool
+
+	// Submit menyimpan pengajuan dan memberi tahu para approver
+	Submit(ctx context.Context, record *finance.FinanceRecord, proofPath string, requester *finance.RecordAuthor) (*finance.ApprovalRequest, error)
+
+	// ListPending mendapatkan pengajuan yang masih menunggu keputusan
+	ListPending(ctx context.Context) ([]*finance.ApprovalRequest, error)
+
+	// Approve mencatat pengajuan ke pembukuan dan memberi tahu pengaju
+	Approve(ctx context.Context, code string, approverPhone string) (*finance.ApprovalRequest, error)
+
+	// Reject menolak pengajuan dengan alasan dan memberi tahu pengaju
+	Reject(ctx context.Context, code string, approverPhone string, reason string) (*finance.ApprovalRequest, error)
+}
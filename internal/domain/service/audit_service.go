@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	"github.com/gwenziro/botopia/internal/domain/audit"
+)
+
+// AuditService mendefinisikan layanan audit log perubahan data keuangan
+type AuditService interface {
+	// Record mencatat perubahan beserta nilai sebelum dan sesudahnya; actor diambil dari context
+	Record(ctx context.Context, action audit.Action, target string, before, after interface{}) error
+
+	// List mendapatkan entri yang sesuai filter, terbaru lebih dulu
+	List(ctx context.Context, filter *audit.Filter) ([]*audit.Entry, error)
+
+	// Verify memeriksa keutuhan rantai hash audit log
+	Verify(ctx context.Context) (*audit.VerifyResult, error)
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+)
+
+// CategoryRuleService mendefinisikan layanan untuk aturan kategorisasi otomatis
+type CategoryRuleService interface {
+	// GetAllRules mendapatkan semua aturan kategori
+	GetAllRules(ctx context.Context) ([]*finance.CategoryRule, error)
+
+	// GetRule mendapatkan aturan berdasarkan ID
+	GetRule(ctx context.Context, id string) (*finance.CategoryRule, error)
+
+	// AddRule menambahkan aturan baru
+	AddRule(ctx context.Context, rule *finance.CategoryRule) (*finance.CategoryRule, error)
+
+	// UpdateRule memperbarui aturan yang sudah ada
+	UpdateRule(ctx context.Context, rule *finance.CategoryRule) (*finance.CategoryRule, error)
+
+	// DeleteRule menghapus aturan
+	DeleteRule(ctx context.Context, id string) error
+
+	// RestoreRule menyimpan kembali aturan apa adanya untuk membatalkan perubahan atau penghapusan
+	RestoreRule(ctx context.Context, rule *finance.CategoryRule) (*finance.CategoryRule, error)
+
+	// ApplyRules menerapkan aturan aktif ke record dan mengembalikan aturan yang cocok
+	ApplyRules(ctx context.Context, record *finance.FinanceRecord) ([]*finance.CategoryRule, error)
+
+	// TestRule menguji aturan terhadap riwayat transaksi
+	TestRule(ctx context.Context, rule *finance.CategoryRule) (*finance.RuleTestResult, error)
+
+	// SuggestRules menyarankan aturan berdasarkan pasangan deskripsi→kategori di riwayat
+	SuggestRules(ctx context.Context, minCount int) ([]*finance.CategorySuggestion, error)
+}
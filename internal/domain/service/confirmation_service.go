@@ -0,0 +1,36 @@
+package service
+
+import "time"
+
+// PendingConfirmation adalah aksi yang menunggu jawaban "ya" atau "tidak" dari pengguna
+type PendingConfirmation struct {
+	// OnConfirm dijalankan ketika pengguna menjawab "ya" dan mengembalikan balasan
+	OnConfirm func() string
+
+	// OnCancel dijalankan ketika pengguna menjawab "tidak" dan mengembalikan balasan
+	OnCancel func() string
+
+	// OnExpire dijalankan ketika konfirmasi kedaluwarsa tanpa jawaban (opsional)
+	OnExpire func()
+
+	// ExpiresAt adalah batas waktu menjawab
+	ExpiresAt time.Time
+}
+
+// ConfirmationService mengelola konfirmasi "ya/tidak" per pengirim di sebuah chat
+type ConfirmationService interface {
+	// Ask menyimpan konfirmasi baru, menggantikan konfirmasi sebelumnya untuk key yang sama
+	Ask(key string, pending *PendingConfirmation)
+
+	// Resolve menjalankan konfirmasi jika jawaban berupa "ya" atau "tidak".
+	// Mengembalikan handled=false jika tidak ada konfirmasi atau teks bukan jawaban.
+	Resolve(key string, answer string) (response string, handled bool)
+
+	// HasPending memeriksa apakah ada konfirmasi yang menunggu jawaban
+	HasPending(key string) bool
+}
+
+// ConfirmationKey membuat key konfirmasi dari ID chat dan nomor pengirim
+func ConfirmationKey(chatID, senderPhone string) string {
+	return chatID + "|" + senderPhone
+}
@@ -5,21 +5,22 @@ import (
 	"time"
 
 	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/money"
 )
 
 // FinanceService mendefinisikan layanan untuk manajemen keuangan
 type FinanceService interface {
 	// AddIncome menambahkan record pemasukan baru
-	AddIncome(ctx context.Context, description string, amount float64, category, storageMedia, notes string, proofURL string) (*finance.FinanceRecord, error)
+	AddIncome(ctx context.Context, description string, amount money.Money, category, storageMedia, notes string, proofURL string) (*finance.FinanceRecord, error)
 
 	// AddExpense menambahkan record pengeluaran baru
-	AddExpense(ctx context.Context, description string, amount float64, category, paymentMethod, storageMedia, notes string, proofURL string) (*finance.FinanceRecord, error)
+	AddExpense(ctx context.Context, description string, amount money.Money, category, paymentMethod, storageMedia, notes string, proofURL string) (*finance.FinanceRecord, error)
 
 	// AddIncomeWithDate menambahkan record pemasukan baru dengan tanggal kustom
-	AddIncomeWithDate(ctx context.Context, date time.Time, description string, amount float64, category, storageMedia, notes string, proofURL string) (*finance.FinanceRecord, error)
+	AddIncomeWithDate(ctx context.Context, date time.Time, description string, amount money.Money, category, storageMedia, notes string, proofURL string) (*finance.FinanceRecord, error)
 
 	// AddExpenseWithDate menambahkan record pengeluaran baru dengan tanggal kustom
-	AddExpenseWithDate(ctx context.Context, date time.Time, description string, amount float64, category, paymentMethod, storageMedia, notes string, proofURL string) (*finance.FinanceRecord, error)
+	AddExpenseWithDate(ctx context.Context, date time.Time, description string, amount money.Money, category, paymentMethod, storageMedia, notes string, proofURL string) (*finance.FinanceRecord, error)
 
 	// GetConfiguration mendapatkan konfigurasi keuangan
 	GetConfiguration(ctx context.Context) (*finance.Configuration, error)
@@ -30,15 +31,45 @@ type FinanceService interface {
 	// ValidateAddExpenseParams memvalidasi parameter untuk penambahan pengeluaran
 	ValidateAddExpenseParams(ctx context.Context, category, paymentMethod, storageMedia string) error
 
+	// ApplyCategoryRules melengkapi kategori, metode, dan sumber record berdasarkan aturan kategori
+	ApplyCategoryRules(ctx context.Context, record *finance.FinanceRecord) error
+
+	// FindDuplicateRecords mencari record tersimpan yang kemungkinan sama dengan record baru
+	FindDuplicateRecords(ctx context.Context, record *finance.FinanceRecord) ([]*finance.FinanceRecord, error)
+
 	// GetSpreadsheetURL mendapatkan URL spreadsheet
 	GetSpreadsheetURL() string
 
 	// GetRecentRecords mendapatkan record keuangan terbaru
 	GetRecentRecords(ctx context.Context, limit int) ([]*finance.FinanceRecord, error)
 
-	// UploadTransactionProof mengunggah bukti transaksi
+	// UploadTransactionProof menambahkan bukti transaksi ke daftar lampiran record
 	UploadTransactionProof(ctx context.Context, transactionCode string, filePath string) (*finance.FinanceRecord, error)
 
+	// ReplaceTransactionProof mengganti seluruh bukti transaksi dengan file baru
+	ReplaceTransactionProof(ctx context.Context, transactionCode string, filePath string) (*finance.FinanceRecord, error)
+
+	// ListRecords mendapatkan record yang memenuhi filter, diurutkan dari yang terbaru
+	ListRecords(ctx context.Context, filter *finance.RecordFilter) ([]*finance.FinanceRecord, error)
+
+	// UpdateRecord memperbarui data transaksi; kode, jenis, dan bukti transaksi tidak berubah
+	UpdateRecord(ctx context.Context, record *finance.FinanceRecord) (*finance.FinanceRecord, error)
+
+	// DeleteRecord menghapus transaksi beserta seluruh lampirannya
+	DeleteRecord(ctx context.Context, code string) error
+
+	// RestoreTransactionProof mengembalikan bukti transaksi ke URL dan daftar lampiran sebelumnya
+	RestoreTransactionProof(ctx context.Context, code string, proofURL string, attachments []*finance.Attachment) (*finance.FinanceRecord, error)
+
+	// GetRecordByCode mendapatkan record beserta seluruh lampirannya
+	GetRecordByCode(ctx context.Context, code string) (*finance.FinanceRecord, error)
+
+	// SaveRecordAuthor mencatat pengguna yang membuat transaksi
+	SaveRecordAuthor(ctx context.Context, author *finance.RecordAuthor) error
+
+	// DownloadAttachment mengambil isi file lampiran dari penyimpanan bukti
+	DownloadAttachment(ctx context.Context, attachment *finance.Attachment) ([]byte, error)
+
 	// UpdateConfiguration memperbarui konfigurasi keuangan
 	UpdateConfiguration(ctx context.Context, config *finance.Configuration) error
 }
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+)
+
+// ForecastService memproyeksikan saldo setiap media penyimpanan berdasarkan riwayat transaksi
+type ForecastService interface {
+	// GetForecast memproyeksikan saldo hingga akhir bulan berjalan ditambah months bulan berikutnya
+	GetForecast(ctx context.Context, months int) (*finance.CashFlowForecast, error)
+}
@@ -0,0 +1,72 @@
+package service
+
+import "github.com/gwenziro/botopia/internal/domain/message"
+
+// FormField adalah satu pertanyaan dalam sesi formulir interaktif
+type FormField struct {
+	// Key adalah nama nilai pada hasil formulir
+	Key string
+
+	// Prompt adalah pertanyaan yang dikirim ke pengguna
+	Prompt string
+
+	// Hint adalah contoh jawaban (opsional)
+	Hint string
+
+	// Choices adalah pilihan jawaban yang ditampilkan bernomor (opsional).
+	// Pengguna dapat menjawab dengan nomor atau nama pilihan.
+	Choices []string
+
+	// Optional mengizinkan pengguna melewati pertanyaan dengan "-" atau "lewati"
+	Optional bool
+
+	// AcceptMedia menerima jawaban berupa foto/dokumen; nilainya adalah path file lokal
+	AcceptMedia bool
+
+	// Validate memeriksa jawaban dan mengembalikan nilai yang sudah dinormalisasi (opsional)
+	Validate func(answer string) (string, error)
+}
+
+// FormSession adalah formulir yang diisi satu per satu melalui percakapan
+type FormSession struct {
+	// Title adalah judul formulir yang tampil di setiap pertanyaan
+	Title string
+
+	// Fields adalah daftar pertanyaan sesuai urutan
+	Fields []FormField
+
+	// Values berisi jawaban; dapat diisi sebelum sesi dimulai agar pertanyaannya dilewati
+	Values map[string]string
+
+	// OnComplete dijalankan setelah semua pertanyaan terjawab dan mengembalikan balasan.
+	// File media pada Values menjadi tanggung jawab OnComplete.
+	OnComplete func(values map[string]string, msg *message.Message) string
+
+	// OnCancel dijalankan ketika pengguna membatalkan sesi (opsional)
+	OnCancel func() string
+
+	// OnExpire dijalankan ketika sesi berakhir karena tidak ada aktivitas (opsional)
+	OnExpire func()
+}
+
+// FormSessionService mengelola sesi formulir interaktif per pengirim di sebuah chat
+type FormSessionService interface {
+	// Start memulai sesi baru, menggantikan sesi sebelumnya untuk key yang sama,
+	// dan mengembalikan pertanyaan pertama
+	Start(key string, session *FormSession, msg *message.Message) string
+
+	// Handle memproses jawaban untuk sesi yang aktif.
+	// Mengembalikan handled=false jika tidak ada sesi atau pesan berupa command.
+	Handle(key string, msg *message.Message) (response string, handled bool)
+
+	// Cancel menghentikan sesi yang aktif tanpa menjalankan OnCancel
+	Cancel(key string) bool
+
+	// HasActive memeriksa apakah ada sesi yang sedang berjalan
+	HasActive(key string) bool
+}
+
+// FormSessionKey membuat key sesi formulir dari ID chat dan nomor pengirim
+func FormSessionKey(chatID, senderPhone string) string {
+	return ConfirmationKey(chatID, senderPhone)
+}
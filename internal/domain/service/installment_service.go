@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/money"
+)
+
+// InstallmentService mengelola rencana cicilan dan mencatat pengeluaran bulanannya secara otomatis
+type InstallmentService interface {
+	// Start menjalankan pencatatan cicilan jatuh tempo secara berkala di background
+	Start()
+
+	// Stop menghentikan pencatatan berkala
+	Stop()
+
+	// Create menyimpan rencana cicilan baru dan langsung mencatat cicilan yang sudah jatuh tempo
+	Create(ctx context.Context, plan *finance.InstallmentPlan) (*finance.InstallmentPlan, error)
+
+	// List mendapatkan seluruh rencana cicilan, diurutkan dari yang paling lama
+	List(ctx context.Context) ([]*finance.InstallmentPlan, error)
+
+	// Get mendapatkan rencana cicilan berdasarkan kode
+	Get(ctx context.Context, code string) (*finance.InstallmentPlan, error)
+
+	// PayOff melunasi sisa cicilan sekaligus; amount 0 berarti sebesar sisa tagihan
+	PayOff(ctx context.Context, code string, amount money.Money) (*finance.InstallmentPlan, *finance.FinanceRecord, error)
+
+	// ProcessDue mencatat seluruh cicilan yang sudah jatuh tempo dan mengembalikan jumlah pengeluaran tercatat
+	ProcessDue(ctx context.Context) (int, error)
+}
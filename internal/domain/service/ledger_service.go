@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+)
+
+// LedgerService mengelola ledger terpisah per kontak atau grup dan menentukan ledger pengirim pesan
+type LedgerService interface {
+	// List mendapatkan seluruh ledger tambahan, diurutkan berdasarkan nama
+	List(ctx context.Context) ([]*finance.Ledger, error)
+
+	// Get mendapatkan ledger berdasarkan ID
+	Get(ctx context.Context, id string) (*finance.Ledger, error)
+
+	// Save menyimpan ledger baru atau memperbarui nama dan spreadsheet ledger yang sudah ada
+	Save(ctx context.Context, ledger *finance.Ledger) (*finance.Ledger, error)
+
+	// Delete menghapus ledger yang tidak lagi dipakai kontak mana pun
+	Delete(ctx context.Context, id string) error
+
+	// Assign mengarahkan kontak atau grup ke ledger; ID kosong berarti kembali ke ledger utama
+	Assign(ctx context.Context, phone, ledgerID string) error
+
+	// Resolve menentukan ledger pesan: ledger grup jika pesan dari grup yang dipetakan, lalu ledger pengirim
+	Resolve(ctx context.Context, chatID string, isGroup bool, senderPhone string) (string, error)
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+)
+
+// PeriodClosingService mengelola tutup buku bulanan dan penguncian periode tertutup
+type PeriodClosingService interface {
+	// Close menutup buku periode (YYYY-MM) dan menyimpan ringkasan angkanya
+	Close(ctx context.Context, period string) (*finance.PeriodClosing, error)
+
+	// Reopen membuka kembali periode yang sudah tutup buku
+	Reopen(ctx context.Context, period string) error
+
+	// GetClosing mendapatkan data tutup buku periode (nil jika periode masih terbuka)
+	GetClosing(ctx context.Context, period string) (*finance.PeriodClosing, error)
+
+	// ListClosings mendapatkan seluruh periode tertutup, diurutkan dari periode terlama
+	ListClosings(ctx context.Context) ([]*finance.PeriodClosing, error)
+
+	// CheckWritable memastikan transaksi bertanggal date boleh ditambah, diubah, atau dihapus.
+	// Periode tertutup hanya dapat diubah dengan izin eksplisit; perubahan tersebut dicatat di audit log.
+	CheckWritable(ctx context.Context, date time.Time, operation, target string) error
+
+	// AllowOverride memberi admin pengirim izin sementara mengubah periode tertutup dan
+	// mengembalikan batas waktu izin tersebut
+	AllowOverride(ctx context.Context, period string) (time.Time, error)
+
+	// IsAdmin memeriksa apakah nomor WhatsApp termasuk admin keuangan
+	IsAdmin(phone string) bool
+}
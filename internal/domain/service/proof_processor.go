@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+)
+
+// ProofProcessor memproses file bukti sebelum diunggah ke penyimpanan
+type ProofProcessor interface {
+	// Process menormalkan file bukti (metadata, orientasi, ukuran) dan menghitung hash kontennya
+	Process(ctx context.Context, filePath string) (*finance.ProcessedProof, error)
+}
@@ -0,0 +1,15 @@
+package service
+
+import "context"
+
+// ProofReminderService mengirim pengingat berkala untuk transaksi yang belum memiliki bukti
+type ProofReminderService interface {
+	// Start menjalankan pengecekan pengingat secara berkala di background
+	Start()
+
+	// Stop menghentikan pengecekan berkala
+	Stop()
+
+	// SendReminders mengirim ringkasan pengingat ke setiap pembuat transaksi dan mengembalikan jumlah pesan terkirim
+	SendReminders(ctx context.Context) (int, error)
+}
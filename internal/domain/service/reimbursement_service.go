@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+)
+
+// ReimbursementService mengelola pengeluaran yang diklaim ke kantor beserta status penggantiannya
+type ReimbursementService interface {
+	// Flag menandai pengeluaran sebagai reimbursable dengan menambahkan tag #reimburse
+	Flag(ctx context.Context, code string) (*finance.FinanceRecord, error)
+
+	// Unflag menghapus tanda reimbursable dari pengeluaran yang belum masuk klaim
+	Unflag(ctx context.Context, code string) (*finance.FinanceRecord, error)
+
+	// ListPending mendapatkan pengeluaran reimbursable yang belum masuk klaim, diurutkan dari yang terbaru
+	ListPending(ctx context.Context) ([]*finance.FinanceRecord, error)
+
+	// CreateClaim mengelompokkan pengeluaran reimbursable menjadi klaim baru; tanpa kode berarti seluruh yang belum diklaim
+	CreateClaim(ctx context.Context, title string, codes []string) (*finance.ReimbursementClaim, error)
+
+	// ListClaims mendapatkan seluruh klaim, diurutkan dari yang paling lama
+	ListClaims(ctx context.Context) ([]*finance.ReimbursementClaim, error)
+
+	// GetClaim mendapatkan klaim berdasarkan kode
+	GetClaim(ctx context.Context, code string) (*finance.ReimbursementClaim, error)
+
+	// Document membuat dokumen ringkasan klaim (HTML) berisi daftar pengeluaran, total, dan tautan bukti
+	Document(ctx context.Context, code string) ([]byte, error)
+
+	// MarkPaid menautkan pemasukan penggantian ke klaim dan menandai seluruh pengeluarannya lunas
+	MarkPaid(ctx context.Context, claimCode, incomeCode string) (*finance.ReimbursementClaim, error)
+
+	// LinkIncome mencari klaim yang dilunasi oleh pemasukan baru; nil jika tidak ada yang cocok
+	LinkIncome(ctx context.Context, income *finance.FinanceRecord) (*finance.ReimbursementClaim, error)
+
+	// FindClaimByRecord mencari klaim yang memuat pengeluaran atau pemasukan penggantian dengan kode tertentu
+	FindClaimByRecord(ctx context.Context, code string) (*finance.ReimbursementClaim, error)
+}
@@ -0,0 +1,15 @@
+package service
+
+import "context"
+
+// SpreadsheetRolloverService membuat spreadsheet baru setiap pergantian periode partisi (misalnya setiap tahun)
+type SpreadsheetRolloverService interface {
+	// Start menjalankan pengecekan pergantian periode secara berkala di background
+	Start()
+
+	// Stop menghentikan pengecekan berkala
+	Stop()
+
+	// Rollover memastikan spreadsheet untuk periode berjalan tersedia, mengembalikan ID dan apakah spreadsheet baru dibuat
+	Rollover(ctx context.Context) (string, bool, error)
+}
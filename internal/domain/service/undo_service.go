@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+)
+
+// ErrNothingToUndo dikembalikan jika tidak ada aksi yang dapat dibatalkan
+var ErrNothingToUndo = errors.New("tidak ada aksi yang dapat dibatalkan")
+
+// UndoService membatalkan aksi terakhir pengguna berdasarkan jurnal aksi
+type UndoService interface {
+	// Undo membatalkan aksi terakhir milik nomor pengirim yang masih dalam batas waktu
+	Undo(ctx context.Context, phone string) (*finance.JournalEntry, error)
+
+	// GetWindow mengembalikan batas waktu sebuah aksi masih dapat dibatalkan
+	GetWindow() time.Duration
+}
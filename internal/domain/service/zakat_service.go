@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+
+	"github.com/gwenziro/botopia/internal/domain/finance"
+	"github.com/gwenziro/botopia/internal/domain/money"
+)
+
+// ZakatService menyusun laporan tahunan untuk zakat dan pajak serta mengelola aturannya per ledger
+type ZakatService interface {
+	// GetSettings mendapatkan pengaturan zakat ledger context, atau pengaturan bawaan jika belum diatur
+	GetSettings(ctx context.Context) (*finance.ZakatSettings, error)
+
+	// SaveSettings menyimpan pengaturan zakat ledger context
+	SaveSettings(ctx context.Context, settings *finance.ZakatSettings) (*finance.ZakatSettings, error)
+
+	// SetGoldPrice memperbarui harga emas per gram tanpa mengubah aturan lain
+	SetGoldPrice(ctx context.Context, price money.Money) (*finance.ZakatSettings, error)
+
+	// Report menyusun laporan zakat dan pajak untuk satu tahun
+	Report(ctx context.Context, year int) (*finance.ZakatReport, error)
+}
@@ -1,12 +1,14 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 
+	"github.com/gwenziro/botopia/internal/domain/money"
 	"github.com/joho/godotenv"
 )
 
@@ -52,6 +54,9 @@ type Config struct {
 	WebAuthUsername string
 	WebAuthPassword string
 
+	// FinanceAPIKey kunci untuk mengakses REST API keuangan dari aplikasi lain dan dashboard tanpa login (kosong = hanya sesi login)
+	FinanceAPIKey string
+
 	// Aplikasi
 	DevMode       bool
 	CleanStart    bool
@@ -64,6 +69,30 @@ type Config struct {
 
 	// Google Sheets
 	GoogleSheets *GoogleSheetsConfig
+
+	// Keuangan
+	DuplicateWindowDays int
+
+	// FormSessionTimeoutMinutes batas waktu tidak aktif sebelum sesi formulir interaktif berakhir
+	FormSessionTimeoutMinutes int
+
+	// UndoWindowMinutes batas waktu aksi terakhir masih dapat dibatalkan dengan !batal
+	UndoWindowMinutes int
+
+	// Penyimpanan bukti transaksi
+	ProofStorage *ProofStorageConfig
+
+	// Pengingat transaksi tanpa bukti
+	ProofReminder *ProofReminderConfig
+
+	// Persetujuan pengeluaran besar
+	Approval *ApprovalConfig
+
+	// Tutup buku bulanan
+	PeriodClosing *PeriodClosingConfig
+
+	// Peringatan pengeluaran tidak biasa
+	Anomaly *AnomalyConfig
 }
 
 // GoogleSheetsConfig menyimpan konfigurasi untuk Google Sheets
@@ -76,6 +105,104 @@ type GoogleSheetsConfig struct {
 
 	// DriveFolderID ID folder di Google Drive untuk upload bukti
 	DriveFolderID string
+
+	// RolloverYears panjang partisi spreadsheet dalam tahun (1 = spreadsheet baru setiap tahun).
+	// Bawaan 0 (nonaktif) agar instalasi lama tidak tiba-tiba menulis peta partisi dan menyalin spreadsheet.
+	RolloverYears int
+
+	// TemplateSpreadsheetID spreadsheet yang disalin untuk partisi baru; kosong berarti menyalin spreadsheet utama
+	TemplateSpreadsheetID string
+}
+
+// ProofStorageConfig menyimpan konfigurasi penyimpanan bukti transaksi
+type ProofStorageConfig struct {
+	// Backend penyimpanan: google, local, atau s3
+	Backend string
+
+	// LocalDir direktori penyimpanan bukti untuk backend local
+	LocalDir string
+
+	// PublicURL alamat web server yang dapat diakses pengguna, dipakai untuk URL bertanda tangan
+	PublicURL string
+
+	// SigningKey kunci HMAC untuk URL bertanda tangan (dibuat otomatis jika kosong)
+	SigningKey string
+
+	// URLTTLHours masa berlaku URL bertanda tangan dalam jam (0 berarti tidak kedaluwarsa)
+	URLTTLHours int
+
+	// MaxDimension sisi terpanjang gambar bukti dalam piksel setelah diperkecil
+	MaxDimension int
+
+	// JPEGQuality kualitas encode ulang gambar bukti (1-100)
+	JPEGQuality int
+
+	// Konfigurasi object storage S3-compatible
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+	S3PublicURL string
+}
+
+// ProofReminderConfig menyimpan konfigurasi pengingat transaksi tanpa bukti
+type ProofReminderConfig struct {
+	// Enabled mengaktifkan pengiriman pengingat terjadwal
+	Enabled bool
+
+	// MinAgeDays umur minimal transaksi (hari) sebelum diingatkan
+	MinAgeDays int
+
+	// IntervalHours jarak minimal antar pengingat untuk transaksi yang sama
+	IntervalHours int
+
+	// MinAmount hanya ingatkan transaksi dengan nominal minimal ini (0 berarti semua)
+	MinAmount money.Money
+
+	// Categories hanya ingatkan transaksi pada kategori ini (kosong berarti semua)
+	Categories []string
+}
+
+// ApprovalConfig menyimpan konfigurasi persetujuan pengeluaran besar
+type ApprovalConfig struct {
+	// Threshold nominal pengeluaran yang memerlukan persetujuan (0 berarti nonaktif)
+	Threshold money.Money
+
+	// Approvers nomor WhatsApp yang dapat menyetujui atau menolak pengeluaran
+	Approvers []string
+}
+
+// PeriodClosingConfig menyimpan konfigurasi tutup buku bulanan
+type PeriodClosingConfig struct {
+	// Admins nomor WhatsApp yang dapat tutup buku dan, setelah !tutupbuku izinkan, mengubah transaksi pada periode tertutup.
+	// Jika kosong, approver pengeluaran besar dianggap admin.
+	Admins []string
+}
+
+// AnomalyConfig menyimpan konfigurasi peringatan pengeluaran tidak biasa
+type AnomalyConfig struct {
+	// Enabled mengaktifkan pemeriksaan setiap pengeluaran baru
+	Enabled bool
+
+	// MedianMultiplier kelipatan median kategori pengirim yang dianggap tidak biasa (0 berarti nonaktif)
+	MedianMultiplier float64
+
+	// MinHistory jumlah minimal riwayat kategori sebelum median dipakai
+	MinHistory int
+
+	// NewMerchantAmount nominal minimal pengeluaran pertama untuk deskripsi baru (0 berarti nonaktif)
+	NewMerchantAmount money.Money
+
+	// RunRateMultiplier kelipatan rata-rata bulanan untuk proyeksi kategori bulan berjalan (0 berarti nonaktif)
+	RunRateMultiplier float64
+
+	// RunRateMonths jumlah bulan sebelumnya sebagai dasar rata-rata bulanan
+	RunRateMonths int
+
+	// Owners nomor WhatsApp yang menerima pemberitahuan terpisah.
+	// Jika kosong, admin keuangan dianggap pemilik.
+	Owners []string
 }
 
 // NewConfig membuat instance Config baru dengan nilai default
@@ -101,11 +228,45 @@ func NewConfig() *Config {
 		WebStaticDir:    "./internal/infrastructure/web/static",
 		DataDir:         "./data",
 
+		DuplicateWindowDays:       3,
+		FormSessionTimeoutMinutes: 10,
+		UndoWindowMinutes:         30,
+
+		// Default tetap menggunakan Google Drive
+		ProofStorage: &ProofStorageConfig{
+			Backend:      "google",
+			LocalDir:     "./data/proofs",
+			MaxDimension: 1600,
+			JPEGQuality:  80,
+			S3Region:     "us-east-1",
+		},
+
+		ProofReminder: &ProofReminderConfig{
+			Enabled:       false,
+			MinAgeDays:    3,
+			IntervalHours: 24,
+		},
+
+		// Persetujuan nonaktif sampai threshold dan approver diisi
+		Approval: &ApprovalConfig{},
+
+		PeriodClosing: &PeriodClosingConfig{},
+
+		Anomaly: &AnomalyConfig{
+			Enabled:           true,
+			MedianMultiplier:  3,
+			MinHistory:        3,
+			NewMerchantAmount: money.FromInt(500000),
+			RunRateMultiplier: 1.5,
+			RunRateMonths:     3,
+		},
+
 		// Inisialisasi Google Sheets Config dengan default values
 		GoogleSheets: &GoogleSheetsConfig{
 			CredentialsFile: "./service-account.json",
 			SpreadsheetID:   "",
 			DriveFolderID:   "",
+			RolloverYears:   0,
 		},
 	}
 
@@ -160,6 +321,10 @@ func (c *Config) LoadFromEnv() {
 		c.WebAuthPassword = v
 	}
 
+	if v := os.Getenv("BOTOPIA_FINANCE_API_KEY"); v != "" {
+		c.FinanceAPIKey = v
+	}
+
 	// Aplikasi
 	if v := os.Getenv("BOTOPIA_DEV_MODE"); v != "" {
 		c.DevMode = strings.ToLower(v) == "true"
@@ -211,6 +376,199 @@ func (c *Config) LoadFromEnv() {
 	if v := os.Getenv("BOTOPIA_DRIVE_FOLDER"); v != "" {
 		c.GoogleSheets.DriveFolderID = v
 	}
+
+	if v := os.Getenv("BOTOPIA_SHEETS_ROLLOVER_YEARS"); v != "" {
+		if years, err := strconv.Atoi(v); err == nil && years >= 0 {
+			c.GoogleSheets.RolloverYears = years
+		}
+	}
+
+	if v := os.Getenv("BOTOPIA_SHEETS_TEMPLATE_ID"); v != "" {
+		c.GoogleSheets.TemplateSpreadsheetID = v
+	}
+
+	// Keuangan
+	if v := os.Getenv("BOTOPIA_DUPLICATE_WINDOW_DAYS"); v != "" {
+		if days, err := strconv.Atoi(v); err == nil && days >= 0 {
+			c.DuplicateWindowDays = days
+		}
+	}
+
+	if v := os.Getenv("BOTOPIA_FORM_SESSION_TIMEOUT_MINUTES"); v != "" {
+		if minutes, err := strconv.Atoi(v); err == nil && minutes > 0 {
+			c.FormSessionTimeoutMinutes = minutes
+		}
+	}
+
+	if v := os.Getenv("BOTOPIA_UNDO_WINDOW_MINUTES"); v != "" {
+		if minutes, err := strconv.Atoi(v); err == nil && minutes > 0 {
+			c.UndoWindowMinutes = minutes
+		}
+	}
+
+	// Pengingat transaksi tanpa bukti
+	if v := os.Getenv("BOTOPIA_PROOF_REMINDER_ENABLED"); v != "" {
+		c.ProofReminder.Enabled = strings.ToLower(v) == "true"
+	}
+
+	if v := os.Getenv("BOTOPIA_PROOF_REMINDER_DAYS"); v != "" {
+		if days, err := strconv.Atoi(v); err == nil && days >= 0 {
+			c.ProofReminder.MinAgeDays = days
+		}
+	}
+
+	if v := os.Getenv("BOTOPIA_PROOF_REMINDER_INTERVAL_HOURS"); v != "" {
+		if hours, err := strconv.Atoi(v); err == nil && hours > 0 {
+			c.ProofReminder.IntervalHours = hours
+		}
+	}
+
+	if v := os.Getenv("BOTOPIA_PROOF_REMINDER_MIN_AMOUNT"); v != "" {
+		if amount, err := money.Parse(v); err == nil && !amount.IsNegative() {
+			c.ProofReminder.MinAmount = amount
+		}
+	}
+
+	if v := os.Getenv("BOTOPIA_PROOF_REMINDER_CATEGORIES"); v != "" {
+		c.ProofReminder.Categories = nil
+		for _, category := range strings.Split(v, ",") {
+			if category = strings.TrimSpace(category); category != "" {
+				c.ProofReminder.Categories = append(c.ProofReminder.Categories, category)
+			}
+		}
+	}
+
+	// Persetujuan pengeluaran besar
+	if v := os.Getenv("BOTOPIA_APPROVAL_THRESHOLD"); v != "" {
+		if amount, err := money.Parse(v); err == nil && !amount.IsNegative() {
+			c.Approval.Threshold = amount
+		}
+	}
+
+	if v := os.Getenv("BOTOPIA_APPROVERS"); v != "" {
+		c.Approval.Approvers = nil
+		for _, phone := range strings.Split(v, ",") {
+			if phone = strings.TrimSpace(phone); phone != "" {
+				c.Approval.Approvers = append(c.Approval.Approvers, phone)
+			}
+		}
+	}
+
+	// Tutup buku bulanan
+	if v := os.Getenv("BOTOPIA_FINANCE_ADMINS"); v != "" {
+		c.PeriodClosing.Admins = nil
+		for _, phone := range strings.Split(v, ",") {
+			if phone = strings.TrimSpace(phone); phone != "" {
+				c.PeriodClosing.Admins = append(c.PeriodClosing.Admins, phone)
+			}
+		}
+	}
+
+	// Peringatan pengeluaran tidak biasa
+	if v := os.Getenv("BOTOPIA_ANOMALY_ENABLED"); v != "" {
+		c.Anomaly.Enabled = strings.ToLower(v) == "true"
+	}
+
+	if v := os.Getenv("BOTOPIA_ANOMALY_MEDIAN_MULTIPLIER"); v != "" {
+		if multiplier, err := strconv.ParseFloat(v, 64); err == nil && multiplier >= 0 {
+			c.Anomaly.MedianMultiplier = multiplier
+		}
+	}
+
+	if v := os.Getenv("BOTOPIA_ANOMALY_MIN_HISTORY"); v != "" {
+		if count, err := strconv.Atoi(v); err == nil && count > 0 {
+			c.Anomaly.MinHistory = count
+		}
+	}
+
+	if v := os.Getenv("BOTOPIA_ANOMALY_NEW_MERCHANT_AMOUNT"); v != "" {
+		if amount, err := money.Parse(v); err == nil && !amount.IsNegative() {
+			c.Anomaly.NewMerchantAmount = amount
+		}
+	}
+
+	if v := os.Getenv("BOTOPIA_ANOMALY_RUN_RATE_MULTIPLIER"); v != "" {
+		if multiplier, err := strconv.ParseFloat(v, 64); err == nil && multiplier >= 0 {
+			c.Anomaly.RunRateMultiplier = multiplier
+		}
+	}
+
+	if v := os.Getenv("BOTOPIA_ANOMALY_RUN_RATE_MONTHS"); v != "" {
+		if months, err := strconv.Atoi(v); err == nil && months > 0 {
+			c.Anomaly.RunRateMonths = months
+		}
+	}
+
+	if v := os.Getenv("BOTOPIA_ANOMALY_OWNERS"); v != "" {
+		c.Anomaly.Owners = nil
+		for _, phone := range strings.Split(v, ",") {
+			if phone = strings.TrimSpace(phone); phone != "" {
+				c.Anomaly.Owners = append(c.Anomaly.Owners, phone)
+			}
+		}
+	}
+
+	// Penyimpanan bukti transaksi
+	c.ProofStorage.LocalDir = filepath.Join(c.DataDir, "proofs")
+	c.ProofStorage.PublicURL = fmt.Sprintf("http://localhost:%d", c.WebPort)
+
+	if v := os.Getenv("BOTOPIA_PROOF_STORAGE"); v != "" {
+		c.ProofStorage.Backend = strings.ToLower(v)
+	}
+
+	if v := os.Getenv("BOTOPIA_PROOF_LOCAL_DIR"); v != "" {
+		c.ProofStorage.LocalDir = v
+	}
+
+	if v := os.Getenv("BOTOPIA_PUBLIC_URL"); v != "" {
+		c.ProofStorage.PublicURL = strings.TrimRight(v, "/")
+	}
+
+	if v := os.Getenv("BOTOPIA_PROOF_SIGNING_KEY"); v != "" {
+		c.ProofStorage.SigningKey = v
+	}
+
+	if v := os.Getenv("BOTOPIA_PROOF_URL_TTL_HOURS"); v != "" {
+		if hours, err := strconv.Atoi(v); err == nil && hours >= 0 {
+			c.ProofStorage.URLTTLHours = hours
+		}
+	}
+
+	if v := os.Getenv("BOTOPIA_PROOF_MAX_DIMENSION"); v != "" {
+		if dimension, err := strconv.Atoi(v); err == nil && dimension > 0 {
+			c.ProofStorage.MaxDimension = dimension
+		}
+	}
+
+	if v := os.Getenv("BOTOPIA_PROOF_JPEG_QUALITY"); v != "" {
+		if quality, err := strconv.Atoi(v); err == nil && quality >= 1 && quality <= 100 {
+			c.ProofStorage.JPEGQuality = quality
+		}
+	}
+
+	if v := os.Getenv("BOTOPIA_S3_ENDPOINT"); v != "" {
+		c.ProofStorage.S3Endpoint = strings.TrimRight(v, "/")
+	}
+
+	if v := os.Getenv("BOTOPIA_S3_REGION"); v != "" {
+		c.ProofStorage.S3Region = v
+	}
+
+	if v := os.Getenv("BOTOPIA_S3_BUCKET"); v != "" {
+		c.ProofStorage.S3Bucket = v
+	}
+
+	if v := os.Getenv("BOTOPIA_S3_ACCESS_KEY"); v != "" {
+		c.ProofStorage.S3AccessKey = v
+	}
+
+	if v := os.Getenv("BOTOPIA_S3_SECRET_KEY"); v != "" {
+		c.ProofStorage.S3SecretKey = v
+	}
+
+	if v := os.Getenv("BOTOPIA_S3_PUBLIC_URL"); v != "" {
+		c.ProofStorage.S3PublicURL = strings.TrimRight(v, "/")
+	}
 }
 
 // GetWebPort mengembalikan port web server
@@ -233,6 +591,10 @@ func (c *Config) EnsureDirectories() error {
 		filepath.Join(c.DataDir, "temp", "media"),
 	}
 
+	if c.ProofStorage != nil && c.ProofStorage.Backend == "local" {
+		dirs = append(dirs, c.ProofStorage.LocalDir)
+	}
+
 	for _, dir := range dirs {
 		if err := os.MkdirAll(dir, 0755); err != nil {
 			return err
@@ -93,6 +93,14 @@ func (s *Server) SetupRoutes() {
 	// Setup message controller untuk WhatsApp
 	s.container.GetMessageController().Setup()
 
+	// File bukti lokal dilindungi URL bertanda tangan, bukan login
+	if proofCtrl := s.container.GetProofController(); proofCtrl != nil {
+		s.app.Get("/proofs/:name", proofCtrl.HandleGetProof)
+	}
+
+	// REST API keuangan didaftarkan lebih dulu agar tidak melewati middleware login /api
+	s.setupFinanceAPIRoutes()
+
 	// Setup routes based on auth status
 	if authCtrl.IsAuthEnabled() {
 		s.setupAuthenticatedRoutes(dashboardCtrl, qrCtrl, authCtrl, configCtrl)
@@ -121,6 +129,22 @@ func (s *Server) Shutdown(ctx context.Context) error {
 	return s.app.ShutdownWithContext(ctx)
 }
 
+// setupFinanceAPIRoutes mengatur REST API keuangan yang dilindungi API key atau sesi login
+func (s *Server) setupFinanceAPIRoutes() {
+	financeAPI := s.container.GetFinanceAPIController()
+
+	s.app.Get("/api/finance/options", financeAPI.Authenticate, financeAPI.HandleGetOptions)
+
+	records := s.app.Group("/api/finance/records", financeAPI.Authenticate)
+	records.Get("/", financeAPI.HandleListRecords)
+	records.Post("/", financeAPI.HandleCreateRecord)
+	records.Get("/:code", financeAPI.HandleGetRecord)
+	records.Put("/:code", financeAPI.HandleUpdateRecord)
+	records.Delete("/:code", financeAPI.HandleDeleteRecord)
+	records.Post("/:code/proofs", financeAPI.HandleUploadProof)
+	records.Get("/:code/proofs/:index", financeAPI.HandleGetProof)
+}
+
 // setupAuthenticatedRoutes mengatur route dengan auth
 func (s *Server) setupAuthenticatedRoutes(
 	dashboardCtrl, qrCtrl, authCtrl, configCtrl interface{},
@@ -172,6 +196,39 @@ func (s *Server) setupAuthenticatedRoutes(
 	api.Get("/qr", qr.HandleGetQR)
 	api.Get("/config", config.HandleGetConfig)
 	api.Post("/config", config.HandleUpdateConfig)
+
+	// Aturan kategori otomatis
+	rules := s.container.GetCategoryRuleController()
+	s.app.Get("/rules", authMiddleware, rules.HandleRulesPage)
+	api.Get("/rules", rules.HandleGetRules)
+	api.Get("/rules/suggestions", rules.HandleGetSuggestions)
+	api.Post("/rules/add", rules.HandleAddRule)
+	api.Post("/rules/update", rules.HandleUpdateRule)
+	api.Post("/rules/delete", rules.HandleDeleteRule)
+	api.Post("/rules/test", rules.HandleTestRule)
+
+	// Pembatalan aksi terakhir pengguna dashboard
+	api.Post("/undo", s.container.GetUndoController().HandleUndo)
+
+	// Audit log hanya untuk pengguna yang sudah login
+	auditCtrl := s.container.GetAuditController()
+	s.app.Get("/audit", authMiddleware, auditCtrl.HandleAuditPage)
+	api.Get("/audit", auditCtrl.HandleGetAudit)
+	api.Get("/audit/verify", auditCtrl.HandleVerifyAudit)
+	api.Get("/audit/export", auditCtrl.HandleExportAudit)
+
+	// Proyeksi arus kas untuk widget dashboard
+	api.Get("/forecast", s.container.GetForecastController().HandleGetForecast)
+
+	// Laporan zakat dan pajak tahunan
+	zakatCtrl := s.container.GetZakatController()
+	s.app.Get("/laporan", authMiddleware, zakatCtrl.HandleReportPage)
+	api.Get("/zakat", zakatCtrl.HandleGetReport)
+	api.Get("/zakat/settings", zakatCtrl.HandleGetSettings)
+	api.Post("/zakat/settings", zakatCtrl.HandleSaveSettings)
+
+	// Halaman transaksi memakai REST API keuangan
+	s.app.Get("/finance", authMiddleware, s.container.GetFinanceAPIController().HandleFinancePage)
 }
 
 // setupUnauthenticatedRoutes mengatur route tanpa auth
@@ -211,6 +268,10 @@ func (s *Server) setupUnauthenticatedRoutes(
 		HandleUpdateContact(ctx *fiber.Ctx) error
 		HandleDeleteContact(ctx *fiber.Ctx) error
 		HandleSetWhitelistStatus(ctx *fiber.Ctx) error
+		HandleGetLedgers(ctx *fiber.Ctx) error
+		HandleSaveLedger(ctx *fiber.Ctx) error
+		HandleDeleteLedger(ctx *fiber.Ctx) error
+		HandleAssignLedger(ctx *fiber.Ctx) error
 	})
 
 	// Public routes
@@ -227,6 +288,21 @@ func (s *Server) setupUnauthenticatedRoutes(
 	// Tambahkan route baru untuk commands
 	s.app.Get("/commands", commands.HandleCommandsPage)
 
+	// Aturan kategori otomatis
+	rules := s.container.GetCategoryRuleController()
+	s.app.Get("/rules", rules.HandleRulesPage)
+
+	// Audit log perubahan keuangan
+	auditCtrl := s.container.GetAuditController()
+	s.app.Get("/audit", auditCtrl.HandleAuditPage)
+
+	// Laporan zakat dan pajak tahunan
+	zakatCtrl := s.container.GetZakatController()
+	s.app.Get("/laporan", zakatCtrl.HandleReportPage)
+
+	// Halaman transaksi memakai REST API keuangan
+	s.app.Get("/finance", s.container.GetFinanceAPIController().HandleFinancePage)
+
 	// API routes
 	api := s.app.Group("/api")
 	api.Get("/stats", dashboard.HandleGetStats)
@@ -239,6 +315,30 @@ func (s *Server) setupUnauthenticatedRoutes(
 	// Data Master API routes - hanya route GET yang diperlukan
 	api.Get("/data-master", dataMaster.HandleGetMasterData)
 
+	// Category rule API routes
+	api.Get("/rules", rules.HandleGetRules)
+	api.Get("/rules/suggestions", rules.HandleGetSuggestions)
+	api.Post("/rules/add", rules.HandleAddRule)
+	api.Post("/rules/update", rules.HandleUpdateRule)
+	api.Post("/rules/delete", rules.HandleDeleteRule)
+	api.Post("/rules/test", rules.HandleTestRule)
+
+	// Pembatalan aksi terakhir pengguna dashboard
+	api.Post("/undo", s.container.GetUndoController().HandleUndo)
+
+	// Audit log API routes
+	api.Get("/audit", auditCtrl.HandleGetAudit)
+	api.Get("/audit/verify", auditCtrl.HandleVerifyAudit)
+	api.Get("/audit/export", auditCtrl.HandleExportAudit)
+
+	// Proyeksi arus kas untuk widget dashboard
+	api.Get("/forecast", s.container.GetForecastController().HandleGetForecast)
+
+	// Laporan zakat dan pajak tahunan
+	api.Get("/zakat", zakatCtrl.HandleGetReport)
+	api.Get("/zakat/settings", zakatCtrl.HandleGetSettings)
+	api.Post("/zakat/settings", zakatCtrl.HandleSaveSettings)
+
 	// Contact API routes
 	api.Get("/contacts", contact.HandleGetContacts)
 	api.Get("/contacts/whitelist", contact.HandleGetWhitelistedContacts)
@@ -247,6 +347,12 @@ func (s *Server) setupUnauthenticatedRoutes(
 	api.Post("/contacts/delete", contact.HandleDeleteContact)
 	api.Post("/whitelist/status", contact.HandleSetWhitelistStatus)
 
+	// Ledger per kontak atau grup
+	api.Get("/ledgers", contact.HandleGetLedgers)
+	api.Post("/ledgers/save", contact.HandleSaveLedger)
+	api.Post("/ledgers/delete", contact.HandleDeleteLedger)
+	api.Post("/contacts/ledger", contact.HandleAssignLedger)
+
 	// Whitelist Toggle API
 	api.Get("/whitelist/status", func(ctx *fiber.Ctx) error {
 		// Dapatkan status whitelist dari MessageController
@@ -0,0 +1,138 @@
+/**
+ * Audit App
+ * Aplikasi untuk melihat, memfilter, dan mengekspor audit log keuangan
+ */
+document.addEventListener('alpine:init', () => {
+    Alpine.data('auditApp', () => ({
+        loading: false,
+        entries: [],
+        total: 0,
+        verify: null,
+        selected: null,
+        filters: {
+            action: '',
+            target: '',
+            actor: '',
+            from: '',
+            to: ''
+        },
+        actionLabels: {
+            add_income: 'Tambah Pemasukan',
+            add_expense: 'Tambah Pengeluaran',
+            upload_proof: 'Unggah Bukti',
+            replace_proof: 'Ganti Bukti',
+            update_configuration: 'Ubah Konfigurasi',
+            update_record: 'Ubah Transaksi',
+            delete_record: 'Hapus Transaksi',
+            restore_proof: 'Kembalikan Bukti',
+            close_period: 'Tutup Buku',
+            reopen_period: 'Buka Periode',
+            period_override: 'Ubah Periode Tertutup',
+            create_installment: 'Tambah Cicilan',
+            payoff_installment: 'Pelunasan Cicilan',
+            rollover_spreadsheet: 'Spreadsheet Tahun Baru',
+            create_claim: 'Ajukan Klaim Reimburse',
+            pay_claim: 'Klaim Reimburse Lunas',
+            update_zakat_settings: 'Ubah Pengaturan Zakat'
+        },
+
+        initAudit() {
+            console.log('Initializing audit app');
+            this.loading = true;
+
+            this.fetchAudit().finally(() => {
+                this.loading = false;
+            });
+        },
+
+        refreshAudit() {
+            this.loading = true;
+
+            this.fetchAudit()
+                .then(() => {
+                    showToast('success', 'Audit log berhasil diperbarui');
+                })
+                .finally(() => {
+                    this.loading = false;
+                });
+        },
+
+        applyFilters() {
+            this.loading = true;
+
+            this.fetchAudit().finally(() => {
+                this.loading = false;
+            });
+        },
+
+        resetFilters() {
+            this.filters = { action: '', target: '', actor: '', from: '', to: '' };
+            this.applyFilters();
+        },
+
+        queryString() {
+            const params = new URLSearchParams();
+            Object.entries(this.filters).forEach(([key, value]) => {
+                if (value) {
+                    params.append(key, value);
+                }
+            });
+            return params.toString();
+        },
+
+        exportURL(format) {
+            const query = this.queryString();
+            return `/api/audit/export?format=${format}` + (query ? `&${query}` : '');
+        },
+
+        fetchAudit() {
+            const query = this.queryString();
+
+            return fetch('/api/audit' + (query ? `?${query}` : ''))
+                .then(response => {
+                    return response.json().then(data => {
+                        if (!response.ok) {
+                            throw new Error(data.error || 'Failed to fetch audit log');
+                        }
+                        return data;
+                    });
+                })
+                .then(data => {
+                    this.entries = data.entries || [];
+                    this.total = data.total || 0;
+                    this.verify = data.verify || null;
+
+                    if (this.verify && !this.verify.valid) {
+                        showToast('error', 'Audit log tidak utuh, kemungkinan telah diubah');
+                    }
+                })
+                .catch(error => {
+                    console.error('Error fetching audit log:', error);
+                    showToast('error', error.message || 'Gagal memuat audit log');
+                });
+        },
+
+        showDetail(entry) {
+            this.selected = entry;
+        },
+
+        formatTime(timestamp) {
+            return new Date(timestamp).toLocaleString('id-ID');
+        },
+
+        actorLabel(actor) {
+            if (!actor) {
+                return '-';
+            }
+            const who = actor.phone || actor.name || '';
+            return who ? `${actor.channel}: ${who}` : actor.channel;
+        },
+
+        prettyJSON(value) {
+            if (value === undefined || value === null) {
+                return '-';
+            }
+            return JSON.stringify(value, null, 2);
+        }
+    }));
+});
@@ -0,0 +1,269 @@
+/**
+ * Category Rules App
+ * Aplikasi untuk mengelola aturan kategorisasi otomatis
+ */
+document.addEventListener('alpine:init', () => {
+    Alpine.data('categoryRulesApp', () => ({
+        activeTab: 'rules',
+        loading: false,
+        loadingSuggestions: false,
+        rules: [],
+        suggestions: [],
+        masterData: {
+            expenseCategories: [],
+            incomeCategories: [],
+            paymentMethods: [],
+            storageMedias: []
+        },
+        showRuleModal: false,
+        editMode: false,
+        isSaving: false,
+        isTesting: false,
+        isUndoing: false,
+        testResult: null,
+        ruleForm: {},
+
+        initRules() {
+            console.log('Initializing category rules app');
+            this.resetForm();
+            this.loading = true;
+
+            this.fetchRules().finally(() => {
+                this.loading = false;
+            });
+        },
+
+        refreshRules() {
+            this.loading = true;
+
+            this.fetchRules()
+                .then(() => {
+                    showToast('success', 'Data aturan berhasil diperbarui');
+                })
+                .finally(() => {
+                    this.loading = false;
+                });
+        },
+
+        fetchRules() {
+            return fetch('/api/rules')
+                .then(response => {
+                    if (!response.ok) {
+                        throw new Error('Failed to fetch rules');
+                    }
+                    return response.json();
+                })
+                .then(data => {
+                    this.rules = data.rules || [];
+                    if (data.masterData) {
+                        this.masterData = {
+                            expenseCategories: data.masterData.expenseCategories || [],
+                            incomeCategories: data.masterData.incomeCategories || [],
+                            paymentMethods: data.masterData.paymentMethods || [],
+                            storageMedias: data.masterData.storageMedias || []
+                        };
+                    }
+                })
+                .catch(error => {
+                    console.error('Error fetching rules:', error);
+                    showToast('error', 'Gagal memuat aturan kategori');
+                });
+        },
+
+        fetchSuggestions() {
+            this.loadingSuggestions = true;
+
+            return fetch('/api/rules/suggestions')
+                .then(response => {
+                    if (!response.ok) {
+                        throw new Error('Failed to fetch suggestions');
+                    }
+                    return response.json();
+                })
+                .then(data => {
+                    this.suggestions = data.suggestions || [];
+                })
+                .catch(error => {
+                    console.error('Error fetching suggestions:', error);
+                    showToast('error', 'Gagal memuat saran aturan');
+                })
+                .finally(() => {
+                    this.loadingSuggestions = false;
+                });
+        },
+
+        typeLabel(type) {
+            if (type === 'expense') return 'Pengeluaran';
+            if (type === 'income') return 'Pemasukan';
+            return 'Semua';
+        },
+
+        categoryOptions() {
+            if (this.ruleForm.type === 'income') {
+                return this.masterData.incomeCategories;
+            }
+            if (this.ruleForm.type === 'expense') {
+                return this.masterData.expenseCategories;
+            }
+            return [...new Set([...this.masterData.expenseCategories, ...this.masterData.incomeCategories])];
+        },
+
+        resetForm() {
+            this.ruleForm = {
+                id: '',
+                name: '',
+                keywordsText: '',
+                type: '',
+                category: '',
+                paymentMethod: '',
+                storageMedia: '',
+                priority: 0,
+                override: false,
+                isActive: true
+            };
+            this.testResult = null;
+        },
+
+        openAddModal() {
+            this.editMode = false;
+            this.resetForm();
+            this.showRuleModal = true;
+        },
+
+        editRule(rule) {
+            this.editMode = true;
+            this.resetForm();
+            this.ruleForm = {
+                id: rule.id,
+                name: rule.name,
+                keywordsText: (rule.keywords || []).join(', '),
+                type: rule.type || '',
+                category: rule.category || '',
+                paymentMethod: rule.paymentMethod || '',
+                storageMedia: rule.storageMedia || '',
+                priority: rule.priority || 0,
+                override: rule.override,
+                isActive: rule.isActive
+            };
+            this.showRuleModal = true;
+        },
+
+        useSuggestion(item) {
+            this.editMode = false;
+            this.resetForm();
+            this.ruleForm.name = item.keyword + ' → ' + item.category;
+            this.ruleForm.keywordsText = item.keyword;
+            this.ruleForm.type = item.type;
+            this.ruleForm.category = item.category;
+            this.showRuleModal = true;
+        },
+
+        buildPayload() {
+            return {
+                id: this.ruleForm.id,
+                name: this.ruleForm.name,
+                keywords: this.ruleForm.keywordsText.split(',').map(k => k.trim()).filter(k => k !== ''),
+                type: this.ruleForm.type,
+                category: this.ruleForm.category,
+                paymentMethod: this.ruleForm.type === 'income' ? '' : this.ruleForm.paymentMethod,
+                storageMedia: this.ruleForm.storageMedia,
+                priority: Number(this.ruleForm.priority) || 0,
+                override: this.ruleForm.override,
+                isActive: this.ruleForm.isActive
+            };
+        },
+
+        postJSON(url, payload) {
+            return fetch(url, {
+                method: 'POST',
+                headers: {
+                    'Content-Type': 'application/json',
+                },
+                body: JSON.stringify(payload)
+            }).then(response => response.json().then(data => {
+                if (!response.ok) {
+                    throw new Error(data.error || 'Permintaan gagal');
+                }
+                return data;
+            }));
+        },
+
+        saveRule() {
+            this.isSaving = true;
+            const url = this.editMode ? '/api/rules/update' : '/api/rules/add';
+
+            this.postJSON(url, this.buildPayload())
+                .then(() => {
+                    showToast('success', this.editMode ? 'Aturan berhasil diperbarui' : 'Aturan berhasil ditambahkan');
+                    this.showRuleModal = false;
+                    return this.fetchRules();
+                })
+                .catch(error => {
+                    showToast('error', error.message);
+                })
+                .finally(() => {
+                    this.isSaving = false;
+                });
+        },
+
+        testRule() {
+            this.isTesting = true;
+            this.testResult = null;
+
+            this.postJSON('/api/rules/test', this.buildPayload())
+                .then(data => {
+                    this.testResult = data;
+                })
+                .catch(error => {
+                    showToast('error', error.message);
+                })
+                .finally(() => {
+                    this.isTesting = false;
+                });
+        },
+
+        toggleRule(rule) {
+            const payload = Object.assign({}, rule, { isActive: !rule.isActive });
+
+            this.postJSON('/api/rules/update', payload)
+                .then(() => {
+                    showToast('success', payload.isActive ? 'Aturan diaktifkan' : 'Aturan dinonaktifkan');
+                    return this.fetchRules();
+                })
+                .catch(error => {
+                    showToast('error', error.message);
+                });
+        },
+
+        undoLast() {
+            this.isUndoing = true;
+
+            this.postJSON('/api/undo', {})
+                .then(data => {
+                    showToast('success', data.message);
+                    return this.fetchRules();
+                })
+                .catch(error => {
+                    showToast('error', error.message);
+                })
+                .finally(() => {
+                    this.isUndoing = false;
+                });
+        },
+
+        deleteRule(rule) {
+            if (!confirm(`Hapus aturan "${rule.name}"?`)) {
+                return;
+            }
+
+            this.postJSON('/api/rules/delete', { id: rule.id })
+                .then(() => {
+                    showToast('success', 'Aturan berhasil dihapus');
+                    return this.fetchRules();
+                })
+                .catch(error => {
+                    showToast('error', error.message);
+                });
+        }
+    }));
+});
@@ -20,7 +20,9 @@ document.addEventListener('alpine:init', () => {
             webHost: '0.0.0.0',
             spreadsheetId: '',
             driveFolderId: '',
-            credentialsFile: './service-account.json'
+            credentialsFile: './service-account.json',
+            rolloverYears: 1,
+            templateSheetId: ''
         },
 
         initialize() {
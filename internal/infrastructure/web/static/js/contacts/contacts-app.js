@@ -18,6 +18,15 @@ document.addEventListener('alpine:init', () => {
             notes: '',
             isActive: true
         },
+        ledgers: [],
+        showLedgerModal: false,
+        ledgerEditMode: false,
+        ledgerForm: {
+            id: '',
+            name: '',
+            spreadsheetId: '',
+            driveFolderId: ''
+        },
         
         initContacts() {
             console.log('Initializing contacts app');
@@ -26,7 +35,8 @@ document.addEventListener('alpine:init', () => {
             // Ambil data kontak dan status whitelist
             Promise.all([
                 this.fetchContacts(),
-                this.fetchWhitelistStatus()
+                this.fetchWhitelistStatus(),
+                this.fetchLedgers()
             ]).finally(() => {
                 this.loading = false;
             });
@@ -50,7 +60,8 @@ document.addEventListener('alpine:init', () => {
             
             Promise.all([
                 this.fetchContacts(),
-                this.fetchWhitelistStatus()
+                this.fetchWhitelistStatus(),
+                this.fetchLedgers()
             ]).then(() => {
                 showToast('success', 'Data kontak berhasil diperbarui');
             }).catch(error => {
@@ -256,6 +267,140 @@ document.addEventListener('alpine:init', () => {
         
         removeFromWhitelist(contact) {
             this.toggleContactStatus(contact);
+        },
+        
+        fetchLedgers() {
+            return fetch('/api/ledgers')
+                .then(response => {
+                    if (!response.ok) {
+                        throw new Error('Failed to fetch ledgers');
+                    }
+                    return response.json();
+                })
+                .then(data => {
+                    this.ledgers = data.ledgers || [];
+                })
+                .catch(error => {
+                    console.error('Error fetching ledgers:', error);
+                    showToast('error', 'Gagal memuat ledger');
+                });
+        },
+        
+        ledgerContactCount(ledger) {
+            return this.contacts.filter(contact => contact.ledgerId === ledger.id).length;
+        },
+        
+        openLedgerModal(ledger = null) {
+            this.ledgerEditMode = ledger !== null;
+            this.ledgerForm = {
+                id: ledger ? ledger.id : '',
+                name: ledger ? ledger.name : '',
+                spreadsheetId: ledger ? ledger.spreadsheetId : '',
+                driveFolderId: ledger ? (ledger.driveFolderId || '') : ''
+            };
+            this.showLedgerModal = true;
+        },
+        
+        saveLedger() {
+            if (!this.ledgerForm.id || !this.ledgerForm.name || !this.ledgerForm.spreadsheetId) {
+                showToast('error', 'ID, nama, dan ID spreadsheet ledger wajib diisi');
+                return;
+            }
+            
+            this.isSaving = true;
+            
+            fetch('/api/ledgers/save', {
+                method: 'POST',
+                headers: {
+                    'Content-Type': 'application/json',
+                },
+                body: JSON.stringify(this.ledgerForm)
+            })
+                .then(response => {
+                    if (!response.ok) {
+                        return response.json().then(data => {
+                            throw new Error(data.error || 'Failed to save ledger');
+                        });
+                    }
+                    return response.json();
+                })
+                .then(data => {
+                    showToast('success', this.ledgerEditMode ? 
+                        'Ledger berhasil diperbarui' : 
+                        'Ledger baru berhasil ditambahkan');
+                    this.showLedgerModal = false;
+                    this.fetchLedgers();
+                })
+                .catch(error => {
+                    console.error('Error saving ledger:', error);
+                    showToast('error', error.message || 'Gagal menyimpan ledger');
+                })
+                .finally(() => {
+                    this.isSaving = false;
+                });
+        },
+        
+        deleteLedger(ledger) {
+            if (!confirm(`Yakin ingin menghapus ledger ${ledger.name}? Spreadsheet-nya tidak ikut dihapus.`)) {
+                return;
+            }
+            
+            fetch('/api/ledgers/delete', {
+                method: 'POST',
+                headers: {
+                    'Content-Type': 'application/json',
+                },
+                body: JSON.stringify({ id: ledger.id })
+            })
+                .then(response => {
+                    if (!response.ok) {
+                        return response.json().then(data => {
+                            throw new Error(data.error || 'Failed to delete ledger');
+                        });
+                    }
+                    return response.json();
+                })
+                .then(data => {
+                    showToast('success', 'Ledger berhasil dihapus');
+                    this.fetchLedgers();
+                })
+                .catch(error => {
+                    console.error('Error deleting ledger:', error);
+                    showToast('error', error.message || 'Gagal menghapus ledger');
+                });
+        },
+        
+        assignLedger(contact, ledgerId) {
+            const previous = contact.ledgerId || '';
+            
+            fetch('/api/contacts/ledger', {
+                method: 'POST',
+                headers: {
+                    'Content-Type': 'application/json',
+                },
+                body: JSON.stringify({
+                    phone: contact.phone,
+                    ledgerId: ledgerId
+                })
+            })
+                .then(response => {
+                    if (!response.ok) {
+                        return response.json().then(data => {
+                            throw new Error(data.error || 'Failed to assign ledger');
+                        });
+                    }
+                    return response.json();
+                })
+                .then(data => {
+                    contact.ledgerId = ledgerId;
+                    const ledger = this.ledgers.find(item => item.id === ledgerId);
+                    showToast('success', `${contact.name || contact.phone} memakai ledger ${ledger ? ledger.name : 'utama'}`);
+                })
+                .catch(error => {
+                    console.error('Error assigning ledger:', error);
+                    showToast('error', error.message || 'Gagal mengatur ledger kontak');
+                    contact.ledgerId = previous; // Revert change on error
+                });
         }
     }));
 });
@@ -0,0 +1,75 @@
+/**
+ * Forecast Widget
+ *
+ * Menampilkan proyeksi saldo per media penyimpanan di dashboard
+ */
+
+document.addEventListener('alpine:init', () => {
+    Alpine.data('forecastWidget', () => ({
+        months: 3,
+        forecast: null,
+        isLoading: true,
+        errorMessage: '',
+
+        init() {
+            this.fetchForecast();
+        },
+
+        fetchForecast() {
+            this.isLoading = true;
+            this.errorMessage = '';
+
+            return fetch(`/api/forecast?months=${this.months}`)
+                .then(response => {
+                    return response.json().then(data => {
+                        if (!response.ok) {
+                            throw new Error(data.error || 'Failed to fetch forecast');
+                        }
+                        return data;
+                    });
+                })
+                .then(data => {
+                    this.forecast = data;
+                })
+                .catch(error => {
+                    console.error('Error fetching forecast:', error);
+                    this.errorMessage = error.message || 'Gagal memuat proyeksi arus kas';
+                })
+                .finally(() => {
+                    this.isLoading = false;
+                });
+        },
+
+        get media() {
+            return (this.forecast && this.forecast.media) || [];
+        },
+
+        get warnings() {
+            return this.media.filter(item => item.negativeDate);
+        },
+
+        get monthLabels() {
+            if (this.media.length === 0) {
+                return [];
+            }
+            return (this.media[0].monthEnds || []).map(item => this.formatMonth(item.month));
+        },
+
+        formatMoney(amount) {
+            const prefix = amount < 0 ? '-Rp ' : 'Rp ';
+            return prefix + Math.abs(Math.round(amount)).toLocaleString('id-ID');
+        },
+
+        formatMonth(timestamp) {
+            return new Date(timestamp).toLocaleDateString('id-ID', { month: 'short', year: 'numeric' });
+        },
+
+        formatDate(timestamp) {
+            return new Date(timestamp).toLocaleDateString('id-ID', { day: 'numeric', month: 'long', year: 'numeric' });
+        },
+
+        balanceClass(amount) {
+            return amount < 0 ? 'text-red-400 font-semibold' : 'text-slate-200';
+        }
+    }));
+});
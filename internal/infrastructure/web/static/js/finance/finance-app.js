@@ -0,0 +1,349 @@
+/**
+ * Finance App
+ * Aplikasi untuk menelusuri, memfilter, dan mengubah transaksi keuangan
+ */
+document.addEventListener('alpine:init', () => {
+    Alpine.data('financeApp', () => ({
+        loading: false,
+        records: [],
+        total: 0,
+        totals: { income: 0, expense: 0, net: 0 },
+        page: 1,
+        limit: 25,
+        sort: 'date',
+        order: 'desc',
+        filters: {
+            type: '',
+            category: '',
+            media: '',
+            tag: '',
+            from: '',
+            to: ''
+        },
+        options: {
+            expenseCategories: [],
+            incomeCategories: [],
+            paymentMethods: [],
+            storageMedias: [],
+            tags: [],
+            canOverride: false
+        },
+        requireApiKey: false,
+        serverHasApiKey: false,
+        apiKey: sessionStorage.getItem('financeApiKey') || '',
+        apiKeyInput: '',
+        editing: null,
+        saving: false,
+        expanded: null,
+        attachments: [],
+        loadingAttachments: false,
+
+        initFinance() {
+            console.log('Initializing finance app');
+            this.requireApiKey = this.$el.dataset.requireApiKey === 'true';
+            this.serverHasApiKey = this.$el.dataset.hasApiKey === 'true';
+            if (this.needsApiKey) {
+                return;
+            }
+            this.loading = true;
+
+            this.fetchOptions();
+            this.fetchRecords().finally(() => {
+                this.loading = false;
+            });
+        },
+
+        get needsApiKey() {
+            return this.requireApiKey && !this.apiKey;
+        },
+
+        // Tanpa login web, REST API keuangan hanya dapat dipakai dengan API key
+        saveApiKey() {
+            const key = this.apiKeyInput.trim();
+            if (!key) {
+                showToast('error', 'API key tidak boleh kosong');
+                return;
+            }
+            this.apiKey = key;
+            this.apiKeyInput = '';
+            sessionStorage.setItem('financeApiKey', key);
+            this.initFinance();
+        },
+
+        forgetApiKey() {
+            this.apiKey = '';
+            sessionStorage.removeItem('financeApiKey');
+            this.records = [];
+            this.expanded = null;
+        },
+
+        refreshRecords() {
+            this.loading = true;
+
+            this.fetchRecords()
+                .then(() => {
+                    showToast('success', 'Data transaksi berhasil diperbarui');
+                })
+                .finally(() => {
+                    this.loading = false;
+                });
+        },
+
+        applyFilters() {
+            this.page = 1;
+            this.reload();
+        },
+
+        resetFilters() {
+            this.filters = { type: '', category: '', media: '', tag: '', from: '', to: '' };
+            this.applyFilters();
+        },
+
+        filterByTag(tag) {
+            this.filters.tag = tag;
+            this.applyFilters();
+        },
+
+        reload() {
+            this.loading = true;
+            this.editing = null;
+            this.expanded = null;
+
+            this.fetchRecords().finally(() => {
+                this.loading = false;
+            });
+        },
+
+        sortBy(field) {
+            if (this.sort === field) {
+                this.order = this.order === 'asc' ? 'desc' : 'asc';
+            } else {
+                this.sort = field;
+                this.order = field === 'date' || field === 'amount' ? 'desc' : 'asc';
+            }
+            this.page = 1;
+            this.reload();
+        },
+
+        sortIcon(field) {
+            if (this.sort !== field) {
+                return 'fa-sort text-slate-500';
+            }
+            return this.order === 'asc' ? 'fa-sort-up' : 'fa-sort-down';
+        },
+
+        goToPage(page) {
+            if (page < 1 || page > this.totalPages) {
+                return;
+            }
+            this.page = page;
+            this.reload();
+        },
+
+        get totalPages() {
+            return Math.max(1, Math.ceil(this.total / this.limit));
+        },
+
+        get filterCategories() {
+            if (this.filters.type === 'income') {
+                return this.options.incomeCategories;
+            }
+            if (this.filters.type === 'expense') {
+                return this.options.expenseCategories;
+            }
+            return [...new Set([...this.options.expenseCategories, ...this.options.incomeCategories])];
+        },
+
+        queryString() {
+            const params = new URLSearchParams();
+            Object.entries(this.filters).forEach(([key, value]) => {
+                if (value) {
+                    params.append(key, value);
+                }
+            });
+            params.append('sort', this.sort);
+            params.append('order', this.order);
+            params.append('page', this.page);
+            params.append('limit', this.limit);
+            return params.toString();
+        },
+
+        authHeaders(headers = {}) {
+            return this.apiKey ? { ...headers, 'X-API-Key': this.apiKey } : headers;
+        },
+
+        fetchJSON(url, options = {}) {
+            options = { ...options, headers: this.authHeaders(options.headers) };
+            return fetch(url, options).then(response => {
+                return response.json().then(data => {
+                    if (response.status === 401 && this.requireApiKey) {
+                        this.forgetApiKey();
+                    }
+                    if (!response.ok) {
+                        const error = new Error(data.error || 'Request failed');
+                        error.status = response.status;
+                        throw error;
+                    }
+                    return data;
+                });
+            });
+        },
+
+        fetchOptions() {
+            return this.fetchJSON('/api/finance/options')
+                .then(data => {
+                    this.options = data;
+                })
+                .catch(error => {
+                    console.error('Error fetching finance options:', error);
+                    showToast('error', error.message || 'Gagal memuat data master');
+                });
+        },
+
+        fetchRecords() {
+            return this.fetchJSON('/api/finance/records?' + this.queryString())
+                .then(data => {
+                    this.records = data.records || [];
+                    this.total = data.total || 0;
+                    this.totals = data.totals || { income: 0, expense: 0, net: 0 };
+                })
+                .catch(error => {
+                    console.error('Error fetching records:', error);
+                    showToast('error', error.message || 'Gagal memuat transaksi');
+                });
+        },
+
+        startEdit(record) {
+            this.editing = {
+                code: record.code,
+                description: record.description,
+                category: record.category,
+                notes: record.notes === '-' ? '' : record.notes
+            };
+        },
+
+        cancelEdit() {
+            this.editing = null;
+        },
+
+        editCategories(record) {
+            return record.type === 'income' ? this.options.incomeCategories : this.options.expenseCategories;
+        },
+
+        saveEdit(record, override = false) {
+            if (!this.editing.description.trim()) {
+                showToast('error', 'Deskripsi tidak boleh kosong');
+                return;
+            }
+
+            this.saving = true;
+            const payload = {
+                date: record.date,
+                description: this.editing.description.trim(),
+                amount: record.amount,
+                category: this.editing.category,
+                paymentMethod: record.paymentMethod || '',
+                storageMedia: record.storageMedia,
+                notes: this.editing.notes.trim()
+            };
+
+            const query = override ? '?override=true' : '';
+            return this.fetchJSON(`/api/finance/records/${encodeURIComponent(record.code)}${query}`, {
+                method: 'PUT',
+                headers: { 'Content-Type': 'application/json' },
+                body: JSON.stringify(payload)
+            })
+                .then(updated => {
+                    Object.assign(record, updated);
+                    this.editing = null;
+                    showToast('success', `Transaksi ${record.code} berhasil diperbarui`);
+                })
+                .catch(error => {
+                    // Periode tertutup hanya dapat diubah dengan konfirmasi admin
+                    if (error.status === 409 && !override && this.options.canOverride &&
+                        confirm(`${error.message}.\n\nTetap ubah sebagai admin? Perubahan akan dicatat di audit log.`)) {
+                        return this.saveEdit(record, true);
+                    }
+                    console.error('Error updating record:', error);
+                    showToast('error', error.message || 'Gagal memperbarui transaksi');
+                })
+                .finally(() => {
+                    this.saving = false;
+                });
+        },
+
+        toggleProof(record) {
+            this.releasePreviews();
+            if (this.expanded === record.code) {
+                this.expanded = null;
+                return;
+            }
+
+            this.expanded = record.code;
+            this.loadingAttachments = true;
+
+            this.fetchJSON(`/api/finance/records/${encodeURIComponent(record.code)}`)
+                .then(detail => {
+                    // Bukti lama tanpa lampiran ditampilkan sebagai lampiran pertama
+                    this.attachments = (detail.attachments && detail.attachments.length > 0)
+                        ? detail.attachments
+                        : [{ fileName: 'bukti-awal', mimeType: 'image/jpeg', url: detail.proofUrl }];
+                    return this.loadPreviews(record.code);
+                })
+                .catch(error => {
+                    console.error('Error fetching attachments:', error);
+                    showToast('error', error.message || 'Gagal memuat bukti');
+                    this.expanded = null;
+                })
+                .finally(() => {
+                    this.loadingAttachments = false;
+                });
+        },
+
+        hasProof(record) {
+            return record.proofUrl && record.proofUrl !== '-';
+        },
+
+        proofURL(code, index) {
+            return `/api/finance/records/${encodeURIComponent(code)}/proofs/${index}`;
+        },
+
+        // Bukti diunduh lewat fetch agar API key ikut terkirim, lalu ditampilkan sebagai object URL
+        loadPreviews(code) {
+            return Promise.all(this.attachments.map((attachment, index) => {
+                return fetch(this.proofURL(code, index), { headers: this.authHeaders() })
+                    .then(response => response.ok ? response.blob() : null)
+                    .then(blob => {
+                        if (blob) {
+                            attachment.previewUrl = URL.createObjectURL(blob);
+                        }
+                    })
+                    .catch(error => console.error('Error fetching proof:', error));
+            })).then(() => {
+                this.attachments = [...this.attachments];
+            });
+        },
+
+        releasePreviews() {
+            this.attachments.forEach(attachment => {
+                if (attachment.previewUrl) {
+                    URL.revokeObjectURL(attachment.previewUrl);
+                }
+            });
+            this.attachments = [];
+        },
+
+        isImage(attachment) {
+            return (attachment.mimeType || '').startsWith('image/');
+        },
+
+        formatMoney(amount) {
+            const prefix = amount < 0 ? '-Rp ' : 'Rp ';
+            return prefix + Math.abs(amount).toLocaleString('id-ID');
+        },
+
+        formatDate(date) {
+            return new Date(date + 'T00:00:00').toLocaleDateString('id-ID', { day: '2-digit', month: 'short', year: 'numeric' });
+        }
+    }));
+});
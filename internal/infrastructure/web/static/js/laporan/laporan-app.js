@@ -0,0 +1,125 @@
+/**
+ * Laporan App
+ * Aplikasi laporan tahunan untuk zakat dan pajak beserta pengaturan nisab
+ */
+document.addEventListener('alpine:init', () => {
+    Alpine.data('laporanApp', () => ({
+        loading: false,
+        saving: false,
+        year: new Date().getFullYear(),
+        report: null,
+        totalZakat: 0,
+        settings: null,
+        excludedCategoriesText: '',
+        excludedMediasText: '',
+
+        get yearOptions() {
+            const current = new Date().getFullYear();
+            const years = [];
+            for (let year = current; year > current - 6; year--) {
+                years.push(year);
+            }
+            return years;
+        },
+
+        initLaporan() {
+            console.log('Initializing laporan app');
+            this.fetchSettings();
+            this.fetchReport();
+        },
+
+        fetchJSON(url, options) {
+            return fetch(url, options).then(response => {
+                return response.json().then(data => {
+                    if (!response.ok) {
+                        throw new Error(data.error || 'Request failed');
+                    }
+                    return data;
+                });
+            });
+        },
+
+        fetchReport() {
+            this.loading = true;
+
+            return this.fetchJSON(`/api/zakat?year=${this.year}`)
+                .then(data => {
+                    this.report = data.report;
+                    this.totalZakat = data.totalZakat || 0;
+                })
+                .catch(error => {
+                    console.error('Error fetching zakat report:', error);
+                    showToast('error', error.message || 'Gagal memuat laporan');
+                })
+                .finally(() => {
+                    this.loading = false;
+                });
+        },
+
+        fetchSettings() {
+            return this.fetchJSON('/api/zakat/settings')
+                .then(data => this.applySettings(data))
+                .catch(error => {
+                    console.error('Error fetching zakat settings:', error);
+                    showToast('error', error.message || 'Gagal memuat pengaturan zakat');
+                });
+        },
+
+        applySettings(data) {
+            this.settings = data;
+            this.excludedCategoriesText = (data.excludedIncomeCategories || []).join(', ');
+            this.excludedMediasText = (data.excludedStorageMedias || []).join(', ');
+        },
+
+        splitNames(text) {
+            return text.split(',').map(name => name.trim()).filter(name => name !== '');
+        },
+
+        saveSettings() {
+            this.saving = true;
+
+            const payload = {
+                ...this.settings,
+                goldPricePerGram: Number(this.settings.goldPricePerGram) || 0,
+                excludedIncomeCategories: this.splitNames(this.excludedCategoriesText),
+                excludedStorageMedias: this.splitNames(this.excludedMediasText)
+            };
+
+            return this.fetchJSON('/api/zakat/settings', {
+                method: 'POST',
+                headers: { 'Content-Type': 'application/json' },
+                body: JSON.stringify(payload)
+            })
+                .then(data => {
+                    this.applySettings(data);
+                    showToast('success', 'Pengaturan zakat berhasil disimpan');
+                    return this.fetchReport();
+                })
+                .catch(error => {
+                    console.error('Error saving zakat settings:', error);
+                    showToast('error', error.message || 'Gagal menyimpan pengaturan zakat');
+                })
+                .finally(() => {
+                    this.saving = false;
+                });
+        },
+
+        printReport() {
+            window.print();
+        },
+
+        formatMoney(amount) {
+            const value = Number(amount) || 0;
+            const prefix = value < 0 ? '-Rp ' : 'Rp ';
+            return prefix + Math.abs(Math.round(value)).toLocaleString('id-ID');
+        },
+
+        formatMonth(timestamp) {
+            return new Date(timestamp).toLocaleDateString('id-ID', { month: 'long', year: 'numeric' });
+        },
+
+        formatDate(timestamp) {
+            return new Date(timestamp).toLocaleDateString('id-ID', { day: 'numeric', month: 'long', year: 'numeric' });
+        }
+    }));
+});
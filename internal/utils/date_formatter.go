@@ -33,6 +33,11 @@ func FormatDateShort(date time.Time) string {
 	return fmt.Sprintf("%02d/%02d/%d", date.Day(), date.Month(), date.Year())
 }
 
+// FormatMonthID memformat bulan dan tahun dalam bahasa Indonesia (Bulan YYYY)
+func FormatMonthID(date time.Time) string {
+	return fmt.Sprintf("%s %d", IndoMonths[date.Month()-1], date.Year())
+}
+
 // ParseDateWithFormats mencoba mem-parse tanggal dengan multiple format
 func ParseDateWithFormats(dateStr string) (time.Time, error) {
 	formats := []string{